@@ -2,11 +2,13 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"quiz-app/internal/domain"
 	"quiz-app/internal/handler"
 	"quiz-app/internal/middleware"
 	"quiz-app/internal/repository"
@@ -17,6 +19,7 @@ import (
 	"syscall"
 	"time"
 
+	"firebase.google.com/go/v4/auth"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
@@ -29,18 +32,47 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	// Firebase初期化
+	// リポジトリ初期化
 	ctx := context.Background()
 	
-	// Repository と UseCase の初期化用にFirebaseClientを初期化
-	firebaseClient, err := repository.NewFirebaseClient(ctx, cfg)
-	if err != nil {
-		log.Fatalf("Failed to initialize Firebase client: %v", err)
+	// ストレージバックエンドに応じてリポジトリを初期化
+	var repos *repository.Repositories
+	var authClient *auth.Client
+	switch cfg.Storage.Backend {
+	case "memory":
+		log.Printf("Using in-memory storage backend")
+		repos = repository.NewMemoryRepositories()
+	case "sqlite", "postgres":
+		sqlClient, err := repository.NewSQLClient(ctx, cfg)
+		if err != nil {
+			log.Fatalf("Failed to initialize %s storage: %v", cfg.Storage.Backend, err)
+		}
+		defer sqlClient.Close()
+		repos = &sqlClient.Repositories
+	default:
+		firebaseClient, err := repository.NewFirebaseClient(ctx, cfg)
+		if err != nil {
+			log.Fatalf("Failed to initialize Firebase client: %v", err)
+		}
+		defer firebaseClient.Close()
+		repos = &firebaseClient.Repositories
+		authClient = firebaseClient.Auth
 	}
-	defer firebaseClient.Close()
 
 	// WebSocket マネージャー初期化
-	wsManager := websocket.NewManager()
+	wsManager := websocket.NewManager(cfg.Server.AllowedOrigins)
+
+	// 複数のサーバーで動かす場合は Backplane で WebSocket の通知と接続数を共有する
+	backplane, err := websocket.NewBackplane(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize WebSocket backplane: %v", err)
+	}
+	if backplane != nil {
+		if err := wsManager.SetBackplane(backplane, cfg.WebSocket.NodeID); err != nil {
+			log.Fatalf("Failed to initialize WebSocket backplane: %v", err)
+		}
+		defer wsManager.Close()
+	}
 
 	// Gin エンジン設定
 	if cfg.Server.Environment == "production" {
@@ -67,13 +99,26 @@ func main() {
 	router.Use(middleware.CORS(cfg))
 
 	// 認証ミドルウェア
-	authMiddleware := middleware.NewAuthMiddleware(firebaseClient.Auth)
+	authMiddleware := middleware.NewAuthMiddleware(authClient)
 	
 	// アクセスコード認証の初期化
 	accessCodeRepo := repository.NewFileAccessCodeRepository("/app/configs/access_codes.txt")
-	authUseCase := usecase.NewAuthUseCase(accessCodeRepo, firebaseClient.UserRepo)
+	authUseCase := usecase.NewAuthUseCase(accessCodeRepo, repos.UserRepo)
 	authHandler := handler.NewAuthHandler(authUseCase)
 
+	// Firebase 以外のストレージでは管理者ユーザーが存在しなければ作成しておく
+	if authClient == nil {
+		if _, err := repos.UserRepo.GetByUsername(ctx, "admin"); err != nil {
+			password, err := seedAdminPassword(cfg)
+			if err != nil {
+				log.Fatalf("Failed to seed admin user: %v", err)
+			}
+			if _, err := authUseCase.CreateUser(ctx, "admin", password, "システム管理者"); err != nil {
+				log.Fatalf("Failed to seed admin user: %v", err)
+			}
+		}
+	}
+
 	// ヘルスチェック
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -85,25 +130,44 @@ func main() {
 	// WebSocket エンドポイント
 	router.GET("/ws", middleware.WebSocketRateLimit(), authMiddleware.OptionalAuth(), func(c *gin.Context) {
 		userID, _ := middleware.GetUserID(c)
+
+		// 管理者権限チェック（admin_control は管理画面と同じ管理者セッションでのみ受け付ける）
+		isAdmin := false
+		if admin, ok := middleware.GetSessionAdmin(c, repos.UserRepo); ok {
+			isAdmin = true
+			if userID == "" {
+				userID = admin.ID
+			}
+		}
 		if userID == "" {
 			userID = "anonymous_" + generateRandomID()
 		}
 
+		// 接続時に指定したセッションも join_session と同じく参加者（管理者は存在のみ）であることを確認する
 		sessionID := c.Query("sessionId")
+		if sessionID != "" {
+			if err := wsManager.AuthorizeSessionAccess(sessionID, userID, isAdmin); err != nil {
+				switch {
+				case errors.Is(err, domain.ErrSessionNotFound):
+					c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+				case errors.Is(err, domain.ErrParticipantNotFound):
+					c.JSON(http.StatusForbidden, gin.H{"error": "Not a participant of this session"})
+				default:
+					log.Printf("Failed to authorize websocket session access: UserID=%s, SessionID=%s: %v", userID, sessionID, err)
+					c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify session access"})
+				}
+				return
+			}
+		}
+
 		displayName := c.Query("displayName")
 		if displayName == "" {
 			displayName = "匿名ユーザー"
 		}
+		// 問題の表示言語（未指定の場合は参加時に選んだ言語）
+		language := c.Query("lang")
 
-		// 管理者権限チェック
-		isAdmin := false
-		if claims, exists := middleware.GetUserClaims(c); exists {
-			if role, ok := claims["role"].(string); ok && role == "admin" {
-				isAdmin = true
-			}
-		}
-
-		err := wsManager.HandleWebSocket(c.Writer, c.Request, userID, sessionID, displayName, isAdmin)
+		err := wsManager.HandleWebSocket(c.Writer, c.Request, userID, sessionID, displayName, language, isAdmin)
 		if err != nil {
 			log.Printf("WebSocket error: %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "WebSocket connection failed"})
@@ -112,43 +176,83 @@ func main() {
 
 	// AI Service 初期化
 	aiService, err := service.NewAIService(cfg)
+	if errors.Is(err, service.ErrNoAIClients) {
+		// API キー未設定でローカル生成に切り替えるのは開発環境のみ（他の環境では AI_PROVIDER=local を明示する）
+		if cfg.Server.Environment != "development" {
+			log.Fatalf("No AI API key configured: set an API key or AI_PROVIDER=local")
+		}
+		log.Printf("No AI API key configured, falling back to local question generator (seed=%d)", cfg.AI.LocalSeed)
+		aiService, err = service.NewLocalAIService(cfg)
+	}
 	if err != nil {
-		log.Printf("Failed to initialize AI service: %v", err)
-		// テスト環境では続行
-		aiService = nil
+		log.Fatalf("Failed to initialize AI service: %v", err)
 	}
 
+	// 問題に添付する画像・音声の保存先
+	mediaStore, err := repository.NewBlobStore(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize media store: %v", err)
+	}
+	maxUploadSize := int64(cfg.Media.MaxUploadSize) << 20
+
 	// UseCase 初期化
 	sessionUseCase := usecase.NewSessionUseCase(
-		firebaseClient.SessionRepo,
-		firebaseClient.ParticipantRepo,
-		firebaseClient.UserRepo,
+		repos.SessionRepo,
+		repos.ParticipantRepo,
+		repos.UserRepo,
 		wsManager,
 	)
+	// WebSocket の join_session / subscribe_session はセッションの存在と参加者であることを確認する
+	wsManager.SetSessionAuthorizer(sessionUseCase)
 
-	userUseCase := usecase.NewUserUseCase(firebaseClient.UserRepo)
+	userUseCase := usecase.NewUserUseCase(repos.UserRepo)
+
+	// 出題タイマーはクイズ進行と管理操作で共有する
+	// 回答受付の状態（締め切り・結果処理済みか）は、Backplane が Redis の場合は同じ Redis で全サーバーと共有する
+	roundStore, err := repository.NewRoundStore(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize round store: %v", err)
+	}
+	roundScheduler := usecase.NewRoundScheduler(roundStore)
 
 	quizUseCase := usecase.NewQuizUseCase(
-		firebaseClient.SessionRepo,
-		firebaseClient.ParticipantRepo,
-		firebaseClient.QuestionRepo,
-		firebaseClient.AnswerRepo,
+		repos.SessionRepo,
+		repos.ParticipantRepo,
+		repos.QuestionRepo,
+		repos.AnswerRepo,
+		repos.QuestionBankRepo,
+		repos.TemplateRepo,
+		mediaStore,
 		aiService,
 		wsManager,
+		roundScheduler,
 	)
+	// WebSocket の answer_submit も REST と同じ経路で採点し、再接続時は現在の状態を送る
+	wsManager.SetAnswerSubmitter(quizUseCase)
+	wsManager.SetSnapshotProvider(quizUseCase)
 
 	adminUseCase := usecase.NewAdminUseCase(
-		firebaseClient.SessionRepo,
-		firebaseClient.ParticipantRepo,
-		firebaseClient.QuestionRepo,
-		firebaseClient.AnswerRepo,
+		repos.SessionRepo,
+		repos.ParticipantRepo,
+		repos.QuestionRepo,
+		repos.AnswerRepo,
 		wsManager,
+		roundScheduler,
 	)
 
+	questionBankUseCase := usecase.NewQuestionBankUseCase(repos.QuestionBankRepo, mediaStore)
+	promptTemplateUseCase := usecase.NewPromptTemplateUseCase(repos.TemplateRepo)
+	mediaUseCase := usecase.NewMediaUseCase(mediaStore, maxUploadSize)
+
 	// Handler 初期化
 	sessionHandler := handler.NewSessionHandler(sessionUseCase, userUseCase)
 	quizHandler := handler.NewQuizHandler(quizUseCase, sessionUseCase)
 	adminHandler := handler.NewAdminHandler(sessionUseCase, adminUseCase)
+	questionBankHandler := handler.NewQuestionBankHandler(questionBankUseCase)
+	promptTemplateHandler := handler.NewPromptTemplateHandler(promptTemplateUseCase)
+	mediaHandler := handler.NewMediaHandler(mediaUseCase, maxUploadSize)
+	// WebSocket の admin_control も REST と同じ UseCase で実行する
+	wsManager.SetAdminCommandExecutor(handler.NewAdminCommandHandler(sessionUseCase, adminUseCase, quizUseCase))
 
 	// API ルート
 	v1 := router.Group("/api/v1")
@@ -169,7 +273,7 @@ func main() {
 
 		// 管理者専用エンドポイント（新しい認証システム）
 		adminAuth := v1.Group("/admin")
-		adminAuth.Use(middleware.AdminSessionMiddleware(firebaseClient.UserRepo))
+		adminAuth.Use(middleware.AdminSessionMiddleware(repos.UserRepo))
 		{
 			// ユーザー管理
 			adminAuth.GET("/users", authHandler.GetUsers)
@@ -183,6 +287,8 @@ func main() {
 		v1.GET("/sessions", sessionHandler.ListAvailableSessions)
 		v1.GET("/sessions/:id/info", sessionHandler.GetSessionInfo)
 		v1.GET("/sessions/:id/status", sessionHandler.GetSessionStatus)
+		v1.GET("/sessions/:id/leaderboard", quizHandler.GetLeaderboard)
+		v1.GET("/media/:key", mediaHandler.GetMedia)
 
 		// 認証必要のエンドポイント（Firebase認証）
 		authRequired := v1.Group("")
@@ -200,11 +306,12 @@ func main() {
 
 		// 管理者専用のエンドポイント（セッションベース認証）
 		adminSession := v1.Group("/admin")
-		adminSession.Use(middleware.AdminSessionMiddleware(firebaseClient.UserRepo))
+		adminSession.Use(middleware.AdminSessionMiddleware(repos.UserRepo))
 		{
 			// セッション管理
 			adminSession.POST("/sessions", adminHandler.CreateSession)
 			adminSession.PUT("/sessions/:id/control", adminHandler.ControlSession)
+			adminSession.PUT("/sessions/:id/generation", adminHandler.UpdateGenerationSettings)
 			adminSession.DELETE("/sessions/:id", adminHandler.DeleteSession)
 			adminSession.GET("/sessions/:id/stats", adminHandler.GetSessionStats)
 			adminSession.GET("/sessions/:id/results", adminHandler.GetResults)
@@ -224,8 +331,45 @@ func main() {
 			adminSession.POST("/sessions/:id/next-round", quizHandler.NextRound)
 			adminSession.POST("/sessions/:id/skip-question", adminHandler.SkipQuestion)
 
+			// 問題の事前生成とレビュー
+			adminSession.GET("/sessions/:id/drafts", quizHandler.GetDraftQuestions)
+			adminSession.POST("/sessions/:id/drafts/generate", quizHandler.GenerateDraftQuestions)
+			adminSession.POST("/sessions/:id/drafts/reorder", quizHandler.ReorderDraftQuestions)
+			adminSession.PUT("/sessions/:id/drafts/:questionId", quizHandler.UpdateDraftQuestion)
+			adminSession.POST("/sessions/:id/drafts/:questionId/approve", quizHandler.ApproveDraftQuestion)
+			adminSession.POST("/sessions/:id/drafts/:questionId/reject", quizHandler.RejectDraftQuestion)
+			adminSession.POST("/sessions/:id/publish-question", quizHandler.PublishNextQuestion)
+			adminSession.PUT("/sessions/:id/questions/:questionId/explanation", quizHandler.UpdateQuestionExplanation)
+			adminSession.PUT("/sessions/:id/questions/:questionId/translations/:language", quizHandler.UpdateQuestionTranslation)
+			adminSession.PUT("/sessions/:id/questions/:questionId/media", quizHandler.UpdateQuestionMedia)
+
+			// 問題生成 AI の稼働状況
+			adminSession.GET("/ai/providers", quizHandler.GetAIProviderHealth)
+
 			// 敗者復活戦
 			adminSession.POST("/sessions/:id/revival", adminHandler.StartRevival)
+
+			// 問題バンク
+			adminSession.GET("/question-banks", questionBankHandler.ListBanks)
+			adminSession.POST("/question-banks", questionBankHandler.CreateBank)
+			adminSession.POST("/question-banks/import", questionBankHandler.ImportBank)
+			adminSession.GET("/question-banks/:id", questionBankHandler.GetBank)
+			adminSession.PUT("/question-banks/:id", questionBankHandler.UpdateBank)
+			adminSession.DELETE("/question-banks/:id", questionBankHandler.DeleteBank)
+			adminSession.GET("/question-banks/:id/export", questionBankHandler.ExportBank)
+
+			// 問題生成プロンプトのテンプレート
+			adminSession.GET("/prompt-templates", promptTemplateHandler.ListTemplates)
+			adminSession.POST("/prompt-templates", promptTemplateHandler.CreateTemplate)
+			adminSession.GET("/prompt-templates/default", promptTemplateHandler.GetDefaultTemplate)
+			adminSession.POST("/prompt-templates/preview", promptTemplateHandler.PreviewTemplate)
+			adminSession.GET("/prompt-templates/:id", promptTemplateHandler.GetTemplate)
+			adminSession.PUT("/prompt-templates/:id", promptTemplateHandler.UpdateTemplate)
+			adminSession.DELETE("/prompt-templates/:id", promptTemplateHandler.DeleteTemplate)
+
+			// 問題に添付する画像・音声
+			adminSession.POST("/media", mediaHandler.UploadMedia)
+			adminSession.DELETE("/media/:key", mediaHandler.DeleteMedia)
 		}
 	}
 
@@ -264,6 +408,26 @@ func main() {
 	log.Println("Server exited")
 }
 
+// devSeedAdminPassword 開発環境の memory バックエンドでのみ使う管理者の初期パスワード
+const devSeedAdminPassword = "admin123"
+
+// seedAdminPassword 作成する管理者ユーザーの初期パスワード
+// 永続化するバックエンドと開発環境以外では SEED_ADMIN_PASSWORD の指定を必須とし、既定のパスワードでは起動しない
+func seedAdminPassword(cfg *config.Config) (string, error) {
+	password := cfg.Storage.SeedAdminPassword
+	if password == "" {
+		if cfg.Storage.Backend != "memory" || cfg.Server.Environment != "development" {
+			return "", fmt.Errorf("SEED_ADMIN_PASSWORD is required for storage backend %q in %q environment", cfg.Storage.Backend, cfg.Server.Environment)
+		}
+		log.Printf("SEED_ADMIN_PASSWORD is not set, using the development default password for admin")
+		return devSeedAdminPassword, nil
+	}
+	if password == devSeedAdminPassword && cfg.Server.Environment != "development" {
+		return "", errors.New("SEED_ADMIN_PASSWORD must not be the development default outside development")
+	}
+	return password, nil
+}
+
 func generateRandomID() string {
 	// 簡単なランダムID生成（実際の実装ではUUIDなどを使用）
 	return fmt.Sprintf("anon_%d", time.Now().UnixNano())
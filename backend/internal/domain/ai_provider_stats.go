@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// AIProviderStats 問題生成プロバイダーごとの稼働状況
+type AIProviderStats struct {
+	Name             string     `json:"name"`
+	Available        bool       `json:"available"`    // 現在呼び出し対象になるか
+	CircuitState     string     `json:"circuitState"` // closed / open / half_open
+	Requests         int        `json:"requests"`     // 再試行を含む呼び出し回数
+	Successes        int        `json:"successes"`
+	Failures         int        `json:"failures"`
+	Rejections       int        `json:"rejections"`  // 応答はあったが内容の検証で不合格となった回数
+	SuccessRate      float64    `json:"successRate"` // 0〜1（呼び出し実績がなければ 0）
+	AverageLatencyMs int64      `json:"averageLatencyMs"`
+	LastLatencyMs    int64      `json:"lastLatencyMs"`
+	LastError        string     `json:"lastError,omitempty"`
+	LastErrorAt      *time.Time `json:"lastErrorAt,omitempty"`
+}
+
+// AIProviderHealth ルーティング方針とプロバイダーごとの稼働状況
+type AIProviderHealth struct {
+	Policy    string            `json:"policy"`
+	Providers []AIProviderStats `json:"providers"`
+}
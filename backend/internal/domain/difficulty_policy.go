@@ -0,0 +1,220 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// DifficultyPolicy 難易度を指定せずに出題する場合の難易度の決め方
+type DifficultyPolicy string
+
+const (
+	DifficultyPolicyRound    DifficultyPolicy = "round"    // ラウンド数で決める（従来方式）
+	DifficultyPolicyAdaptive DifficultyPolicy = "adaptive" // これまでの正解率と生存者数から、目標の脱落率に近い難易度を選ぶ
+)
+
+// DefaultTargetEliminationRate 1ラウンドで脱落（不正解）させたい割合の既定値（%）
+const DefaultTargetEliminationRate = 25
+
+// difficultyPriors 出題実績がない難易度の想定正解率
+var difficultyPriors = map[Difficulty]float64{
+	DifficultyEasy:   0.85,
+	DifficultyMedium: 0.65,
+	DifficultyHard:   0.40,
+}
+
+// priorWeight 想定正解率を何人分の回答とみなすか（回答が少ないうちは想定正解率に近い値になる）
+const priorWeight = 10
+
+// difficulties 難易度の易しい順
+var difficulties = []Difficulty{DifficultyEasy, DifficultyMedium, DifficultyHard}
+
+// DifficultySettings セッションの難易度の自動調整の設定
+type DifficultySettings struct {
+	Policy DifficultyPolicy `json:"policy" firestore:"policy"` // 未指定は round
+	// TargetEliminationRate 1ラウンドで脱落させたい割合（%、adaptive のみ。未指定は25）
+	TargetEliminationRate int `json:"targetEliminationRate" firestore:"targetEliminationRate"`
+}
+
+// Validate 方式と目標の脱落率を検証する
+func (d DifficultySettings) Validate() error {
+	switch d.Policy {
+	case "", DifficultyPolicyRound, DifficultyPolicyAdaptive:
+	default:
+		return ErrInvalidDifficultySettings
+	}
+	if d.TargetEliminationRate < 0 || d.TargetEliminationRate >= 100 {
+		return ErrInvalidDifficultySettings
+	}
+	return nil
+}
+
+// Mode 難易度の決め方を返す（未指定の場合は round）
+func (d DifficultySettings) Mode() DifficultyPolicy {
+	if d.Policy == "" {
+		return DifficultyPolicyRound
+	}
+	return d.Policy
+}
+
+// TargetRate 目標の脱落率（0〜1）
+func (d DifficultySettings) TargetRate() float64 {
+	if d.TargetEliminationRate <= 0 {
+		return DefaultTargetEliminationRate / 100.0
+	}
+	return float64(d.TargetEliminationRate) / 100
+}
+
+// RoundDifficulty ラウンド数に応じた難易度（1〜3: easy、4〜6: medium、7〜: hard）
+func RoundDifficulty(round int) Difficulty {
+	switch {
+	case round <= 3:
+		return DifficultyEasy
+	case round <= 6:
+		return DifficultyMedium
+	default:
+		return DifficultyHard
+	}
+}
+
+// RoundStat 出題済みの問題1問の正解状況
+type RoundStat struct {
+	Round      int
+	Difficulty Difficulty
+	Answered   int
+	Correct    int
+}
+
+// RoundStats 出題済みの問題と回答からラウンド順に正解状況を集計する
+// 下書き・回答のない問題と、最も近い数値が正解になる問題（難易度と正解率が対応しない）は除く
+func RoundStats(questions []*Question, answers []*Answer) []RoundStat {
+	byQuestion := make(map[string]*RoundStat)
+	stats := make([]*RoundStat, 0, len(questions))
+	for _, q := range questions {
+		if !q.IsPublished() || q.Round <= 0 || q.ClosestWins() {
+			continue
+		}
+		stat := &RoundStat{Round: q.Round, Difficulty: q.Difficulty}
+		byQuestion[q.ID] = stat
+		stats = append(stats, stat)
+	}
+
+	for _, answer := range answers {
+		// 再送された回答は既に保存済みの回答と同じものなので数えない
+		if answer.Duplicate {
+			continue
+		}
+		stat, ok := byQuestion[answer.QuestionID]
+		if !ok {
+			continue
+		}
+		stat.Answered++
+		if answer.IsCorrect {
+			stat.Correct++
+		}
+	}
+
+	result := make([]RoundStat, 0, len(stats))
+	for _, stat := range stats {
+		if stat.Answered > 0 {
+			result = append(result, *stat)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Round < result[j].Round })
+	return result
+}
+
+// DifficultyDecision 自動で決めた難易度と、その判断の根拠
+type DifficultyDecision struct {
+	Difficulty Difficulty       `json:"difficulty"`
+	Policy     DifficultyPolicy `json:"policy"`
+	Round      int              `json:"round"`
+	// 以下は adaptive のみ
+	Survivors             int                    `json:"survivors,omitempty"`             // 出題時点の回答を続けられる参加者数
+	ObservedRounds        int                    `json:"observedRounds,omitempty"`        // 集計した出題済みの問題数
+	ObservedCorrectRate   float64                `json:"observedCorrectRate,omitempty"`   // これまでの正解率（0〜1）
+	TargetEliminationRate float64                `json:"targetEliminationRate,omitempty"` // 目標の脱落率（0〜1）
+	ExpectedCorrectRates  map[Difficulty]float64 `json:"expectedCorrectRates,omitempty"`  // 難易度ごとの予想正解率
+	Reason                string                 `json:"reason"`
+}
+
+// ChooseDifficulty 設定された方式で次のラウンドの難易度を決める
+// survivors と stats は adaptive の場合のみ使う
+func ChooseDifficulty(settings DifficultySettings, round, survivors int, stats []RoundStat) *DifficultyDecision {
+	if settings.Mode() != DifficultyPolicyAdaptive {
+		difficulty := RoundDifficulty(round)
+		return &DifficultyDecision{
+			Difficulty: difficulty,
+			Policy:     DifficultyPolicyRound,
+			Round:      round,
+			Reason:     fmt.Sprintf("round %d uses %s (rounds 1-3 easy, 4-6 medium, 7+ hard)", round, difficulty),
+		}
+	}
+
+	decision := &DifficultyDecision{
+		Policy:                DifficultyPolicyAdaptive,
+		Round:                 round,
+		Survivors:             survivors,
+		ObservedRounds:        len(stats),
+		TargetEliminationRate: settings.TargetRate(),
+		ExpectedCorrectRates:  expectedCorrectRates(stats),
+	}
+	answered, correct := 0, 0
+	for _, stat := range stats {
+		answered += stat.Answered
+		correct += stat.Correct
+	}
+	if answered > 0 {
+		decision.ObservedCorrectRate = float64(correct) / float64(answered)
+	}
+
+	// 目標の正解率に最も近い難易度を選ぶ（同じ差なら易しい方）
+	// 生存者が複数いる場合、全員が脱落すると予想される難易度は避ける
+	target := 1 - decision.TargetEliminationRate
+	best, bestDiff := DifficultyEasy, math.Inf(1)
+	for _, difficulty := range difficulties {
+		rate := decision.ExpectedCorrectRates[difficulty]
+		if survivors > 1 && float64(survivors)*rate < 1 {
+			continue
+		}
+		if diff := math.Abs(rate - target); diff < bestDiff-1e-9 {
+			best, bestDiff = difficulty, diff
+		}
+	}
+	decision.Difficulty = best
+
+	history := "no previous rounds, using default correct rates"
+	if answered > 0 {
+		history = fmt.Sprintf("correct rate so far %.0f%% over %d question(s)", decision.ObservedCorrectRate*100, len(stats))
+	}
+	decision.Reason = fmt.Sprintf("%d survivor(s), %s; %s is expected to eliminate %.0f%% (target %.0f%%)",
+		survivors, history, best, (1-decision.ExpectedCorrectRates[best])*100, decision.TargetEliminationRate*100)
+	return decision
+}
+
+// expectedCorrectRates 難易度ごとの予想正解率
+// 出題実績のある難易度は実績の正解率に、ない難易度は想定の不正解率を参加者全体の出来（実績と想定の不正解数の比）で補正した値に近づける
+func expectedCorrectRates(stats []RoundStat) map[Difficulty]float64 {
+	answered := make(map[Difficulty]int)
+	correct := make(map[Difficulty]int)
+	totalIncorrect, expectedIncorrect := 0.0, 0.0
+	for _, stat := range stats {
+		prior, ok := difficultyPriors[stat.Difficulty]
+		if !ok {
+			continue
+		}
+		answered[stat.Difficulty] += stat.Answered
+		correct[stat.Difficulty] += stat.Correct
+		totalIncorrect += float64(stat.Answered - stat.Correct)
+		expectedIncorrect += (1 - prior) * float64(stat.Answered)
+	}
+	incorrectRatio := (totalIncorrect + priorWeight) / (expectedIncorrect + priorWeight)
+
+	rates := make(map[Difficulty]float64, len(difficulties))
+	for _, difficulty := range difficulties {
+		prior := 1 - math.Min((1-difficultyPriors[difficulty])*incorrectRatio, 1)
+		rates[difficulty] = (float64(correct[difficulty]) + priorWeight*prior) / (float64(answered[difficulty]) + priorWeight)
+	}
+	return rates
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChooseDifficulty(t *testing.T) {
+	adaptive := DifficultySettings{Policy: DifficultyPolicyAdaptive}
+
+	t.Run("未指定の場合は従来どおりラウンド数で難易度が決まること", func(t *testing.T) {
+		for round, want := range map[int]Difficulty{1: DifficultyEasy, 4: DifficultyMedium, 7: DifficultyHard} {
+			decision := ChooseDifficulty(DifficultySettings{}, round, 200, nil)
+			assert.Equal(t, want, decision.Difficulty)
+			assert.Equal(t, DifficultyPolicyRound, decision.Policy)
+			assert.NotEmpty(t, decision.Reason)
+		}
+	})
+
+	t.Run("出題実績がなければ想定正解率で目標の脱落率に近い難易度を選ぶこと", func(t *testing.T) {
+		decision := ChooseDifficulty(adaptive, 1, 200, nil)
+		assert.Equal(t, DifficultyEasy, decision.Difficulty)
+		assert.Equal(t, 0.25, decision.TargetEliminationRate)
+
+		decision = ChooseDifficulty(DifficultySettings{Policy: DifficultyPolicyAdaptive, TargetEliminationRate: 55}, 1, 200, nil)
+		assert.Equal(t, DifficultyHard, decision.Difficulty)
+	})
+
+	t.Run("正解率が高いラウンドが続くと難しい問題を選ぶこと", func(t *testing.T) {
+		stats := []RoundStat{
+			{Round: 1, Difficulty: DifficultyEasy, Answered: 200, Correct: 198},
+			{Round: 2, Difficulty: DifficultyMedium, Answered: 198, Correct: 190},
+		}
+		decision := ChooseDifficulty(adaptive, 7, 190, stats)
+		assert.Equal(t, DifficultyHard, decision.Difficulty)
+		assert.Equal(t, 2, decision.ObservedRounds)
+		assert.InDelta(t, 388.0/398.0, decision.ObservedCorrectRate, 1e-9)
+		assert.Contains(t, decision.Reason, "190 survivor(s)")
+	})
+
+	t.Run("正解率が低いと後半のラウンドでも易しい問題を選ぶこと", func(t *testing.T) {
+		stats := []RoundStat{
+			{Round: 1, Difficulty: DifficultyMedium, Answered: 200, Correct: 60},
+			{Round: 2, Difficulty: DifficultyHard, Answered: 60, Correct: 6},
+		}
+		decision := ChooseDifficulty(adaptive, 7, 54, stats)
+		assert.Equal(t, DifficultyEasy, decision.Difficulty)
+	})
+
+	t.Run("生存者が少ない場合は全員が脱落すると予想される難易度を避けること", func(t *testing.T) {
+		stats := []RoundStat{{Round: 1, Difficulty: DifficultyHard, Answered: 100, Correct: 20}}
+		decision := ChooseDifficulty(DifficultySettings{Policy: DifficultyPolicyAdaptive, TargetEliminationRate: 90}, 2, 2, stats)
+		assert.NotEqual(t, DifficultyHard, decision.Difficulty)
+	})
+
+	t.Run("設定の検証", func(t *testing.T) {
+		assert.NoError(t, DifficultySettings{}.Validate())
+		assert.NoError(t, adaptive.Validate())
+		assert.ErrorIs(t, DifficultySettings{Policy: "random"}.Validate(), ErrInvalidDifficultySettings)
+		assert.ErrorIs(t, DifficultySettings{Policy: DifficultyPolicyAdaptive, TargetEliminationRate: 100}.Validate(), ErrInvalidDifficultySettings)
+	})
+}
+
+func TestRoundStats(t *testing.T) {
+	easy := NewQuestion("s1", 1, "問題1", []string{"A", "B"}, 0, DifficultyEasy, "", AIProviderLocal)
+	hard := NewQuestion("s1", 2, "問題2", []string{"A", "B"}, 0, DifficultyHard, "", AIProviderLocal)
+	closest := NewQuestion("s1", 3, "問題3", nil, 0, DifficultyMedium, "", AIProviderLocal)
+	closest.SetAnswerKey(AnswerKey{Type: QuestionTypeNumeric, NumericAnswer: 100})
+	draft := NewQuestion("s1", 0, "下書き", []string{"A", "B"}, 0, DifficultyMedium, "", AIProviderLocal)
+	draft.MarkDraft(1)
+	for i, q := range []*Question{easy, hard, closest, draft} {
+		q.ID = string(rune('a' + i))
+	}
+
+	answer := func(question *Question, correct bool) *Answer {
+		a := NewAnswer("u", "s1", question.ID, 0, 0)
+		a.IsCorrect = correct
+		return a
+	}
+	duplicate := answer(easy, true)
+	duplicate.Duplicate = true
+	answers := []*Answer{
+		answer(hard, false), answer(hard, true), answer(easy, true), duplicate,
+		answer(closest, true), answer(draft, true),
+	}
+
+	stats := RoundStats([]*Question{hard, closest, draft, easy}, answers)
+	require.Len(t, stats, 2)
+	assert.Equal(t, RoundStat{Round: 1, Difficulty: DifficultyEasy, Answered: 1, Correct: 1}, stats[0])
+	assert.Equal(t, RoundStat{Round: 2, Difficulty: DifficultyHard, Answered: 2, Correct: 1}, stats[1])
+}
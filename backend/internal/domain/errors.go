@@ -25,10 +25,38 @@ var (
 	ErrQuestionNotFound  = errors.New("question not found")
 	ErrInvalidAnswer     = errors.New("invalid answer")
 	ErrAnswerExists      = errors.New("answer already exists")
+	ErrAnswerNotFound    = errors.New("answer not found")
 	ErrTimeExpired       = errors.New("answer time expired")
+	ErrInvalidQuestion   = errors.New("invalid question")
+
+	// ラウンド関連エラー
+	ErrRoundNotFound         = errors.New("round not found")
+	ErrRoundAlreadyProcessed = errors.New("round results are already processed")
+
+	// 下書きレビュー関連エラー
+	ErrQuestionAlreadyPublished = errors.New("question is already published")
+	ErrQuestionNotApproved      = errors.New("question is not approved")
+	ErrNoApprovedQuestion       = errors.New("no approved question to publish")
+
+	// 問題バンク関連エラー
+	ErrQuestionBankNotFound  = errors.New("question bank not found")
+	ErrInvalidQuestionBank   = errors.New("invalid question bank")
+	ErrQuestionBankExhausted = errors.New("no unused questions left in question bank")
+
+	// 問題生成プロンプト関連エラー
+	ErrPromptTemplateNotFound    = errors.New("prompt template not found")
+	ErrInvalidPromptTemplate     = errors.New("invalid prompt template")
+	ErrInvalidGenerationSettings = errors.New("invalid generation settings")
+	ErrInvalidDifficultySettings = errors.New("invalid difficulty settings")
+
+	// メディア関連エラー
+	ErrMediaNotFound = errors.New("media not found")
+	ErrInvalidMedia  = errors.New("invalid media")
+	ErrMediaTooLarge = errors.New("media is too large")
 
 	// AI関連エラー
 	ErrAIServiceUnavailable = errors.New("AI service is unavailable")
+	ErrDuplicateQuestion    = errors.New("could not generate a non-duplicate question")
 	ErrInvalidPrompt        = errors.New("invalid prompt")
 
 	// 一般的なエラー
@@ -0,0 +1,150 @@
+package domain
+
+import "sort"
+
+// GameMode セッションの進行方式
+type GameMode string
+
+const (
+	GameModeSurvival GameMode = "survival" // 1問でも間違えたら脱落（従来方式）
+	GameModeClassic  GameMode = "classic"  // 脱落なし、規定ラウンド終了後にスコア順で順位決定
+	GameModeLives    GameMode = "lives"    // ライフ制、ライフが0になったら脱落
+)
+
+const (
+	DefaultClassicRounds = 10
+	DefaultLives         = 3
+)
+
+// IsValidGameMode 進行方式が定義済みか判定する（空文字は survival として扱うため有効）
+func IsValidGameMode(mode GameMode) bool {
+	switch mode {
+	case "", GameModeSurvival, GameModeClassic, GameModeLives:
+		return true
+	default:
+		return false
+	}
+}
+
+// Mode 進行方式を返す（未指定の場合は survival）
+func (s Settings) Mode() GameMode {
+	if s.GameMode == "" {
+		return GameModeSurvival
+	}
+	return s.GameMode
+}
+
+// InitialLives 参加時に付与するライフ数
+// survival でもライフ数を設定すれば、その回数間違えるまで脱落しない
+func (s Settings) InitialLives() int {
+	switch s.Mode() {
+	case GameModeClassic:
+		return 0
+	case GameModeLives:
+		if s.Lives > 0 {
+			return s.Lives
+		}
+		return DefaultLives
+	default:
+		if s.Lives > 0 {
+			return s.Lives
+		}
+		return 1
+	}
+}
+
+// ClassicRounds classic モードの総ラウンド数
+func (s Settings) ClassicRounds() int {
+	if s.TotalRounds > 0 {
+		return s.TotalRounds
+	}
+	return DefaultClassicRounds
+}
+
+// RoundResult ラウンド結果（進行方式ごとに使用するフィールドが異なる）
+type RoundResult struct {
+	Mode       GameMode
+	Round      int
+	Survivors  []*Participant // ラウンド後も回答を続けられる参加者
+	Eliminated []*Participant // このラウンドで脱落した参加者
+	LostLife   []*Participant // ライフを失ったが残った参加者（survival / lives）
+	Correct    []*Participant // このラウンドの正解者
+	Rankings   []*Participant // スコア順の参加者一覧（classic）
+	// QuestionID 結果を処理する問題（この問題で既にライフを失った参加者は処理済みとみなす）
+	QuestionID string
+	// RemainingRounds 残りラウンド数（classic）
+	RemainingRounds int
+	GameOver        bool
+}
+
+// NewRoundResult 空のラウンド結果を作成
+func NewRoundResult(session *Session) *RoundResult {
+	return &RoundResult{
+		Mode:       session.Settings.Mode(),
+		Round:      session.CurrentRound,
+		Survivors:  []*Participant{},
+		Eliminated: []*Participant{},
+		LostLife:   []*Participant{},
+		Correct:    []*Participant{},
+	}
+}
+
+// Apply 参加者1人分の正誤を進行方式に従って反映する
+func (r *RoundResult) Apply(participant *Participant, correct bool) {
+	if correct {
+		r.Correct = append(r.Correct, participant)
+		r.Survivors = append(r.Survivors, participant)
+		return
+	}
+
+	switch r.Mode {
+	case GameModeClassic:
+		// 脱落なし
+		r.Survivors = append(r.Survivors, participant)
+	default:
+		// 同じ問題の結果を再処理した場合にライフを二重に減らさない
+		if r.QuestionID == "" || !participant.LostLifeFor(r.QuestionID) {
+			participant.LoseLife(r.QuestionID)
+		}
+		if participant.IsEliminated() {
+			r.Eliminated = append(r.Eliminated, participant)
+		} else {
+			r.LostLife = append(r.LostLife, participant)
+			r.Survivors = append(r.Survivors, participant)
+		}
+	}
+}
+
+// Finalize 終了判定と順位付けを行う
+func (r *RoundResult) Finalize(session *Session) {
+	r.GameOver = session.IsGameOver(len(r.Survivors))
+
+	if r.Mode == GameModeClassic {
+		r.RemainingRounds = session.Settings.ClassicRounds() - session.CurrentRound
+		if r.RemainingRounds < 0 {
+			r.RemainingRounds = 0
+		}
+		r.Rankings = RankByScore(r.Survivors)
+	}
+}
+
+// IsGameOver 回答を続けられる参加者数と現在のラウンドからゲーム終了か判定する
+func (g *Game) IsGameOver(remaining int) bool {
+	if g.Settings.Mode() == GameModeClassic {
+		return g.CurrentRound >= g.Settings.ClassicRounds() || remaining == 0
+	}
+	return remaining <= 1
+}
+
+// RankByScore スコアの降順（同点は正解数の降順）に並べた参加者一覧を返す
+func RankByScore(participants []*Participant) []*Participant {
+	ranked := make([]*Participant, len(participants))
+	copy(ranked, participants)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].CorrectAnswers > ranked[j].CorrectAnswers
+	})
+	return ranked
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newModeTestSession(settings Settings) (*Session, []*Participant) {
+	session := NewSession("モードテスト", 10, settings)
+	session.Start()
+
+	participants := make([]*Participant, 3)
+	for i, name := range []string{"A", "B", "C"} {
+		participants[i] = NewParticipant("user"+name, "session", name)
+		participants[i].Lives = settings.InitialLives()
+	}
+	return session, participants
+}
+
+func TestRoundResultSurvival(t *testing.T) {
+	t.Run("不正解者は即脱落し、生き残りが1人ならゲーム終了となること", func(t *testing.T) {
+		session, participants := newModeTestSession(Settings{})
+
+		result := NewRoundResult(session)
+		result.Apply(participants[0], true)
+		result.Apply(participants[1], false)
+		result.Apply(participants[2], false)
+		result.Finalize(session)
+
+		assert.Equal(t, GameModeSurvival, result.Mode)
+		assert.Len(t, result.Survivors, 1)
+		assert.Len(t, result.Eliminated, 2)
+		assert.True(t, participants[1].IsEliminated())
+		assert.True(t, result.GameOver)
+	})
+
+	t.Run("ライフ未設定の既存参加者も不正解で脱落すること", func(t *testing.T) {
+		session, participants := newModeTestSession(Settings{GameMode: GameModeSurvival})
+		participants[0].Lives = 0
+
+		result := NewRoundResult(session)
+		result.Apply(participants[0], false)
+
+		assert.True(t, participants[0].IsEliminated())
+	})
+}
+
+func TestRoundResultLives(t *testing.T) {
+	t.Run("ライフが残っている間は脱落しないこと", func(t *testing.T) {
+		session, participants := newModeTestSession(Settings{GameMode: GameModeLives, Lives: 2})
+		assert.Equal(t, 2, participants[0].Lives)
+
+		result := NewRoundResult(session)
+		result.Apply(participants[0], false)
+		result.Apply(participants[1], true)
+		result.Apply(participants[2], true)
+		result.Finalize(session)
+
+		assert.Len(t, result.LostLife, 1)
+		assert.Empty(t, result.Eliminated)
+		assert.Len(t, result.Survivors, 3)
+		assert.Equal(t, 1, participants[0].Lives)
+		assert.False(t, result.GameOver)
+
+		session.NextRound()
+		result = NewRoundResult(session)
+		result.Apply(participants[0], false)
+
+		assert.Len(t, result.Eliminated, 1)
+		assert.Equal(t, 0, participants[0].Lives)
+		assert.True(t, participants[0].IsEliminated())
+	})
+
+	t.Run("同じ問題の結果を2回処理してもライフは1つしか減らないこと", func(t *testing.T) {
+		session, participants := newModeTestSession(Settings{GameMode: GameModeLives, Lives: 3})
+
+		for i := 0; i < 2; i++ {
+			result := NewRoundResult(session)
+			result.QuestionID = "q1"
+			result.Apply(participants[0], false)
+			result.Apply(participants[1], true)
+			result.Finalize(session)
+
+			assert.Len(t, result.LostLife, 1)
+			assert.Empty(t, result.Eliminated)
+		}
+
+		assert.Equal(t, 2, participants[0].Lives)
+		assert.Equal(t, []string{"q1"}, participants[0].LifeLostFor)
+		assert.False(t, participants[0].IsEliminated())
+
+		// 後の問題を先に処理しても、前の問題ではライフを失う
+		session.NextRound()
+		for _, questionID := range []string{"q3", "q2"} {
+			result := NewRoundResult(session)
+			result.QuestionID = questionID
+			result.Apply(participants[0], false)
+		}
+		assert.Equal(t, []string{"q1", "q3", "q2"}, participants[0].LifeLostFor)
+		assert.True(t, participants[0].IsEliminated())
+	})
+
+	t.Run("ライフを失った時刻と問題が記録されること", func(t *testing.T) {
+		_, participants := newModeTestSession(Settings{GameMode: GameModeLives, Lives: 3})
+		participants[0].LoseLife("q1")
+		participants[0].LoseLife("q2")
+
+		assert.Equal(t, 1, participants[0].Lives)
+		assert.Len(t, participants[0].LifeLostAt, 2)
+		assert.False(t, participants[0].LifeLostAt[1].Before(participants[0].LifeLostAt[0]))
+		assert.True(t, participants[0].LostLifeFor("q2"))
+		assert.False(t, participants[0].LostLifeFor("q3"))
+		assert.Nil(t, participants[0].EliminatedAt)
+	})
+
+	t.Run("survivalでもライフ数を設定できること", func(t *testing.T) {
+		session, participants := newModeTestSession(Settings{GameMode: GameModeSurvival, Lives: 2})
+		assert.Equal(t, 2, participants[0].Lives)
+
+		result := NewRoundResult(session)
+		result.Apply(participants[0], false)
+
+		assert.Len(t, result.LostLife, 1)
+		assert.False(t, participants[0].IsEliminated())
+	})
+
+	t.Run("復活するとライフが1になること", func(t *testing.T) {
+		_, participants := newModeTestSession(Settings{GameMode: GameModeLives, Lives: 1})
+		participants[0].LoseLife("q1")
+		participants[0].Revive()
+
+		assert.Equal(t, 1, participants[0].Lives)
+		assert.True(t, participants[0].IsActive())
+	})
+}
+
+func TestRoundResultClassic(t *testing.T) {
+	t.Run("不正解でも脱落せず、規定ラウンドでゲーム終了となること", func(t *testing.T) {
+		session, participants := newModeTestSession(Settings{GameMode: GameModeClassic, TotalRounds: 2})
+		participants[1].AddCorrectAnswer(30)
+		participants[2].AddCorrectAnswer(10)
+
+		result := NewRoundResult(session)
+		result.Apply(participants[0], false)
+		result.Apply(participants[1], true)
+		result.Apply(participants[2], false)
+		result.Finalize(session)
+
+		assert.Empty(t, result.Eliminated)
+		assert.Len(t, result.Survivors, 3)
+		assert.Len(t, result.Correct, 1)
+		assert.False(t, participants[0].IsEliminated())
+		assert.False(t, result.GameOver)
+		assert.Equal(t, 1, result.RemainingRounds)
+		assert.Equal(t, "userB", result.Rankings[0].UserID)
+		assert.Equal(t, "userC", result.Rankings[1].UserID)
+
+		session.NextRound()
+		result = NewRoundResult(session)
+		for _, p := range participants {
+			result.Apply(p, true)
+		}
+		result.Finalize(session)
+
+		assert.True(t, result.GameOver)
+		assert.Equal(t, 0, result.RemainingRounds)
+	})
+}
+
+func TestSettingsDefaults(t *testing.T) {
+	t.Run("未指定の進行方式はsurvivalになること", func(t *testing.T) {
+		assert.Equal(t, GameModeSurvival, Settings{}.Mode())
+		assert.Equal(t, 1, Settings{}.InitialLives())
+		assert.Equal(t, DefaultLives, Settings{GameMode: GameModeLives}.InitialLives())
+		assert.Equal(t, DefaultClassicRounds, Settings{GameMode: GameModeClassic}.ClassicRounds())
+		assert.False(t, IsValidGameMode("unknown"))
+	})
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// DefaultLanguage 言語を指定しない場合の言語
+const DefaultLanguage = "ja"
+
+// supportedLanguages 対応する言語コードと、問題生成のプロンプトで使う言語名
+var supportedLanguages = map[string]string{
+	"ja": "日本語",
+	"en": "英語",
+	"zh": "中国語",
+	"ko": "韓国語",
+	"es": "スペイン語",
+	"fr": "フランス語",
+	"de": "ドイツ語",
+	"pt": "ポルトガル語",
+	"vi": "ベトナム語",
+}
+
+// NormalizeLanguage 言語コードを小文字の主言語部分にそろえる（"en-US" → "en"）
+func NormalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	return language
+}
+
+func IsSupportedLanguage(language string) bool {
+	_, ok := supportedLanguages[language]
+	return ok
+}
+
+// LanguageName 言語コードに対応する言語名（未対応の言語は空文字）
+func LanguageName(language string) string {
+	return supportedLanguages[language]
+}
+
+// SupportedLanguages 対応する言語コードの一覧（コード順）
+func SupportedLanguages() []string {
+	languages := make([]string, 0, len(supportedLanguages))
+	for language := range supportedLanguages {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
@@ -0,0 +1,67 @@
+package domain
+
+import "sort"
+
+// LeaderboardEntry ランキングの1行
+type LeaderboardEntry struct {
+	Rank              int               `json:"rank"`
+	UserID            string            `json:"userId"`
+	DisplayName       string            `json:"displayName"`
+	Status            ParticipantStatus `json:"status"`
+	Score             int               `json:"score"`
+	CorrectAnswers    int               `json:"correctAnswers"`
+	TotalResponseTime int               `json:"totalResponseTime"` // 正解した問題の回答時間合計（ミリ秒）
+}
+
+// BuildLeaderboard 参加者と回答からランキングを作成する
+// スコアの降順、同点は正解数の降順、さらに同じなら回答時間合計の昇順（速い方が上位）で並べる
+// すべて同じ場合は同順位とする
+func BuildLeaderboard(participants []*Participant, answers []*Answer) []*LeaderboardEntry {
+	responseTimes := make(map[string]int)
+	for _, answer := range answers {
+		if answer.IsCorrect {
+			responseTimes[answer.UserID] += answer.ResponseTime
+		}
+	}
+
+	entries := make([]*LeaderboardEntry, len(participants))
+	for i, p := range participants {
+		entries[i] = &LeaderboardEntry{
+			UserID:            p.UserID,
+			DisplayName:       p.DisplayName,
+			Status:            p.Status,
+			Score:             p.Score,
+			CorrectAnswers:    p.CorrectAnswers,
+			TotalResponseTime: responseTimes[p.UserID],
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if c := compareLeaderboardEntries(entries[i], entries[j]); c != 0 {
+			return c < 0
+		}
+		return entries[i].UserID < entries[j].UserID
+	})
+
+	for i, entry := range entries {
+		if i > 0 && compareLeaderboardEntries(entries[i-1], entry) == 0 {
+			entry.Rank = entries[i-1].Rank
+		} else {
+			entry.Rank = i + 1
+		}
+	}
+
+	return entries
+}
+
+// compareLeaderboardEntries a が上位なら負、下位なら正、同順位なら0を返す
+func compareLeaderboardEntries(a, b *LeaderboardEntry) int {
+	switch {
+	case a.Score != b.Score:
+		return b.Score - a.Score
+	case a.CorrectAnswers != b.CorrectAnswers:
+		return b.CorrectAnswers - a.CorrectAnswers
+	default:
+		return a.TotalResponseTime - b.TotalResponseTime
+	}
+}
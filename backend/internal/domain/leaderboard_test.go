@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildLeaderboard(t *testing.T) {
+	newScoredParticipant := func(userID string, score, correct int) *Participant {
+		p := NewParticipant(userID, "session", userID)
+		p.Score = score
+		p.CorrectAnswers = correct
+		return p
+	}
+
+	t.Run("スコア・正解数・回答時間の順で並び、完全に同じなら同順位になること", func(t *testing.T) {
+		participants := []*Participant{
+			newScoredParticipant("slow", 20, 2),
+			newScoredParticipant("fast", 20, 2),
+			newScoredParticipant("top", 30, 3),
+			newScoredParticipant("tie", 20, 2),
+			newScoredParticipant("last", 0, 0),
+		}
+
+		answer := func(userID string, correct bool, responseTime int) *Answer {
+			a := NewAnswer(userID, "session", "q", 0, 0)
+			a.IsCorrect = correct
+			a.ResponseTime = responseTime
+			return a
+		}
+		answers := []*Answer{
+			answer("slow", true, 3000),
+			answer("slow", true, 2000),
+			answer("fast", true, 1000),
+			answer("fast", true, 1000),
+			answer("fast", false, 9000), // 不正解の回答時間は集計しない
+			answer("tie", true, 2000),
+			answer("tie", true, 3000),
+			answer("top", true, 500),
+		}
+
+		leaderboard := BuildLeaderboard(participants, answers)
+		require.Len(t, leaderboard, 5)
+
+		var order []string
+		var ranks []int
+		for _, entry := range leaderboard {
+			order = append(order, entry.UserID)
+			ranks = append(ranks, entry.Rank)
+		}
+		assert.Equal(t, []string{"top", "fast", "slow", "tie", "last"}, order)
+		assert.Equal(t, []int{1, 2, 3, 3, 5}, ranks)
+		assert.Equal(t, 2000, leaderboard[1].TotalResponseTime)
+		assert.Equal(t, 5000, leaderboard[2].TotalResponseTime)
+	})
+
+	t.Run("参加者がいない場合は空のランキングになること", func(t *testing.T) {
+		assert.Empty(t, BuildLeaderboard(nil, nil))
+	})
+}
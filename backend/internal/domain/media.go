@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MediaType 問題に添付するメディアの種類
+type MediaType string
+
+const (
+	MediaTypeImage MediaType = "image" // 写真当てクイズなど
+	MediaTypeAudio MediaType = "audio" // イントロクイズなど
+)
+
+// MaxMediaAltTextLength 代替テキストの上限
+const MaxMediaAltTextLength = 200
+
+// mediaFormat アップロードできる形式の拡張子と種類
+type mediaFormat struct {
+	extension string
+	mediaType MediaType
+}
+
+// mediaFormats Content-Type ごとのアップロードできる形式
+var mediaFormats = map[string]mediaFormat{
+	"image/png":  {".png", MediaTypeImage},
+	"image/jpeg": {".jpg", MediaTypeImage},
+	"image/gif":  {".gif", MediaTypeImage},
+	"image/webp": {".webp", MediaTypeImage},
+	"audio/mpeg": {".mp3", MediaTypeAudio},
+	"audio/ogg":  {".ogg", MediaTypeAudio},
+	"audio/wav":  {".wav", MediaTypeAudio},
+	"audio/mp4":  {".m4a", MediaTypeAudio},
+}
+
+// mediaKeyPattern 保存先のキーの形式（UUID と拡張子。パスを含むキーは受け付けない）
+var mediaKeyPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\.[a-z0-9]+$`)
+
+// Media 問題・選択肢に添付する画像・音声
+// 実体は blob store（repository.BlobStore）に Key で保存し、URL は配信用の URL
+type Media struct {
+	Key         string    `json:"key" firestore:"key"`
+	Type        MediaType `json:"type" firestore:"type"`
+	ContentType string    `json:"contentType" firestore:"contentType"`
+	URL         string    `json:"url" firestore:"url"`
+	AltText     string    `json:"altText,omitempty" firestore:"altText"`
+}
+
+// NewMediaKey アップロードするメディアの保存先のキーを作る（対応していない形式は ErrInvalidMedia）
+func NewMediaKey(contentType string) (string, error) {
+	format, ok := mediaFormats[normalizeContentType(contentType)]
+	if !ok {
+		return "", ErrInvalidMedia
+	}
+	return uuid.New().String() + format.extension, nil
+}
+
+// MediaForKey キーから種類と Content-Type を決めたメディアを作る（URL は保存先が設定する）
+func MediaForKey(key, altText string) (*Media, error) {
+	altText = strings.TrimSpace(altText)
+	if !mediaKeyPattern.MatchString(key) || utf8.RuneCountInString(altText) > MaxMediaAltTextLength {
+		return nil, ErrInvalidMedia
+	}
+	for contentType, format := range mediaFormats {
+		if format.extension == path.Ext(key) {
+			return &Media{Key: key, Type: format.mediaType, ContentType: contentType, AltText: altText}, nil
+		}
+	}
+	return nil, ErrInvalidMedia
+}
+
+// normalizeContentType パラメータ（"; charset=..." など）と大文字小文字の違いを除く
+func normalizeContentType(contentType string) string {
+	contentType, _, _ = strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
+
+// SetMedia 問題文と選択肢のメディアを設定する
+// optionMedia は選択肢と同じ順序・同じ数（メディアのない選択肢は nil）で、すべて nil なら選択肢のメディアを外す
+func (q *Question) SetMedia(media *Media, optionMedia []*Media) error {
+	optionMedia, err := alignOptionMedia(optionMedia, len(q.Options))
+	if err != nil {
+		return err
+	}
+	q.Media = media
+	q.OptionMedia = optionMedia
+	return nil
+}
+
+// HasMedia 問題文または選択肢にメディアがあるか
+func (q *Question) HasMedia() bool {
+	return q.Media != nil || len(q.OptionMedia) > 0
+}
+
+// alignOptionMedia 選択肢のメディアの数を検証し、メディアが1つもなければ nil にする
+func alignOptionMedia(optionMedia []*Media, optionCount int) ([]*Media, error) {
+	for _, media := range optionMedia {
+		if media != nil {
+			if len(optionMedia) != optionCount {
+				return nil, ErrInvalidMedia
+			}
+			return optionMedia, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMedia(t *testing.T) {
+	t.Run("Content-Typeから保存先のキーを作り、キーから種類を判定できること", func(t *testing.T) {
+		key, err := NewMediaKey("image/JPEG; charset=binary")
+		require.NoError(t, err)
+		assert.True(t, strings.HasSuffix(key, ".jpg"))
+
+		media, err := MediaForKey(key, "  東京タワー  ")
+		require.NoError(t, err)
+		assert.Equal(t, MediaTypeImage, media.Type)
+		assert.Equal(t, "image/jpeg", media.ContentType)
+		assert.Equal(t, "東京タワー", media.AltText)
+
+		key, err = NewMediaKey("audio/mpeg")
+		require.NoError(t, err)
+		media, err = MediaForKey(key, "")
+		require.NoError(t, err)
+		assert.Equal(t, MediaTypeAudio, media.Type)
+	})
+
+	t.Run("対応していない形式・不正なキー・長すぎる代替テキストはErrInvalidMediaになること", func(t *testing.T) {
+		_, err := NewMediaKey("text/html")
+		assert.ErrorIs(t, err, ErrInvalidMedia)
+
+		for _, key := range []string{"../etc/passwd", "photo.png", "0b0d5f1e-8c1a-4c7b-9f43-2f6f3d1a9e10.exe"} {
+			_, err = MediaForKey(key, "")
+			assert.ErrorIs(t, err, ErrInvalidMedia, key)
+		}
+
+		key, err := NewMediaKey("image/png")
+		require.NoError(t, err)
+		_, err = MediaForKey(key, strings.Repeat("あ", MaxMediaAltTextLength+1))
+		assert.ErrorIs(t, err, ErrInvalidMedia)
+	})
+
+	t.Run("選択肢のメディアは選択肢と同じ数でなければならず、すべてnilなら外れること", func(t *testing.T) {
+		q := NewQuestion("s1", 1, "問題", []string{"A", "B", "C", "D"}, 0, DifficultyEasy, "", AIProviderLocal)
+		image := &Media{Key: "a.png", Type: MediaTypeImage}
+		assert.False(t, q.HasMedia())
+
+		assert.ErrorIs(t, q.SetMedia(nil, []*Media{image}), ErrInvalidMedia)
+		require.NoError(t, q.SetMedia(nil, []*Media{nil, image, nil, nil}))
+		assert.True(t, q.HasMedia())
+
+		require.NoError(t, q.SetMedia(image, []*Media{nil, nil, nil, nil}))
+		assert.Equal(t, image, q.Media)
+		assert.Nil(t, q.OptionMedia)
+	})
+
+	t.Run("下書きの編集で選択肢の数が変わると選択肢のメディアが外れること", func(t *testing.T) {
+		q := NewQuestion("s1", 1, "問題", []string{"A", "B", "C", "D"}, 0, DifficultyEasy, "", AIProviderLocal)
+		q.MarkDraft(1)
+		image := &Media{Key: "a.png", Type: MediaTypeImage}
+		require.NoError(t, q.SetMedia(image, []*Media{image, nil, nil, nil}))
+
+		require.NoError(t, q.EditWithAnswerKey("問題", []string{"A", "B"}, AnswerKey{Type: QuestionTypeTrueFalse}, DifficultyEasy, "", ""))
+		assert.Equal(t, image, q.Media)
+		assert.Nil(t, q.OptionMedia)
+	})
+
+	t.Run("バンク問題の選択肢のメディアの数が合わなければ検証エラーになり、出題時に引き継がれること", func(t *testing.T) {
+		image := &Media{Key: "a.png", Type: MediaTypeImage}
+		bq := BankQuestion{Text: "問題", Options: []string{"A", "B", "C", "D"}, Difficulty: DifficultyEasy, Media: image, OptionMedia: []*Media{image}}
+		bank := &QuestionBank{Name: "バンク", Questions: []BankQuestion{bq}}
+		assert.ErrorIs(t, bank.Validate(), ErrInvalidQuestionBank)
+
+		bq.OptionMedia = []*Media{nil, nil, image, nil}
+		question := bq.ToQuestion("s1", 1)
+		assert.Equal(t, image, question.Media)
+		assert.Equal(t, bq.OptionMedia, question.OptionMedia)
+	})
+}
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 問題生成の設定の上限
+const (
+	MaxPromptTemplateLength = 4000
+	MaxThemeLength          = 100
+	MaxAudienceLength       = 100
+	MaxReferenceTextLength  = 8000
+	MaxSessionCategories    = 30
+	MaxCategoryLength       = 50
+	MaxSessionLanguages     = 5
+)
+
+// PromptTemplate 問題生成プロンプトのテンプレート（Go の text/template 形式）
+// 使用できる変数は service.PromptVariables を参照
+type PromptTemplate struct {
+	ID          string    `json:"id" firestore:"id"`
+	Name        string    `json:"name" firestore:"name"`
+	Description string    `json:"description" firestore:"description"`
+	Body        string    `json:"body" firestore:"body"`
+	CreatedAt   time.Time `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt" firestore:"updatedAt"`
+}
+
+func NewPromptTemplate(name, description, body string) *PromptTemplate {
+	now := time.Now()
+	template := &PromptTemplate{
+		Name:        name,
+		Description: description,
+		Body:        body,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	template.Normalize()
+	return template
+}
+
+// Normalize 入力値の前後の空白を取り除く
+func (t *PromptTemplate) Normalize() {
+	t.Name = strings.TrimSpace(t.Name)
+	t.Description = strings.TrimSpace(t.Description)
+	t.Body = strings.TrimSpace(t.Body)
+}
+
+// Validate 名前と本文を検証する（テンプレートの構文は service.ValidatePromptTemplate で検証する）
+func (t *PromptTemplate) Validate() error {
+	if t.Name == "" || t.Body == "" || utf8.RuneCountInString(t.Body) > MaxPromptTemplateLength {
+		return ErrInvalidPromptTemplate
+	}
+	return nil
+}
+
+// GenerationSettings セッションごとの AI 問題生成の設定
+// すべての AI プロバイダーで共通のプロンプトに反映される
+type GenerationSettings struct {
+	Categories       []string `json:"categories" firestore:"categories"`             // 出題カテゴリ（未指定は既定の一覧）
+	Theme            string   `json:"theme" firestore:"theme"`                       // 出題テーマ（例: 会社の歴史）
+	ReferenceText    string   `json:"referenceText" firestore:"referenceText"`       // テーマの参考資料（問題はこの内容に基づいて作成される）
+	Audience         string   `json:"audience" firestore:"audience"`                 // 想定する参加者（未指定は忘年会の参加者）
+	PromptTemplateID string   `json:"promptTemplateId" firestore:"promptTemplateId"` // 使用するテンプレート（未指定は既定のテンプレート）
+	Languages        []string `json:"languages" firestore:"languages"`               // 出題する言語（先頭の言語で生成し、残りの言語に翻訳する。未指定は日本語のみ）
+	// QuestionTypes 出題する問題形式（ラウンドごとに順番に使う。未指定は単一選択のみ）
+	QuestionTypes []QuestionType `json:"questionTypes" firestore:"questionTypes"`
+}
+
+// Normalize 前後の空白を取り除き、空・重複したカテゴリ・言語・問題形式を除く
+func (g *GenerationSettings) Normalize() {
+	g.Theme = strings.TrimSpace(g.Theme)
+	g.ReferenceText = strings.TrimSpace(g.ReferenceText)
+	g.Audience = strings.TrimSpace(g.Audience)
+	g.PromptTemplateID = strings.TrimSpace(g.PromptTemplateID)
+
+	categories := make([]string, 0, len(g.Categories))
+	seen := make(map[string]bool, len(g.Categories))
+	for _, category := range g.Categories {
+		category = strings.TrimSpace(category)
+		if category == "" || seen[category] {
+			continue
+		}
+		seen[category] = true
+		categories = append(categories, category)
+	}
+	g.Categories = categories
+
+	languages := make([]string, 0, len(g.Languages))
+	for _, language := range g.Languages {
+		language = NormalizeLanguage(language)
+		if language == "" || slices.Contains(languages, language) {
+			continue
+		}
+		languages = append(languages, language)
+	}
+	g.Languages = languages
+
+	types := make([]QuestionType, 0, len(g.QuestionTypes))
+	for _, questionType := range g.QuestionTypes {
+		if questionType == "" || slices.Contains(types, questionType) {
+			continue
+		}
+		types = append(types, questionType)
+	}
+	g.QuestionTypes = types
+}
+
+// QuestionTypeForRound ラウンド（下書きは出題順）で出題する問題形式
+func (g *GenerationSettings) QuestionTypeForRound(round int) QuestionType {
+	if len(g.QuestionTypes) == 0 {
+		return QuestionTypeChoice
+	}
+	if round < 0 {
+		round = 0
+	}
+	return g.QuestionTypes[round%len(g.QuestionTypes)]
+}
+
+// PrimaryLanguage 問題を生成する言語
+func (g *GenerationSettings) PrimaryLanguage() string {
+	if len(g.Languages) == 0 {
+		return DefaultLanguage
+	}
+	return g.Languages[0]
+}
+
+// TranslationLanguages 生成した問題を翻訳する言語
+func (g *GenerationSettings) TranslationLanguages() []string {
+	if len(g.Languages) <= 1 {
+		return nil
+	}
+	return g.Languages[1:]
+}
+
+// Validate 各項目の長さと対応言語・問題形式を検証する
+func (g *GenerationSettings) Validate() error {
+	if len(g.Categories) > MaxSessionCategories ||
+		utf8.RuneCountInString(g.Theme) > MaxThemeLength ||
+		utf8.RuneCountInString(g.Audience) > MaxAudienceLength ||
+		utf8.RuneCountInString(g.ReferenceText) > MaxReferenceTextLength {
+		return ErrInvalidGenerationSettings
+	}
+	for _, category := range g.Categories {
+		if utf8.RuneCountInString(category) > MaxCategoryLength {
+			return ErrInvalidGenerationSettings
+		}
+	}
+	if len(g.Languages) > MaxSessionLanguages {
+		return ErrInvalidGenerationSettings
+	}
+	for _, language := range g.Languages {
+		if !IsSupportedLanguage(language) {
+			return ErrInvalidGenerationSettings
+		}
+	}
+	for _, questionType := range g.QuestionTypes {
+		if !IsValidQuestionType(questionType) {
+			return ErrInvalidGenerationSettings
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromptTemplateValidate(t *testing.T) {
+	t.Run("前後の空白が除かれ、名前と本文があれば有効となること", func(t *testing.T) {
+		template := NewPromptTemplate(" 社内向け ", "", " {{.Theme}}の問題 \n")
+		assert.NoError(t, template.Validate())
+		assert.Equal(t, "社内向け", template.Name)
+		assert.Equal(t, "{{.Theme}}の問題", template.Body)
+	})
+
+	t.Run("名前・本文が空、または本文が長すぎるテンプレートは不正となること", func(t *testing.T) {
+		assert.ErrorIs(t, NewPromptTemplate("", "", "本文").Validate(), ErrInvalidPromptTemplate)
+		assert.ErrorIs(t, NewPromptTemplate("名前", "", "  ").Validate(), ErrInvalidPromptTemplate)
+		assert.ErrorIs(t, NewPromptTemplate("名前", "", strings.Repeat("あ", MaxPromptTemplateLength+1)).Validate(), ErrInvalidPromptTemplate)
+	})
+}
+
+func TestGenerationSettings(t *testing.T) {
+	t.Run("空・重複したカテゴリが除かれること", func(t *testing.T) {
+		settings := GenerationSettings{
+			Categories: []string{" 社史 ", "", "製品", "社史"},
+			Theme:      " 会社の歴史 ",
+		}
+		settings.Normalize()
+		assert.NoError(t, settings.Validate())
+		assert.Equal(t, []string{"社史", "製品"}, settings.Categories)
+		assert.Equal(t, "会社の歴史", settings.Theme)
+	})
+
+	t.Run("長すぎる項目は不正となること", func(t *testing.T) {
+		reference := GenerationSettings{ReferenceText: strings.Repeat("あ", MaxReferenceTextLength+1)}
+		assert.ErrorIs(t, reference.Validate(), ErrInvalidGenerationSettings)
+
+		category := GenerationSettings{Categories: []string{strings.Repeat("あ", MaxCategoryLength+1)}}
+		assert.ErrorIs(t, category.Validate(), ErrInvalidGenerationSettings)
+	})
+
+	t.Run("先頭の言語で生成し、残りの言語に翻訳すること", func(t *testing.T) {
+		settings := GenerationSettings{Languages: []string{"EN", "ja-JP", "en-US", ""}}
+		settings.Normalize()
+		assert.NoError(t, settings.Validate())
+		assert.Equal(t, "en", settings.PrimaryLanguage())
+		assert.Equal(t, []string{"ja"}, settings.TranslationLanguages())
+
+		none := GenerationSettings{}
+		assert.Equal(t, DefaultLanguage, none.PrimaryLanguage())
+		assert.Nil(t, none.TranslationLanguages())
+
+		unsupported := GenerationSettings{Languages: []string{"ja", "xx"}}
+		unsupported.Normalize()
+		assert.ErrorIs(t, unsupported.Validate(), ErrInvalidGenerationSettings)
+	})
+}
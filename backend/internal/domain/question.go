@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -12,12 +14,16 @@ const (
 	DifficultyHard   Difficulty = "hard"
 )
 
+// MaxQuestionOptions 選択肢の上限（回答APIが受け付ける選択肢番号に合わせる）
+const MaxQuestionOptions = 4
+
 type AIProvider string
 
 const (
 	AIProviderGemini AIProvider = "gemini"
 	AIProviderOpenAI AIProvider = "openai"
 	AIProviderClaude AIProvider = "claude"
+	AIProviderLocal  AIProvider = "local" // ネットワークを使わないローカル生成
 )
 
 type Question struct {
@@ -31,17 +37,50 @@ type Question struct {
 	Category      string     `json:"category" firestore:"category"`
 	AIProvider    AIProvider `json:"aiProvider" firestore:"aiProvider"`
 	CreatedAt     time.Time  `json:"createdAt" firestore:"createdAt"`
+	OpenedAt      time.Time  `json:"openedAt" firestore:"openedAt"` // 回答受付開始時刻（サーバー時刻）
+	// BankQuestionID 問題バンクから出題した場合の元の問題ID
+	BankQuestionID string `json:"bankQuestionId,omitempty" firestore:"bankQuestionId"`
+	// Status 下書きレビューの状態（未設定の既存データは出題済みとして扱う）
+	Status   QuestionStatus `json:"status,omitempty" firestore:"status"`
+	Position int            `json:"position" firestore:"position"` // 下書きの出題順
+	// Explanation 正解の解説（問題終了時に表示、管理者が編集可能）
+	Explanation string `json:"explanation" firestore:"explanation"`
+	// Language 問題文の言語（未設定は日本語）、Translations は言語コードごとの他言語版
+	Language     string                         `json:"language,omitempty" firestore:"language"`
+	Translations map[string]QuestionTranslation `json:"translations,omitempty" firestore:"translations,omitempty"`
+	// Type 問題の形式（未設定は単一選択）。CorrectAnswer 以外の正解の意味は AnswerKey を参照
+	Type           QuestionType `json:"type,omitempty" firestore:"type"`
+	CorrectAnswers []int        `json:"correctAnswers,omitempty" firestore:"correctAnswers"`
+	NumericAnswer  float64      `json:"numericAnswer" firestore:"numericAnswer"`
+	Tolerance      float64      `json:"tolerance" firestore:"tolerance"`
+	Unit           string       `json:"unit,omitempty" firestore:"unit"`
+	// Media 問題文に添付する画像・音声、OptionMedia は選択肢ごとのメディア（Options と同じ順序、ない選択肢は nil）
+	Media       *Media   `json:"media,omitempty" firestore:"media"`
+	OptionMedia []*Media `json:"optionMedia,omitempty" firestore:"optionMedia"`
+	// ProcessedAt 結果処理（採点・ライフ反映）を行った時刻。処理済みの問題は再処理しない
+	ProcessedAt *time.Time `json:"processedAt,omitempty" firestore:"processedAt"`
+	// DifficultyDecision 難易度を自動で決めた場合の判断内容（生成時の応答用、保存しない）
+	DifficultyDecision *DifficultyDecision `json:"difficultyDecision,omitempty" firestore:"-"`
 }
 
 type Answer struct {
-	ID             string    `json:"id" firestore:"id"`
-	UserID         string    `json:"userId" firestore:"userId"`
-	SessionID      string    `json:"sessionId" firestore:"sessionId"`
-	QuestionID     string    `json:"questionId" firestore:"questionId"`
-	SelectedOption int       `json:"selectedOption" firestore:"selectedOption"`
-	IsCorrect      bool      `json:"isCorrect" firestore:"isCorrect"`
-	AnsweredAt     time.Time `json:"answeredAt" firestore:"answeredAt"`
-	ResponseTime   int       `json:"responseTime" firestore:"responseTime"` // ミリ秒
+	ID                 string    `json:"id" firestore:"id"`
+	UserID             string    `json:"userId" firestore:"userId"`
+	SessionID          string    `json:"sessionId" firestore:"sessionId"`
+	QuestionID         string    `json:"questionId" firestore:"questionId"`
+	SelectedOption     int       `json:"selectedOption" firestore:"selectedOption"`
+	IsCorrect          bool      `json:"isCorrect" firestore:"isCorrect"`
+	AnsweredAt         time.Time `json:"answeredAt" firestore:"answeredAt"`
+	ResponseTime       int       `json:"responseTime" firestore:"responseTime"`             // ミリ秒（サーバー計測）
+	ClientResponseTime int       `json:"clientResponseTime" firestore:"clientResponseTime"` // ミリ秒（クライアント申告値、診断用）
+	Points             int       `json:"points" firestore:"points"`                         // 獲得点数（不正解は0）
+	// 単一選択・○×以外の回答（SelectedOption は -1）。AnswerInput を参照
+	SelectedOptions []int    `json:"selectedOptions,omitempty" firestore:"selectedOptions"`
+	NumericValue    *float64 `json:"numericValue,omitempty" firestore:"numericValue"`
+	// Pending 締め切り時に採点する回答（最も近い回答が正解となる数値問題）
+	Pending bool `json:"pending,omitempty" firestore:"-"`
+	// Duplicate 既に回答済みだったため、新たに保存せず最初の回答を返した
+	Duplicate bool `json:"-" firestore:"-"`
 }
 
 func NewQuestion(sessionID string, round int, text string, options []string, correctAnswer int, difficulty Difficulty, category string, aiProvider AIProvider) *Question {
@@ -58,26 +97,101 @@ func NewQuestion(sessionID string, round int, text string, options []string, cor
 	}
 }
 
-func NewAnswer(userID, sessionID, questionID string, selectedOption int, responseTime int) *Answer {
+// NewAnswer 回答を作成する。clientResponseTime はクライアント申告値で診断用にのみ保持する
+func NewAnswer(userID, sessionID, questionID string, selectedOption int, clientResponseTime int) *Answer {
 	return &Answer{
-		UserID:         userID,
-		SessionID:      sessionID,
-		QuestionID:     questionID,
-		SelectedOption: selectedOption,
-		AnsweredAt:     time.Now(),
-		ResponseTime:   responseTime,
+		UserID:             userID,
+		SessionID:          sessionID,
+		QuestionID:         questionID,
+		SelectedOption:     selectedOption,
+		AnsweredAt:         time.Now(),
+		ClientResponseTime: clientResponseTime,
+	}
+}
+
+// SetInput 問題形式に応じた回答内容を設定する
+func (a *Answer) SetInput(input AnswerInput) {
+	a.SelectedOption = -1
+	if input.SelectedOption != nil {
+		a.SelectedOption = *input.SelectedOption
+	}
+	a.SelectedOptions = append([]int(nil), input.SelectedOptions...)
+	a.NumericValue = nil
+	if input.NumericValue != nil {
+		value := *input.NumericValue
+		a.NumericValue = &value
 	}
 }
 
+// Summary 回答内容を表示用の文字列にする（複数の選択肢は " / " 区切り）
+func (a *Answer) Summary() string {
+	switch {
+	case a.NumericValue != nil:
+		return FormatNumber(*a.NumericValue)
+	case len(a.SelectedOptions) > 0:
+		selected := make([]string, len(a.SelectedOptions))
+		for i, option := range a.SelectedOptions {
+			selected[i] = strconv.Itoa(option)
+		}
+		return strings.Join(selected, " / ")
+	}
+	return strconv.Itoa(a.SelectedOption)
+}
+
 func (a *Answer) SetCorrect(isCorrect bool) {
 	a.IsCorrect = isCorrect
 }
 
-func (q *Question) ValidateAnswer(selectedOption int) bool {
-	if selectedOption < 0 || selectedOption >= len(q.Options) {
-		return false
+// Score 採点方式に従って獲得点数を記録する（不正解の場合は0点）
+func (a *Answer) Score(strategy ScoringStrategy, question *Question, timeLimit time.Duration, streak int) {
+	if !a.IsCorrect {
+		a.Points = 0
+		return
+	}
+	a.Points = strategy.Points(ScoringInput{
+		Question:     question,
+		ResponseTime: time.Duration(a.ResponseTime) * time.Millisecond,
+		TimeLimit:    timeLimit,
+		Streak:       streak,
+	})
+}
+
+// MeasureResponseTime 出題時刻と回答時刻の差からサーバー側の回答時間を算出する
+func (a *Answer) MeasureResponseTime(question *Question) {
+	elapsed := a.AnsweredAt.Sub(question.AnswerOpenedAt())
+	if elapsed < 0 {
+		elapsed = 0
 	}
-	return selectedOption == q.CorrectAnswer
+	a.ResponseTime = int(elapsed.Milliseconds())
+}
+
+// Open 回答受付を開始する
+func (q *Question) Open(at time.Time) {
+	q.OpenedAt = at
+}
+
+// MarkProcessed 結果処理を行った時刻を記録する
+func (q *Question) MarkProcessed(at time.Time) {
+	q.ProcessedAt = &at
+}
+
+// IsProcessed 結果処理済みか判定する
+func (q *Question) IsProcessed() bool {
+	return q.ProcessedAt != nil
+}
+
+// AnswerOpenedAt 回答受付開始時刻を返す（記録のない旧データは作成時刻で代用）
+func (q *Question) AnswerOpenedAt() time.Time {
+	if q.OpenedAt.IsZero() {
+		return q.CreatedAt
+	}
+	return q.OpenedAt
+}
+
+// ValidateAnswer 選択肢番号で回答する問題（単一選択・○×）の正誤を判定する
+func (q *Question) ValidateAnswer(selectedOption int) bool {
+	correct, err := q.Grade(AnswerInput{SelectedOption: &selectedOption})
+	return err == nil && correct
 }
 
 func (q *Question) GetPoints() int {
@@ -91,4 +205,19 @@ func (q *Question) GetPoints() int {
 	default:
 		return 10
 	}
-}
\ No newline at end of file
+}
+
+// isValidChoiceQuestion 問題文・選択肢・正解番号・難易度が単一選択問題として出題可能な形式か判定する
+func isValidChoiceQuestion(text string, options []string, correctAnswer int, difficulty Difficulty) bool {
+	return isValidQuestion(text, options, AnswerKey{Type: QuestionTypeChoice, CorrectAnswer: correctAnswer}, difficulty)
+}
+
+// IsValidDifficulty 定義済みの難易度か判定する
+func IsValidDifficulty(difficulty Difficulty) bool {
+	switch difficulty {
+	case DifficultyEasy, DifficultyMedium, DifficultyHard:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AIProviderBank 問題バンクから出題した問題の出題元
+const AIProviderBank AIProvider = "bank"
+
+// QuestionBank 事前に作成した再利用可能な問題の集合
+type QuestionBank struct {
+	ID          string         `json:"id" firestore:"id"`
+	Name        string         `json:"name" firestore:"name"`
+	Description string         `json:"description" firestore:"description"`
+	Questions   []BankQuestion `json:"questions" firestore:"questions"`
+	CreatedAt   time.Time      `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt" firestore:"updatedAt"`
+}
+
+// BankQuestion 問題バンク内の1問
+type BankQuestion struct {
+	ID            string     `json:"id" firestore:"id"`
+	Text          string     `json:"text" firestore:"text"`
+	Options       []string   `json:"options" firestore:"options"`
+	CorrectAnswer int        `json:"correctAnswer" firestore:"correctAnswer"`
+	Difficulty    Difficulty `json:"difficulty" firestore:"difficulty"`
+	Category      string     `json:"category" firestore:"category"`
+	Tags          []string   `json:"tags" firestore:"tags"`
+	Explanation   string     `json:"explanation" firestore:"explanation"`
+	// 問題文・選択肢に添付する画像・音声（Question.Media を参照）
+	Media       *Media   `json:"media,omitempty" firestore:"media"`
+	OptionMedia []*Media `json:"optionMedia,omitempty" firestore:"optionMedia"`
+}
+
+func NewQuestionBank(name, description string, questions []BankQuestion) *QuestionBank {
+	now := time.Now()
+	bank := &QuestionBank{
+		Name:        name,
+		Description: description,
+		Questions:   questions,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	bank.Normalize()
+	return bank
+}
+
+// Normalize 問題IDの採番と入力値の整形を行う
+func (b *QuestionBank) Normalize() {
+	b.Name = strings.TrimSpace(b.Name)
+	if b.Questions == nil {
+		b.Questions = []BankQuestion{}
+	}
+	for i := range b.Questions {
+		q := &b.Questions[i]
+		if q.ID == "" {
+			q.ID = uuid.New().String()
+		}
+		q.Text = strings.TrimSpace(q.Text)
+		q.Category = strings.TrimSpace(q.Category)
+		q.Explanation = strings.TrimSpace(q.Explanation)
+		for j, option := range q.Options {
+			q.Options[j] = strings.TrimSpace(option)
+		}
+		if q.Difficulty == "" {
+			q.Difficulty = DifficultyMedium
+		}
+		if q.Tags == nil {
+			q.Tags = []string{}
+		}
+	}
+}
+
+// Validate 問題バンクの内容を検証する
+func (b *QuestionBank) Validate() error {
+	if b.Name == "" {
+		return ErrInvalidQuestionBank
+	}
+	for _, q := range b.Questions {
+		if err := q.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate 問題文・選択肢・正解番号・難易度と、選択肢のメディアの数を検証する
+func (q BankQuestion) Validate() error {
+	if !isValidChoiceQuestion(q.Text, q.Options, q.CorrectAnswer, q.Difficulty) {
+		return ErrInvalidQuestionBank
+	}
+	if _, err := alignOptionMedia(q.OptionMedia, len(q.Options)); err != nil {
+		return ErrInvalidQuestionBank
+	}
+	return nil
+}
+
+// Pick 未出題の問題を1問選ぶ（バンク内の順序で先頭から）
+// 難易度・カテゴリの指定に一致する問題を優先し、なければ条件を緩めて選ぶ
+func (b *QuestionBank) Pick(used map[string]bool, difficulty Difficulty, category string) (*BankQuestion, error) {
+	matchers := []func(q *BankQuestion) bool{
+		func(q *BankQuestion) bool {
+			return (difficulty == "" || q.Difficulty == difficulty) && (category == "" || q.Category == category)
+		},
+		func(q *BankQuestion) bool { return difficulty == "" || q.Difficulty == difficulty },
+		func(q *BankQuestion) bool { return category == "" || q.Category == category },
+		func(q *BankQuestion) bool { return true },
+	}
+
+	for _, match := range matchers {
+		for i := range b.Questions {
+			q := &b.Questions[i]
+			if !used[q.ID] && match(q) {
+				return q, nil
+			}
+		}
+	}
+	return nil, ErrQuestionBankExhausted
+}
+
+// ToQuestion バンクの問題からセッションの問題を作成する
+func (q *BankQuestion) ToQuestion(sessionID string, round int) *Question {
+	options := make([]string, len(q.Options))
+	copy(options, q.Options)
+
+	question := NewQuestion(sessionID, round, q.Text, options, q.CorrectAnswer, q.Difficulty, q.Category, AIProviderBank)
+	question.BankQuestionID = q.ID
+	question.Explanation = q.Explanation
+	question.Media = q.Media
+	question.OptionMedia = append([]*Media(nil), q.OptionMedia...)
+	return question
+}
+
+// UsedBankQuestionIDs セッションで出題済みのバンク問題IDを返す
+func UsedBankQuestionIDs(questions []*Question) map[string]bool {
+	used := make(map[string]bool)
+	for _, q := range questions {
+		if q.BankQuestionID != "" {
+			used[q.BankQuestionID] = true
+		}
+	}
+	return used
+}
@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// QuestionBankFormat 問題バンクのインポート／エクスポート形式
+type QuestionBankFormat string
+
+const (
+	QuestionBankFormatJSON QuestionBankFormat = "json"
+	QuestionBankFormatCSV  QuestionBankFormat = "csv"
+)
+
+// CSV の列名（選択肢は option1, option2, ... と必要な数だけ並べる）
+const (
+	bankCSVText          = "text"
+	bankCSVOptionPrefix  = "option"
+	bankCSVCorrectAnswer = "correctAnswer"
+	bankCSVDifficulty    = "difficulty"
+	bankCSVCategory      = "category"
+	bankCSVTags          = "tags"
+	bankCSVExplanation   = "explanation"
+	bankCSVTagSeparator  = ";"
+)
+
+// IsValidQuestionBankFormat 対応している形式か判定する
+func IsValidQuestionBankFormat(format QuestionBankFormat) bool {
+	return format == QuestionBankFormatJSON || format == QuestionBankFormatCSV
+}
+
+// ParseQuestionBank JSON または CSV から問題バンクを読み込む
+// CSV にはバンク名を含められないため、name が空でなければ JSON の名前より優先する
+func ParseQuestionBank(format QuestionBankFormat, data []byte, name string) (*QuestionBank, error) {
+	var bank QuestionBank
+	switch format {
+	case QuestionBankFormatJSON:
+		if err := json.Unmarshal(data, &bank); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuestionBank, err)
+		}
+	case QuestionBankFormatCSV:
+		questions, err := parseBankQuestionsCSV(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		bank.Questions = questions
+	default:
+		return nil, ErrInvalidQuestionBank
+	}
+
+	if name != "" {
+		bank.Name = name
+	}
+	imported := NewQuestionBank(bank.Name, bank.Description, bank.Questions)
+	if err := imported.Validate(); err != nil {
+		return nil, err
+	}
+	return imported, nil
+}
+
+// Export 問題バンクを指定形式で書き出す
+func (b *QuestionBank) Export(format QuestionBankFormat) ([]byte, error) {
+	switch format {
+	case QuestionBankFormatJSON:
+		return json.MarshalIndent(b, "", "  ")
+	case QuestionBankFormatCSV:
+		return b.exportCSV()
+	default:
+		return nil, ErrInvalidQuestionBank
+	}
+}
+
+func parseBankQuestionsCSV(r io.Reader) ([]BankQuestion, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidQuestionBank, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: header row is required", ErrInvalidQuestionBank)
+	}
+
+	// ヘッダーの列名から各列の位置を決める（列の順序は問わない）
+	columns := make(map[string]int)
+	optionColumns := make(map[int]int)
+	maxOption := 0
+	for i, header := range records[0] {
+		header = strings.TrimSpace(strings.TrimPrefix(header, "\ufeff"))
+		if strings.HasPrefix(header, bankCSVOptionPrefix) {
+			if n, err := strconv.Atoi(strings.TrimPrefix(header, bankCSVOptionPrefix)); err == nil && n > 0 {
+				optionColumns[n] = i
+				if n > maxOption {
+					maxOption = n
+				}
+				continue
+			}
+		}
+		columns[header] = i
+	}
+	if _, ok := columns[bankCSVText]; !ok {
+		return nil, fmt.Errorf("%w: missing %q column", ErrInvalidQuestionBank, bankCSVText)
+	}
+	if _, ok := columns[bankCSVCorrectAnswer]; !ok {
+		return nil, fmt.Errorf("%w: missing %q column", ErrInvalidQuestionBank, bankCSVCorrectAnswer)
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	questions := make([]BankQuestion, 0, len(records)-1)
+	for line, record := range records[1:] {
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		correct, err := strconv.Atoi(field(record, bankCSVCorrectAnswer))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: invalid correctAnswer", ErrInvalidQuestionBank, line+2)
+		}
+
+		options := []string{}
+		for n := 1; n <= maxOption; n++ {
+			i, ok := optionColumns[n]
+			if !ok || i >= len(record) || strings.TrimSpace(record[i]) == "" {
+				continue
+			}
+			options = append(options, strings.TrimSpace(record[i]))
+		}
+
+		tags := []string{}
+		for _, tag := range strings.Split(field(record, bankCSVTags), bankCSVTagSeparator) {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+
+		questions = append(questions, BankQuestion{
+			Text:          field(record, bankCSVText),
+			Options:       options,
+			CorrectAnswer: correct,
+			Difficulty:    Difficulty(field(record, bankCSVDifficulty)),
+			Category:      field(record, bankCSVCategory),
+			Tags:          tags,
+			Explanation:   field(record, bankCSVExplanation),
+		})
+	}
+
+	return questions, nil
+}
+
+func (b *QuestionBank) exportCSV() ([]byte, error) {
+	optionCount := MaxQuestionOptions
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	header := []string{bankCSVText}
+	for n := 1; n <= optionCount; n++ {
+		header = append(header, fmt.Sprintf("%s%d", bankCSVOptionPrefix, n))
+	}
+	header = append(header, bankCSVCorrectAnswer, bankCSVDifficulty, bankCSVCategory, bankCSVTags, bankCSVExplanation)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, q := range b.Questions {
+		row := []string{q.Text}
+		for n := 0; n < optionCount; n++ {
+			if n < len(q.Options) {
+				row = append(row, q.Options[n])
+			} else {
+				row = append(row, "")
+			}
+		}
+		row = append(row,
+			strconv.Itoa(q.CorrectAnswer),
+			string(q.Difficulty),
+			q.Category,
+			strings.Join(q.Tags, bankCSVTagSeparator),
+			q.Explanation,
+		)
+		if err := writer.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
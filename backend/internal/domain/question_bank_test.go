@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestQuestionBank() *QuestionBank {
+	return NewQuestionBank("社内クイズ", "", []BankQuestion{
+		{Text: "創業年は？", Options: []string{"1990", "2000", "2010", "2020"}, CorrectAnswer: 1, Difficulty: DifficultyEasy, Category: "会社", Tags: []string{"歴史"}, Explanation: "2000年に創業しました"},
+		{Text: "本社の所在地は？", Options: []string{"東京", "大阪", "名古屋", "福岡"}, CorrectAnswer: 0, Difficulty: DifficultyHard, Category: "会社"},
+		{Text: "富士山の標高は？", Options: []string{"3776m", "3000m", "4000m", "2500m"}, CorrectAnswer: 0, Category: "地理"},
+	})
+}
+
+func TestQuestionBankValidate(t *testing.T) {
+	t.Run("問題IDが採番され、難易度の既定値がmediumになること", func(t *testing.T) {
+		bank := newTestQuestionBank()
+		require.NoError(t, bank.Validate())
+		for _, q := range bank.Questions {
+			assert.NotEmpty(t, q.ID)
+		}
+		assert.Equal(t, DifficultyMedium, bank.Questions[2].Difficulty)
+	})
+
+	t.Run("正解番号が範囲外の問題は不正となること", func(t *testing.T) {
+		bank := NewQuestionBank("bank", "", []BankQuestion{
+			{Text: "問題", Options: []string{"A", "B", "C", "D"}, CorrectAnswer: 4},
+		})
+		assert.ErrorIs(t, bank.Validate(), ErrInvalidQuestionBank)
+	})
+
+	t.Run("空の選択肢や5つ以上の選択肢は不正となること", func(t *testing.T) {
+		empty := NewQuestionBank("bank", "", []BankQuestion{
+			{Text: "問題", Options: []string{"A", " "}, CorrectAnswer: 0},
+		})
+		assert.ErrorIs(t, empty.Validate(), ErrInvalidQuestionBank)
+
+		tooMany := NewQuestionBank("bank", "", []BankQuestion{
+			{Text: "問題", Options: []string{"A", "B", "C", "D", "E"}, CorrectAnswer: 0},
+		})
+		assert.ErrorIs(t, tooMany.Validate(), ErrInvalidQuestionBank)
+	})
+}
+
+func TestQuestionBankPick(t *testing.T) {
+	t.Run("出題済みの問題は選ばれず、使い切るとErrQuestionBankExhaustedになること", func(t *testing.T) {
+		bank := newTestQuestionBank()
+		used := map[string]bool{}
+		var texts []string
+		for range bank.Questions {
+			q, err := bank.Pick(used, "", "")
+			require.NoError(t, err)
+			used[q.ID] = true
+			texts = append(texts, q.Text)
+		}
+		assert.Equal(t, []string{"創業年は？", "本社の所在地は？", "富士山の標高は？"}, texts)
+
+		_, err := bank.Pick(used, "", "")
+		assert.ErrorIs(t, err, ErrQuestionBankExhausted)
+	})
+
+	t.Run("難易度とカテゴリが一致する問題を優先し、なければ条件を緩めること", func(t *testing.T) {
+		bank := newTestQuestionBank()
+
+		q, err := bank.Pick(nil, DifficultyHard, "会社")
+		require.NoError(t, err)
+		assert.Equal(t, "本社の所在地は？", q.Text)
+
+		q, err = bank.Pick(nil, DifficultyHard, "地理")
+		require.NoError(t, err)
+		assert.Equal(t, "本社の所在地は？", q.Text)
+
+		q, err = bank.Pick(map[string]bool{bank.Questions[1].ID: true}, DifficultyHard, "地理")
+		require.NoError(t, err)
+		assert.Equal(t, "富士山の標高は？", q.Text)
+	})
+
+	t.Run("出題した問題から出題済みIDを集計できること", func(t *testing.T) {
+		bank := newTestQuestionBank()
+		question := bank.Questions[0].ToQuestion("session", 1)
+		assert.Equal(t, AIProviderBank, question.AIProvider)
+		assert.Equal(t, "2000年に創業しました", question.Explanation)
+
+		used := UsedBankQuestionIDs([]*Question{question, NewQuestion("session", 2, "AI問題", []string{"A", "B"}, 0, DifficultyEasy, "", AIProviderGemini)})
+		assert.Equal(t, map[string]bool{bank.Questions[0].ID: true}, used)
+	})
+}
+
+func TestQuestionBankFormat(t *testing.T) {
+	t.Run("CSVでエクスポートした内容を再インポートできること", func(t *testing.T) {
+		bank := newTestQuestionBank()
+		data, err := bank.Export(QuestionBankFormatCSV)
+		require.NoError(t, err)
+
+		imported, err := ParseQuestionBank(QuestionBankFormatCSV, data, "再インポート")
+		require.NoError(t, err)
+		assert.Equal(t, "再インポート", imported.Name)
+		require.Len(t, imported.Questions, 3)
+		assert.Equal(t, bank.Questions[0].Options, imported.Questions[0].Options)
+		assert.Equal(t, 1, imported.Questions[0].CorrectAnswer)
+		assert.Equal(t, []string{"歴史"}, imported.Questions[0].Tags)
+		assert.Equal(t, "2000年に創業しました", imported.Questions[0].Explanation)
+		assert.Equal(t, DifficultyHard, imported.Questions[1].Difficulty)
+	})
+
+	t.Run("列の順序が異なり選択肢が2つのCSVも読み込めること", func(t *testing.T) {
+		csv := "category,correctAnswer,text,option1,option2,tags\n" +
+			"一般,1,\"地球は丸い？\",いいえ,はい,基本; 科学\n"
+
+		imported, err := ParseQuestionBank(QuestionBankFormatCSV, []byte(csv), "bank")
+		require.NoError(t, err)
+		require.Len(t, imported.Questions, 1)
+		assert.Equal(t, []string{"いいえ", "はい"}, imported.Questions[0].Options)
+		assert.Equal(t, []string{"基本", "科学"}, imported.Questions[0].Tags)
+	})
+
+	t.Run("JSONでエクスポートした内容を再インポートでき、新しいバンクになること", func(t *testing.T) {
+		bank := newTestQuestionBank()
+		bank.ID = "bank-1"
+		data, err := bank.Export(QuestionBankFormatJSON)
+		require.NoError(t, err)
+
+		imported, err := ParseQuestionBank(QuestionBankFormatJSON, data, "")
+		require.NoError(t, err)
+		assert.Empty(t, imported.ID)
+		assert.Equal(t, "社内クイズ", imported.Name)
+		assert.Equal(t, bank.Questions, imported.Questions)
+	})
+
+	t.Run("必須列がないCSVは不正となること", func(t *testing.T) {
+		_, err := ParseQuestionBank(QuestionBankFormatCSV, []byte("text,option1\nQ,A\n"), "bank")
+		assert.ErrorIs(t, err, ErrInvalidQuestionBank)
+	})
+}
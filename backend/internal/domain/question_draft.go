@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// QuestionStatus 事前生成した問題のレビュー状態
+type QuestionStatus string
+
+const (
+	QuestionStatusDraft     QuestionStatus = "draft"     // 生成直後（未レビュー）
+	QuestionStatusApproved  QuestionStatus = "approved"  // 出題可能
+	QuestionStatusRejected  QuestionStatus = "rejected"  // 出題しない
+	QuestionStatusPublished QuestionStatus = "published" // 出題済み
+)
+
+// MarkDraft 出題前の下書きにする（ラウンドは出題時に決まる）
+func (q *Question) MarkDraft(position int) {
+	q.Status = QuestionStatusDraft
+	q.Round = 0
+	q.Position = position
+}
+
+// IsPublished 参加者に公開済みか判定する
+func (q *Question) IsPublished() bool {
+	return q.Status == "" || q.Status == QuestionStatusPublished
+}
+
+// Edit 出題前の問題を単一選択問題として編集する
+func (q *Question) Edit(text string, options []string, correctAnswer int, difficulty Difficulty, category, explanation string) error {
+	return q.EditWithAnswerKey(text, options, AnswerKey{Type: QuestionTypeChoice, CorrectAnswer: correctAnswer}, difficulty, category, explanation)
+}
+
+// EditWithAnswerKey 出題前の問題を問題形式と正解を含めて編集する
+func (q *Question) EditWithAnswerKey(text string, options []string, key AnswerKey, difficulty Difficulty, category, explanation string) error {
+	if q.IsPublished() {
+		return ErrQuestionAlreadyPublished
+	}
+
+	text = strings.TrimSpace(text)
+	trimmed := make([]string, len(options))
+	for i, option := range options {
+		trimmed[i] = strings.TrimSpace(option)
+	}
+	key = key.normalized()
+	if !isValidQuestion(text, trimmed, key, difficulty) {
+		return ErrInvalidQuestion
+	}
+
+	// 問題文・選択肢を変えると他言語版と内容が合わなくなるため破棄する
+	if text != q.Text || !slices.Equal(trimmed, q.Options) {
+		q.Translations = nil
+	}
+	// 選択肢の数が変わると選択肢のメディアの対応が崩れるため外す
+	if len(trimmed) != len(q.Options) {
+		q.OptionMedia = nil
+	}
+
+	q.Text = text
+	q.Options = trimmed
+	q.SetAnswerKey(key)
+	q.Difficulty = difficulty
+	q.Category = strings.TrimSpace(category)
+	q.SetExplanation(explanation)
+	return nil
+}
+
+// SetExplanation 解説を設定する（出題後も編集可能）
+func (q *Question) SetExplanation(explanation string) {
+	q.Explanation = strings.TrimSpace(explanation)
+}
+
+// Approve 下書きを出題可能にする
+func (q *Question) Approve() error {
+	if q.IsPublished() {
+		return ErrQuestionAlreadyPublished
+	}
+	q.Status = QuestionStatusApproved
+	return nil
+}
+
+// Reject 下書きを出題対象から外す
+func (q *Question) Reject() error {
+	if q.IsPublished() {
+		return ErrQuestionAlreadyPublished
+	}
+	q.Status = QuestionStatusRejected
+	return nil
+}
+
+// Publish 承認済みの問題を指定ラウンドで出題し、回答受付を開始する
+func (q *Question) Publish(round int, at time.Time) error {
+	if q.Status != QuestionStatusApproved {
+		return ErrQuestionNotApproved
+	}
+	q.Status = QuestionStatusPublished
+	q.Round = round
+	q.Open(at)
+	return nil
+}
+
+// PublishedQuestions 参加者に公開済みの問題のみを返す
+func PublishedQuestions(questions []*Question) []*Question {
+	published := make([]*Question, 0, len(questions))
+	for _, q := range questions {
+		if q.IsPublished() {
+			published = append(published, q)
+		}
+	}
+	return published
+}
+
+// DraftQuestions 出題前の問題を出題順に並べて返す
+func DraftQuestions(questions []*Question) []*Question {
+	drafts := make([]*Question, 0, len(questions))
+	for _, q := range questions {
+		if !q.IsPublished() {
+			drafts = append(drafts, q)
+		}
+	}
+	sort.SliceStable(drafts, func(i, j int) bool {
+		return drafts[i].Position < drafts[j].Position
+	})
+	return drafts
+}
+
+// NextApprovedQuestion 次に出題する承認済みの問題を返す
+func NextApprovedQuestion(questions []*Question) (*Question, error) {
+	for _, q := range DraftQuestions(questions) {
+		if q.Status == QuestionStatusApproved {
+			return q, nil
+		}
+	}
+	return nil, ErrNoApprovedQuestion
+}
+
+// NextDraftPosition 新しい下書きに割り当てる出題順
+func NextDraftPosition(questions []*Question) int {
+	next := 1
+	for _, q := range DraftQuestions(questions) {
+		if q.Position >= next {
+			next = q.Position + 1
+		}
+	}
+	return next
+}
+
+// ReorderDrafts 指定したID順に下書きの出題順を振り直す
+// 指定は出題前の全問題を過不足なく含む必要がある
+func ReorderDrafts(drafts []*Question, questionIDs []string) error {
+	if len(questionIDs) != len(drafts) {
+		return ErrInvalidInput
+	}
+
+	byID := make(map[string]*Question, len(drafts))
+	for _, q := range drafts {
+		byID[q.ID] = q
+	}
+
+	seen := make(map[string]bool, len(questionIDs))
+	for _, id := range questionIDs {
+		if byID[id] == nil || seen[id] {
+			return ErrInvalidInput
+		}
+		seen[id] = true
+	}
+
+	for i, id := range questionIDs {
+		byID[id].Position = i + 1
+	}
+	return nil
+}
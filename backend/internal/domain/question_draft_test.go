@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDraftQuestion(id string, position int) *Question {
+	q := NewQuestion("session", 0, "問題"+id, []string{"A", "B", "C", "D"}, 0, DifficultyEasy, "general", AIProviderGemini)
+	q.ID = id
+	q.MarkDraft(position)
+	return q
+}
+
+func TestQuestionDraftLifecycle(t *testing.T) {
+	t.Run("承認した下書きのみ出題でき、出題後は編集できないこと", func(t *testing.T) {
+		q := newDraftQuestion("q1", 1)
+		assert.False(t, q.IsPublished())
+
+		assert.ErrorIs(t, q.Publish(1, time.Now()), ErrQuestionNotApproved)
+
+		require.NoError(t, q.Edit(" 編集後 ", []string{"はい", "いいえ"}, 1, DifficultyHard, "一般", " 解説 "))
+		assert.Equal(t, "編集後", q.Text)
+		assert.Equal(t, "解説", q.Explanation)
+		assert.Equal(t, []string{"はい", "いいえ"}, q.Options)
+
+		require.NoError(t, q.Approve())
+		openedAt := time.Now()
+		require.NoError(t, q.Publish(3, openedAt))
+		assert.True(t, q.IsPublished())
+		assert.Equal(t, 3, q.Round)
+		assert.Equal(t, openedAt, q.OpenedAt)
+
+		assert.ErrorIs(t, q.Edit("再編集", []string{"A", "B"}, 0, DifficultyEasy, "", ""), ErrQuestionAlreadyPublished)
+		assert.ErrorIs(t, q.Reject(), ErrQuestionAlreadyPublished)
+	})
+
+	t.Run("正解番号が範囲外の編集は拒否されること", func(t *testing.T) {
+		q := newDraftQuestion("q1", 1)
+		assert.ErrorIs(t, q.Edit("問題", []string{"A", "B"}, 2, DifficultyEasy, "", ""), ErrInvalidQuestion)
+		assert.ErrorIs(t, q.Edit("問題", []string{"A", "B"}, 0, "unknown", "", ""), ErrInvalidQuestion)
+		assert.Equal(t, "問題q1", q.Text)
+	})
+
+	t.Run("状態未設定の既存の問題は出題済みとして扱われること", func(t *testing.T) {
+		legacy := NewQuestion("session", 1, "既存", []string{"A", "B"}, 0, DifficultyEasy, "", AIProviderGemini)
+		draft := newDraftQuestion("q1", 1)
+
+		assert.Equal(t, []*Question{legacy}, PublishedQuestions([]*Question{legacy, draft}))
+		assert.Equal(t, []*Question{draft}, DraftQuestions([]*Question{legacy, draft}))
+	})
+}
+
+func TestDraftOrdering(t *testing.T) {
+	t.Run("出題順が最も早い承認済みの問題が次に出題されること", func(t *testing.T) {
+		q1, q2, q3 := newDraftQuestion("q1", 1), newDraftQuestion("q2", 2), newDraftQuestion("q3", 3)
+		questions := []*Question{q3, q1, q2}
+
+		_, err := NextApprovedQuestion(questions)
+		assert.ErrorIs(t, err, ErrNoApprovedQuestion)
+
+		require.NoError(t, q1.Reject())
+		require.NoError(t, q2.Approve())
+		require.NoError(t, q3.Approve())
+
+		next, err := NextApprovedQuestion(questions)
+		require.NoError(t, err)
+		assert.Equal(t, "q2", next.ID)
+		assert.Equal(t, 4, NextDraftPosition(questions))
+	})
+
+	t.Run("指定したID順に出題順が振り直されること", func(t *testing.T) {
+		q1, q2, q3 := newDraftQuestion("q1", 1), newDraftQuestion("q2", 2), newDraftQuestion("q3", 3)
+		drafts := []*Question{q1, q2, q3}
+
+		require.NoError(t, ReorderDrafts(drafts, []string{"q3", "q1", "q2"}))
+		sorted := DraftQuestions(drafts)
+		assert.Equal(t, []string{"q3", "q1", "q2"}, []string{sorted[0].ID, sorted[1].ID, sorted[2].ID})
+	})
+
+	t.Run("不足・重複・未知のIDを含む並べ替えは拒否されること", func(t *testing.T) {
+		drafts := []*Question{newDraftQuestion("q1", 1), newDraftQuestion("q2", 2)}
+
+		assert.ErrorIs(t, ReorderDrafts(drafts, []string{"q1"}), ErrInvalidInput)
+		assert.ErrorIs(t, ReorderDrafts(drafts, []string{"q1", "q1"}), ErrInvalidInput)
+		assert.ErrorIs(t, ReorderDrafts(drafts, []string{"q1", "q9"}), ErrInvalidInput)
+		assert.Equal(t, 1, drafts[0].Position)
+	})
+}
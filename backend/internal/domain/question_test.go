@@ -37,4 +37,38 @@ func TestQuestion(t *testing.T) {
 	if question.Difficulty != DifficultyMedium {
 		t.Errorf("Expected difficulty to be Medium, got %s", question.Difficulty)
 	}
-}
\ No newline at end of file
+}
+func TestAnswerMeasureResponseTime(t *testing.T) {
+	openedAt := time.Now().Add(-3 * time.Second)
+	question := &Question{
+		ID:        "test-1",
+		CreatedAt: openedAt.Add(-time.Minute),
+	}
+	question.Open(openedAt)
+
+	answer := NewAnswer("user-1", "session-1", "test-1", 0, 1)
+	answer.AnsweredAt = openedAt.Add(2500 * time.Millisecond)
+	answer.MeasureResponseTime(question)
+
+	if answer.ResponseTime != 2500 {
+		t.Errorf("Expected server response time to be 2500, got %d", answer.ResponseTime)
+	}
+
+	if answer.ClientResponseTime != 1 {
+		t.Errorf("Expected client response time to be kept as 1, got %d", answer.ClientResponseTime)
+	}
+
+	// 受付開始時刻のない旧データは作成時刻を基準にする
+	legacy := &Question{ID: "legacy", CreatedAt: openedAt}
+	answer.MeasureResponseTime(legacy)
+	if answer.ResponseTime != 2500 {
+		t.Errorf("Expected legacy response time to be 2500, got %d", answer.ResponseTime)
+	}
+
+	// 受付開始前の回答は0に丸める
+	answer.AnsweredAt = openedAt.Add(-time.Second)
+	answer.MeasureResponseTime(question)
+	if answer.ResponseTime != 0 {
+		t.Errorf("Expected negative response time to be clamped to 0, got %d", answer.ResponseTime)
+	}
+}
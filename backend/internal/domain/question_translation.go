@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// QuestionTranslation 問題の他言語版（選択肢の順序と正解は元の問題と同じ）
+type QuestionTranslation struct {
+	Text        string   `json:"text" firestore:"text"`
+	Options     []string `json:"options" firestore:"options"`
+	Explanation string   `json:"explanation" firestore:"explanation"`
+}
+
+// SourceLanguage 問題文の言語（未設定の既存データは日本語）
+func (q *Question) SourceLanguage() string {
+	if q.Language == "" {
+		return DefaultLanguage
+	}
+	return q.Language
+}
+
+// Languages 問題を表示できる言語（元の言語が先頭、以降は言語コード順）
+func (q *Question) Languages() []string {
+	languages := make([]string, 0, len(q.Translations))
+	for language := range q.Translations {
+		if language != q.SourceLanguage() {
+			languages = append(languages, language)
+		}
+	}
+	sort.Strings(languages)
+	return append([]string{q.SourceLanguage()}, languages...)
+}
+
+// SetTranslation 他言語版を設定する（選択肢の数は元の問題と同じである必要がある）
+func (q *Question) SetTranslation(language string, translation QuestionTranslation) error {
+	language = NormalizeLanguage(language)
+	if !IsSupportedLanguage(language) || language == q.SourceLanguage() {
+		return ErrInvalidInput
+	}
+
+	translation.Text = strings.TrimSpace(translation.Text)
+	translation.Explanation = strings.TrimSpace(translation.Explanation)
+	options := make([]string, len(translation.Options))
+	for i, option := range translation.Options {
+		options[i] = strings.TrimSpace(option)
+	}
+	translation.Options = options
+
+	if translation.Text == "" || len(translation.Options) != len(q.Options) {
+		return ErrInvalidQuestion
+	}
+	for _, option := range translation.Options {
+		if option == "" {
+			return ErrInvalidQuestion
+		}
+	}
+
+	if q.Translations == nil {
+		q.Translations = make(map[string]QuestionTranslation)
+	}
+	q.Translations[language] = translation
+	return nil
+}
+
+// Localized 問題文・選択肢・解説を language の版に置き換えた複製を返す
+// 翻訳がない場合は元の言語のまま返す
+func (q *Question) Localized(language string) *Question {
+	c := *q
+	c.Options = append([]string(nil), q.Options...)
+	c.Language = q.SourceLanguage()
+
+	translation, ok := q.Translations[NormalizeLanguage(language)]
+	if !ok {
+		return &c
+	}
+	c.Language = NormalizeLanguage(language)
+	c.Text = translation.Text
+	c.Options = append([]string(nil), translation.Options...)
+	c.Explanation = translation.Explanation
+	return &c
+}
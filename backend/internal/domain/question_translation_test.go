@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuestionTranslation(t *testing.T) {
+	newQuestion := func() *Question {
+		return NewQuestion("s1", 1, "日本で一番高い山は？", []string{"富士山", "北岳", "奥穂高岳", "間ノ岳"}, 0, DifficultyEasy, "地理", AIProviderLocal)
+	}
+
+	t.Run("翻訳を設定すると表示言語の版が返り、翻訳がない言語は元の言語で返ること", func(t *testing.T) {
+		q := newQuestion()
+		require.NoError(t, q.SetTranslation("EN-us", QuestionTranslation{
+			Text:        " What is the highest mountain in Japan? ",
+			Options:     []string{"Mt. Fuji", "Mt. Kita", "Mt. Okuhotaka", "Mt. Aino"},
+			Explanation: "Mt. Fuji is 3,776 m.",
+		}))
+		assert.Equal(t, []string{"ja", "en"}, q.Languages())
+
+		en := q.Localized("en")
+		assert.Equal(t, "en", en.Language)
+		assert.Equal(t, "What is the highest mountain in Japan?", en.Text)
+		assert.Equal(t, "Mt. Fuji", en.Options[en.CorrectAnswer])
+		assert.Equal(t, "日本で一番高い山は？", q.Text)
+
+		ko := q.Localized("ko")
+		assert.Equal(t, "ja", ko.Language)
+		assert.Equal(t, q.Text, ko.Text)
+	})
+
+	t.Run("未対応・元の言語、選択肢の数が異なる翻訳は設定できないこと", func(t *testing.T) {
+		q := newQuestion()
+		translation := QuestionTranslation{Text: "Question", Options: []string{"A", "B", "C", "D"}}
+		assert.ErrorIs(t, q.SetTranslation("xx", translation), ErrInvalidInput)
+		assert.ErrorIs(t, q.SetTranslation("ja", translation), ErrInvalidInput)
+		assert.ErrorIs(t, q.SetTranslation("en", QuestionTranslation{Text: "Question", Options: []string{"A", "B"}}), ErrInvalidQuestion)
+		assert.ErrorIs(t, q.SetTranslation("en", QuestionTranslation{Text: "Question", Options: []string{"A", " ", "C", "D"}}), ErrInvalidQuestion)
+		assert.Empty(t, q.Translations)
+	})
+
+	t.Run("下書きの問題文を編集すると翻訳が破棄されること", func(t *testing.T) {
+		q := newQuestion()
+		q.MarkDraft(1)
+		require.NoError(t, q.SetTranslation("en", QuestionTranslation{Text: "Question", Options: []string{"A", "B", "C", "D"}}))
+
+		require.NoError(t, q.Edit(q.Text, q.Options, 1, DifficultyEasy, "地理", "解説"))
+		assert.Len(t, q.Translations, 1)
+
+		require.NoError(t, q.Edit("日本で二番目に高い山は？", q.Options, 1, DifficultyEasy, "地理", ""))
+		assert.Nil(t, q.Translations)
+	})
+}
@@ -0,0 +1,226 @@
+package domain
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// QuestionType 問題の形式
+type QuestionType string
+
+const (
+	QuestionTypeChoice      QuestionType = "choice"       // 正解が1つの選択問題（既定）
+	QuestionTypeTrueFalse   QuestionType = "true_false"   // ○×問題（選択肢は2つ）
+	QuestionTypeMultiSelect QuestionType = "multi_select" // 正解の選択肢をすべて選ぶ
+	QuestionTypeNumeric     QuestionType = "numeric"      // 数値で答える（許容誤差内、または最も近い回答が正解）
+	QuestionTypeOrdering    QuestionType = "ordering"     // 選択肢を正しい順に並べる
+)
+
+// QuestionTypes 定義済みの問題形式
+var QuestionTypes = []QuestionType{
+	QuestionTypeChoice,
+	QuestionTypeTrueFalse,
+	QuestionTypeMultiSelect,
+	QuestionTypeNumeric,
+	QuestionTypeOrdering,
+}
+
+func IsValidQuestionType(t QuestionType) bool {
+	return slices.Contains(QuestionTypes, t)
+}
+
+// AnswerKey 問題形式ごとの正解
+type AnswerKey struct {
+	Type          QuestionType `json:"type"`
+	CorrectAnswer int          `json:"correctAnswer"` // 単一選択・○×
+	// CorrectAnswers 複数選択は正解の選択肢番号、並べ替えは正しい順に並べた選択肢番号
+	CorrectAnswers []int `json:"correctAnswers,omitempty"`
+	// 数値問題の正解と許容誤差（0 なら最も近い回答が正解）、単位
+	NumericAnswer float64 `json:"numericAnswer"`
+	Tolerance     float64 `json:"tolerance"`
+	Unit          string  `json:"unit,omitempty"`
+}
+
+// AnswerInput 参加者の回答（問題形式に応じていずれかを指定する）
+type AnswerInput struct {
+	SelectedOption *int `json:"selectedOption,omitempty"` // 単一選択・○×
+	// SelectedOptions 複数選択は選んだ選択肢番号、並べ替えは並べた順の選択肢番号
+	SelectedOptions []int    `json:"selectedOptions,omitempty"`
+	NumericValue    *float64 `json:"numericValue,omitempty"` // 数値問題
+}
+
+// Kind 問題の形式（未設定の既存データは単一選択）
+func (q *Question) Kind() QuestionType {
+	if q.Type == "" {
+		return QuestionTypeChoice
+	}
+	return q.Type
+}
+
+// AnswerKey 問題の正解
+func (q *Question) AnswerKey() AnswerKey {
+	return AnswerKey{
+		Type:           q.Kind(),
+		CorrectAnswer:  q.CorrectAnswer,
+		CorrectAnswers: append([]int(nil), q.CorrectAnswers...),
+		NumericAnswer:  q.NumericAnswer,
+		Tolerance:      q.Tolerance,
+		Unit:           q.Unit,
+	}
+}
+
+// SetAnswerKey 問題の形式と正解を設定する（形式に関係しない項目はゼロ値にする）
+func (q *Question) SetAnswerKey(key AnswerKey) {
+	key = key.normalized()
+	q.Type = key.Type
+	q.CorrectAnswer = key.CorrectAnswer
+	q.CorrectAnswers = key.CorrectAnswers
+	q.NumericAnswer = key.NumericAnswer
+	q.Tolerance = key.Tolerance
+	q.Unit = key.Unit
+}
+
+// normalized 形式に関係しない項目を除き、複数選択の正解を昇順にそろえる
+func (k AnswerKey) normalized() AnswerKey {
+	if k.Type == "" {
+		k.Type = QuestionTypeChoice
+	}
+	k.Unit = strings.TrimSpace(k.Unit)
+
+	switch k.Type {
+	case QuestionTypeChoice, QuestionTypeTrueFalse:
+		return AnswerKey{Type: k.Type, CorrectAnswer: k.CorrectAnswer}
+	case QuestionTypeMultiSelect:
+		answers := append([]int(nil), k.CorrectAnswers...)
+		slices.Sort(answers)
+		return AnswerKey{Type: k.Type, CorrectAnswers: answers}
+	case QuestionTypeOrdering:
+		return AnswerKey{Type: k.Type, CorrectAnswers: append([]int(nil), k.CorrectAnswers...)}
+	case QuestionTypeNumeric:
+		return AnswerKey{Type: k.Type, NumericAnswer: k.NumericAnswer, Tolerance: k.Tolerance, Unit: k.Unit}
+	}
+	return k
+}
+
+// ClosestWins 最も近い回答を正解とする数値問題か（回答の締め切り時に判定する）
+func (q *Question) ClosestWins() bool {
+	return q.Kind() == QuestionTypeNumeric && q.Tolerance <= 0
+}
+
+// Grade 回答を問題形式に応じて採点する（形式に合わない回答は ErrInvalidAnswer）
+// 最も近い回答が正解となる数値問題は締め切りまで正誤が決まらないため、回答の検証のみ行い false を返す
+func (q *Question) Grade(input AnswerInput) (bool, error) {
+	switch q.Kind() {
+	case QuestionTypeChoice, QuestionTypeTrueFalse:
+		if input.SelectedOption == nil || *input.SelectedOption < 0 || *input.SelectedOption >= len(q.Options) {
+			return false, ErrInvalidAnswer
+		}
+		return *input.SelectedOption == q.CorrectAnswer, nil
+
+	case QuestionTypeMultiSelect:
+		if len(input.SelectedOptions) == 0 || !distinctIndexes(input.SelectedOptions, len(q.Options)) {
+			return false, ErrInvalidAnswer
+		}
+		selected := append([]int(nil), input.SelectedOptions...)
+		slices.Sort(selected)
+		return slices.Equal(selected, q.CorrectAnswers), nil
+
+	case QuestionTypeOrdering:
+		if !isPermutation(input.SelectedOptions, len(q.Options)) {
+			return false, ErrInvalidAnswer
+		}
+		return slices.Equal(input.SelectedOptions, q.CorrectAnswers), nil
+
+	case QuestionTypeNumeric:
+		if input.NumericValue == nil || !isFinite(*input.NumericValue) {
+			return false, ErrInvalidAnswer
+		}
+		if q.ClosestWins() {
+			return false, nil
+		}
+		// 小数の誤差で境界の回答が不正解にならないよう僅かに広げる
+		return math.Abs(*input.NumericValue-q.NumericAnswer) <= q.Tolerance+1e-9, nil
+	}
+	return false, ErrInvalidAnswer
+}
+
+// ClosestAnswers 数値問題の回答のうち正解に最も近いもの（同じ差の回答はすべて）を返す
+func ClosestAnswers(q *Question, answers []*Answer) []*Answer {
+	var closest []*Answer
+	best := math.Inf(1)
+	for _, answer := range answers {
+		if answer.NumericValue == nil {
+			continue
+		}
+		distance := math.Abs(*answer.NumericValue - q.NumericAnswer)
+		switch {
+		case distance < best:
+			best = distance
+			closest = []*Answer{answer}
+		case distance == best:
+			closest = append(closest, answer)
+		}
+	}
+	return closest
+}
+
+// IsValid 問題文・選択肢・正解・難易度が問題形式に合っているか判定する
+func (q *Question) IsValid() bool {
+	return isValidQuestion(q.Text, q.Options, q.AnswerKey(), q.Difficulty)
+}
+
+// isValidQuestion 問題文・選択肢・正解・難易度が問題形式に合っているか判定する
+func isValidQuestion(text string, options []string, key AnswerKey, difficulty Difficulty) bool {
+	if text == "" || !IsValidDifficulty(difficulty) {
+		return false
+	}
+	for _, option := range options {
+		if option == "" {
+			return false
+		}
+	}
+
+	switch key.Type {
+	case "", QuestionTypeChoice:
+		return len(options) >= 2 && len(options) <= MaxQuestionOptions &&
+			key.CorrectAnswer >= 0 && key.CorrectAnswer < len(options)
+	case QuestionTypeTrueFalse:
+		return len(options) == 2 && key.CorrectAnswer >= 0 && key.CorrectAnswer < 2
+	case QuestionTypeMultiSelect:
+		return len(options) >= 2 && len(options) <= MaxQuestionOptions &&
+			len(key.CorrectAnswers) > 0 && distinctIndexes(key.CorrectAnswers, len(options))
+	case QuestionTypeOrdering:
+		return len(options) >= 2 && len(options) <= MaxQuestionOptions && isPermutation(key.CorrectAnswers, len(options))
+	case QuestionTypeNumeric:
+		return len(options) == 0 && isFinite(key.NumericAnswer) && isFinite(key.Tolerance) && key.Tolerance >= 0
+	}
+	return false
+}
+
+// distinctIndexes 重複のない 0 以上 n 未満の番号か
+func distinctIndexes(indexes []int, n int) bool {
+	seen := make(map[int]bool, len(indexes))
+	for _, i := range indexes {
+		if i < 0 || i >= n || seen[i] {
+			return false
+		}
+		seen[i] = true
+	}
+	return true
+}
+
+// isPermutation 0 から n-1 までの番号をすべて1回ずつ含むか
+func isPermutation(indexes []int, n int) bool {
+	return len(indexes) == n && distinctIndexes(indexes, n)
+}
+
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// FormatNumber 数値の回答・正解を表示用の文字列にする（整数は小数点なし）
+func FormatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
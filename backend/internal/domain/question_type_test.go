@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuestionTypeGrade(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	floatPtr := func(f float64) *float64 { return &f }
+
+	newQuestion := func(options []string, key AnswerKey) *Question {
+		q := NewQuestion("s1", 1, "問題", options, 0, DifficultyEasy, "", AIProviderLocal)
+		q.SetAnswerKey(key)
+		require.True(t, q.IsValid())
+		return q
+	}
+
+	t.Run("形式が未設定の既存の問題は単一選択として採点されること", func(t *testing.T) {
+		q := NewQuestion("s1", 1, "問題", []string{"A", "B", "C", "D"}, 2, DifficultyEasy, "", AIProviderLocal)
+		assert.Equal(t, QuestionTypeChoice, q.Kind())
+
+		correct, err := q.Grade(AnswerInput{SelectedOption: intPtr(2)})
+		require.NoError(t, err)
+		assert.True(t, correct)
+		assert.False(t, q.ValidateAnswer(1))
+
+		_, err = q.Grade(AnswerInput{SelectedOption: intPtr(4)})
+		assert.ErrorIs(t, err, ErrInvalidAnswer)
+		_, err = q.Grade(AnswerInput{SelectedOptions: []int{2}})
+		assert.ErrorIs(t, err, ErrInvalidAnswer)
+	})
+
+	t.Run("複数選択は正解の選択肢をすべて、過不足なく選んだ場合のみ正解になること", func(t *testing.T) {
+		q := newQuestion([]string{"A", "B", "C", "D"}, AnswerKey{Type: QuestionTypeMultiSelect, CorrectAnswers: []int{3, 1}})
+		assert.Equal(t, []int{1, 3}, q.CorrectAnswers)
+
+		correct, err := q.Grade(AnswerInput{SelectedOptions: []int{3, 1}})
+		require.NoError(t, err)
+		assert.True(t, correct)
+
+		correct, err = q.Grade(AnswerInput{SelectedOptions: []int{1}})
+		require.NoError(t, err)
+		assert.False(t, correct)
+
+		_, err = q.Grade(AnswerInput{SelectedOptions: []int{1, 1}})
+		assert.ErrorIs(t, err, ErrInvalidAnswer)
+	})
+
+	t.Run("並べ替えは順序が一致した場合のみ正解になり、すべての選択肢を並べる必要があること", func(t *testing.T) {
+		q := newQuestion([]string{"江戸", "平安", "明治", "奈良"}, AnswerKey{Type: QuestionTypeOrdering, CorrectAnswers: []int{3, 1, 0, 2}})
+
+		correct, err := q.Grade(AnswerInput{SelectedOptions: []int{3, 1, 0, 2}})
+		require.NoError(t, err)
+		assert.True(t, correct)
+
+		correct, err = q.Grade(AnswerInput{SelectedOptions: []int{1, 3, 0, 2}})
+		require.NoError(t, err)
+		assert.False(t, correct)
+
+		_, err = q.Grade(AnswerInput{SelectedOptions: []int{3, 1, 0}})
+		assert.ErrorIs(t, err, ErrInvalidAnswer)
+	})
+
+	t.Run("許容誤差のある数値問題は誤差の範囲内なら正解になること", func(t *testing.T) {
+		q := newQuestion(nil, AnswerKey{Type: QuestionTypeNumeric, NumericAnswer: 3776, Tolerance: 10, Unit: " m "})
+		assert.False(t, q.ClosestWins())
+		assert.Equal(t, "m", q.Unit)
+
+		correct, err := q.Grade(AnswerInput{NumericValue: floatPtr(3786)})
+		require.NoError(t, err)
+		assert.True(t, correct)
+
+		correct, err = q.Grade(AnswerInput{NumericValue: floatPtr(3800)})
+		require.NoError(t, err)
+		assert.False(t, correct)
+
+		_, err = q.Grade(AnswerInput{SelectedOption: intPtr(0)})
+		assert.ErrorIs(t, err, ErrInvalidAnswer)
+	})
+
+	t.Run("許容誤差のない数値問題は最も近い回答（同じ差はすべて）が正解になること", func(t *testing.T) {
+		q := newQuestion(nil, AnswerKey{Type: QuestionTypeNumeric, NumericAnswer: 100})
+		assert.True(t, q.ClosestWins())
+
+		correct, err := q.Grade(AnswerInput{NumericValue: floatPtr(100)})
+		require.NoError(t, err)
+		assert.False(t, correct, "締め切りまで正誤は決まらない")
+
+		answers := make([]*Answer, 0, 4)
+		for _, value := range []float64{90, 108, 110, 92} {
+			answer := NewAnswer("u", "s1", q.ID, -1, 0)
+			answer.SetInput(AnswerInput{NumericValue: floatPtr(value)})
+			answers = append(answers, answer)
+		}
+		closest := ClosestAnswers(q, answers)
+		require.Len(t, closest, 2)
+		assert.Equal(t, "108", closest[0].Summary())
+		assert.Equal(t, "92", closest[1].Summary())
+	})
+}
+
+func TestQuestionTypeValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []string
+		key     AnswerKey
+		valid   bool
+	}{
+		{"○×問題は選択肢が2つ", []string{"正しい", "誤り"}, AnswerKey{Type: QuestionTypeTrueFalse, CorrectAnswer: 1}, true},
+		{"○×問題の選択肢が3つ", []string{"正しい", "誤り", "不明"}, AnswerKey{Type: QuestionTypeTrueFalse}, false},
+		{"複数選択の正解なし", []string{"A", "B", "C"}, AnswerKey{Type: QuestionTypeMultiSelect}, false},
+		{"複数選択の正解が範囲外", []string{"A", "B", "C"}, AnswerKey{Type: QuestionTypeMultiSelect, CorrectAnswers: []int{0, 3}}, false},
+		{"並べ替えの正解が順列でない", []string{"A", "B", "C"}, AnswerKey{Type: QuestionTypeOrdering, CorrectAnswers: []int{0, 0, 1}}, false},
+		{"数値問題は選択肢なし", nil, AnswerKey{Type: QuestionTypeNumeric, NumericAnswer: 1.5}, true},
+		{"数値問題に選択肢がある", []string{"1", "2"}, AnswerKey{Type: QuestionTypeNumeric}, false},
+		{"数値問題の許容誤差が負", nil, AnswerKey{Type: QuestionTypeNumeric, Tolerance: -1}, false},
+		{"未定義の形式", []string{"A", "B"}, AnswerKey{Type: "essay"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, isValidQuestion("問題", tt.options, tt.key, DifficultyEasy))
+		})
+	}
+
+	t.Run("下書きの編集で問題形式を変更すると形式に関係しない正解が取り除かれること", func(t *testing.T) {
+		q := NewQuestion("s1", 1, "問題", []string{"A", "B", "C", "D"}, 2, DifficultyEasy, "", AIProviderLocal)
+		q.MarkDraft(1)
+
+		err := q.EditWithAnswerKey("富士山の標高は？", nil, AnswerKey{Type: QuestionTypeNumeric, CorrectAnswer: 2, NumericAnswer: 3776, Unit: "m"}, DifficultyEasy, "地理", "")
+		require.NoError(t, err)
+		assert.Equal(t, QuestionTypeNumeric, q.Type)
+		assert.Equal(t, 0, q.CorrectAnswer)
+		assert.Empty(t, q.Options)
+		assert.Equal(t, 3776.0, q.NumericAnswer)
+
+		err = q.EditWithAnswerKey("問題", []string{"A", "B"}, AnswerKey{Type: QuestionTypeOrdering, CorrectAnswers: []int{1}}, DifficultyEasy, "", "")
+		assert.ErrorIs(t, err, ErrInvalidQuestion)
+	})
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// Round 出題中の問題の回答受付の状態（複数のサーバーで動かす場合は全サーバーで共有する）
+type Round struct {
+	SessionID  string
+	QuestionID string
+	OpenedAt   time.Time
+	Deadline   time.Time // ゼロ値の場合は締め切りなし
+	Closed     bool
+	ClosedAt   time.Time
+	Processed  bool // 結果処理を開始済み
+	Answered   int  // 受け付けた回答の数
+}
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// ScoringMode セッションで使用する採点方式
+type ScoringMode string
+
+const (
+	ScoringModeFlat       ScoringMode = "flat"        // 難易度ごとの固定点（従来方式）
+	ScoringModeSpeedBonus ScoringMode = "speed_bonus" // 固定点 + 制限時間に対する残り時間ボーナス
+	ScoringModeStreak     ScoringMode = "streak"      // 固定点 × 連続正解倍率
+	ScoringModeKahoot     ScoringMode = "kahoot"      // 最大1000点、回答が遅いほど最大半分まで減点
+)
+
+const (
+	// 連続正解1回ごとに加算される倍率と倍率の上限
+	streakMultiplierStep = 0.25
+	streakMultiplierMax  = 2.0
+
+	kahootMaxPoints = 1000
+)
+
+// ScoringInput 採点に必要な情報
+type ScoringInput struct {
+	Question     *Question
+	ResponseTime time.Duration // サーバー計測の回答時間
+	TimeLimit    time.Duration // 0 の場合は制限なし
+	Streak       int           // この回答より前の連続正解数
+}
+
+// ScoringStrategy 正解時の獲得点数を算出する
+type ScoringStrategy interface {
+	Mode() ScoringMode
+	Points(input ScoringInput) int
+}
+
+// NewScoringStrategy 採点方式に対応する ScoringStrategy を返す（未指定・不明な場合は flat）
+func NewScoringStrategy(mode ScoringMode) ScoringStrategy {
+	switch mode {
+	case ScoringModeSpeedBonus:
+		return speedBonusScoring{}
+	case ScoringModeStreak:
+		return streakScoring{}
+	case ScoringModeKahoot:
+		return kahootScoring{}
+	default:
+		return flatScoring{}
+	}
+}
+
+// IsValidScoringMode 採点方式が定義済みか判定する（空文字は flat として扱うため有効）
+func IsValidScoringMode(mode ScoringMode) bool {
+	switch mode {
+	case "", ScoringModeFlat, ScoringModeSpeedBonus, ScoringModeStreak, ScoringModeKahoot:
+		return true
+	default:
+		return false
+	}
+}
+
+type flatScoring struct{}
+
+func (flatScoring) Mode() ScoringMode { return ScoringModeFlat }
+
+func (flatScoring) Points(input ScoringInput) int {
+	return input.Question.GetPoints()
+}
+
+type speedBonusScoring struct{}
+
+func (speedBonusScoring) Mode() ScoringMode { return ScoringModeSpeedBonus }
+
+// Points 即答で固定点の2倍、制限時間ちょうどで固定点のみになるよう線形に減衰する
+func (speedBonusScoring) Points(input ScoringInput) int {
+	base := input.Question.GetPoints()
+	return base + int(float64(base)*remainingRatio(input))
+}
+
+type streakScoring struct{}
+
+func (streakScoring) Mode() ScoringMode { return ScoringModeStreak }
+
+func (streakScoring) Points(input ScoringInput) int {
+	multiplier := 1 + streakMultiplierStep*float64(input.Streak)
+	if multiplier > streakMultiplierMax {
+		multiplier = streakMultiplierMax
+	}
+	return int(float64(input.Question.GetPoints()) * multiplier)
+}
+
+type kahootScoring struct{}
+
+func (kahootScoring) Mode() ScoringMode { return ScoringModeKahoot }
+
+// Points Kahoot! と同じく 1000 × (1 - 回答時間/制限時間/2) を四捨五入する
+func (kahootScoring) Points(input ScoringInput) int {
+	ratio := 1 - remainingRatio(input)
+	if input.TimeLimit <= 0 {
+		ratio = 0
+	}
+	return int(float64(kahootMaxPoints)*(1-ratio/2) + 0.5)
+}
+
+// remainingRatio 制限時間に対する残り時間の割合（0〜1、制限なしの場合は 0）
+func remainingRatio(input ScoringInput) float64 {
+	if input.TimeLimit <= 0 {
+		return 0
+	}
+	ratio := 1 - float64(input.ResponseTime)/float64(input.TimeLimit)
+	if ratio < 0 {
+		return 0
+	}
+	if ratio > 1 {
+		return 1
+	}
+	return ratio
+}
+
+// CurrentStreak 直近から数えた連続正解数を返す
+func CurrentStreak(answers []*Answer) int {
+	sorted := make([]*Answer, len(answers))
+	copy(sorted, answers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].AnsweredAt.After(sorted[j].AnsweredAt)
+	})
+
+	streak := 0
+	for _, answer := range sorted {
+		if !answer.IsCorrect {
+			break
+		}
+		streak++
+	}
+	return streak
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScoringStrategies(t *testing.T) {
+	question := &Question{Difficulty: DifficultyMedium} // 固定点 20
+	timeLimit := 10 * time.Second
+
+	tests := []struct {
+		name     string
+		mode     ScoringMode
+		input    ScoringInput
+		expected int
+	}{
+		{"未指定はflat", "", ScoringInput{Question: question, ResponseTime: time.Second, TimeLimit: timeLimit}, 20},
+		{"flatは回答時間に依存しない", ScoringModeFlat, ScoringInput{Question: question, ResponseTime: 9 * time.Second, TimeLimit: timeLimit}, 20},
+		{"speed_bonus即答", ScoringModeSpeedBonus, ScoringInput{Question: question, ResponseTime: 0, TimeLimit: timeLimit}, 40},
+		{"speed_bonus半分経過", ScoringModeSpeedBonus, ScoringInput{Question: question, ResponseTime: 5 * time.Second, TimeLimit: timeLimit}, 30},
+		{"speed_bonus時間切れ", ScoringModeSpeedBonus, ScoringInput{Question: question, ResponseTime: 12 * time.Second, TimeLimit: timeLimit}, 20},
+		{"speed_bonus制限時間なし", ScoringModeSpeedBonus, ScoringInput{Question: question, ResponseTime: time.Second}, 20},
+		{"streak連続正解なし", ScoringModeStreak, ScoringInput{Question: question, Streak: 0}, 20},
+		{"streak2連続", ScoringModeStreak, ScoringInput{Question: question, Streak: 2}, 30},
+		{"streak倍率上限", ScoringModeStreak, ScoringInput{Question: question, Streak: 10}, 40},
+		{"kahoot即答", ScoringModeKahoot, ScoringInput{Question: question, ResponseTime: 0, TimeLimit: timeLimit}, 1000},
+		{"kahoot半分経過", ScoringModeKahoot, ScoringInput{Question: question, ResponseTime: 5 * time.Second, TimeLimit: timeLimit}, 750},
+		{"kahoot時間切れ", ScoringModeKahoot, ScoringInput{Question: question, ResponseTime: 10 * time.Second, TimeLimit: timeLimit}, 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewScoringStrategy(tt.mode).Points(tt.input); got != tt.expected {
+				t.Errorf("Expected %d points, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAnswerScore(t *testing.T) {
+	question := &Question{Difficulty: DifficultyHard}
+
+	answer := &Answer{IsCorrect: false, ResponseTime: 1000}
+	answer.Score(NewScoringStrategy(ScoringModeKahoot), question, 10*time.Second, 0)
+	if answer.Points != 0 {
+		t.Errorf("Expected incorrect answer to score 0, got %d", answer.Points)
+	}
+
+	answer = &Answer{IsCorrect: true, ResponseTime: 2000}
+	answer.Score(NewScoringStrategy(ScoringModeKahoot), question, 10*time.Second, 0)
+	if answer.Points != 900 {
+		t.Errorf("Expected 900 points, got %d", answer.Points)
+	}
+}
+
+func TestCurrentStreak(t *testing.T) {
+	base := time.Now()
+	answers := []*Answer{
+		{IsCorrect: true, AnsweredAt: base.Add(1 * time.Minute)},
+		{IsCorrect: true, AnsweredAt: base.Add(3 * time.Minute)},
+		{IsCorrect: false, AnsweredAt: base},
+		{IsCorrect: true, AnsweredAt: base.Add(2 * time.Minute)},
+	}
+
+	if got := CurrentStreak(answers); got != 3 {
+		t.Errorf("Expected streak 3, got %d", got)
+	}
+
+	if got := CurrentStreak(nil); got != 0 {
+		t.Errorf("Expected streak 0 for no answers, got %d", got)
+	}
+}
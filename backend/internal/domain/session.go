@@ -35,9 +35,17 @@ type Game struct {
 type Session = Game
 
 type Settings struct {
-	TimeLimit      int  `json:"timeLimit" firestore:"timeLimit"`           // 秒
-	RevivalEnabled bool `json:"revivalEnabled" firestore:"revivalEnabled"` // 敗者復活戦有効フラグ
-	RevivalCount   int  `json:"revivalCount" firestore:"revivalCount"`     // 復活可能人数
+	TimeLimit      int                `json:"timeLimit" firestore:"timeLimit"`           // 秒
+	RevivalEnabled bool               `json:"revivalEnabled" firestore:"revivalEnabled"` // 敗者復活戦有効フラグ
+	RevivalCount   int                `json:"revivalCount" firestore:"revivalCount"`     // 復活可能人数
+	Scoring        ScoringMode        `json:"scoring" firestore:"scoring"`               // 採点方式（未指定は flat）
+	GameMode       GameMode           `json:"gameMode" firestore:"gameMode"`             // 進行方式（未指定は survival）
+	TotalRounds    int                `json:"totalRounds" firestore:"totalRounds"`       // classic の総ラウンド数
+	Lives          int                `json:"lives" firestore:"lives"`                   // 参加者の初期ライフ数（classic 以外）
+	QuestionBankID string             `json:"questionBankId" firestore:"questionBankId"` // 出題元の問題バンク（未指定は AI 生成）
+	HistoryGroup   string             `json:"historyGroup" firestore:"historyGroup"`     // 出題履歴を共有するグループ（組織など、未指定はセッション内のみ）
+	Generation     GenerationSettings `json:"generation" firestore:"generation"`         // AI 問題生成のテーマ・カテゴリ・テンプレート
+	Difficulty     DifficultySettings `json:"difficulty" firestore:"difficulty"`         // 難易度を指定しない出題での難易度の決め方
 }
 
 func NewGame(title string, maxParticipants int, settings Settings) *Game {
@@ -96,4 +104,13 @@ func (g *Game) IsWaiting() bool {
 
 func (g *Game) IsFinished() bool {
 	return g.Status == GameStatusFinished
-}
\ No newline at end of file
+}
+
+// SessionSnapshot 再接続したクライアントに送るセッションの現在の状態
+type SessionSnapshot struct {
+	Session       *Session
+	Question      *Question     // 現在のラウンドの問題（出題前・終了後は nil）
+	QuestionOpen  bool          // 回答を受け付けているか
+	RemainingTime time.Duration // 締め切りまでの残り時間（制限時間なし・締め切り後は0）
+	Participant   *Participant  // 接続したユーザーの参加状況（参加者でなければ nil）
+}
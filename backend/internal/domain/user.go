@@ -37,6 +37,10 @@ type Participant struct {
 	RevivedAt      *time.Time        `json:"revivedAt,omitempty" firestore:"revivedAt,omitempty"`
 	Score          int               `json:"score" firestore:"score"`
 	CorrectAnswers int               `json:"correctAnswers" firestore:"correctAnswers"`
+	Lives          int               `json:"lives" firestore:"lives"`                                 // 残りライフ（classic では未使用）
+	LifeLostAt     []time.Time       `json:"lifeLostAt,omitempty" firestore:"lifeLostAt,omitempty"`   // ライフを失った時刻の履歴
+	LifeLostFor    []string          `json:"lifeLostFor,omitempty" firestore:"lifeLostFor,omitempty"` // ライフを失った問題IDの履歴（LifeLostAt と同じ順序）
+	Language       string            `json:"language,omitempty" firestore:"language"`                 // 問題を表示する言語（未設定は問題の元の言語）
 }
 
 // NewUserWithEmail Firebase認証用のユーザー作成
@@ -118,6 +122,32 @@ func (p *Participant) Revive() {
 	now := time.Now()
 	p.Status = ParticipantStatusRevived
 	p.RevivedAt = &now
+	if p.Lives < 1 {
+		p.Lives = 1
+	}
+}
+
+// LoseLife 問題 questionID でライフを1つ減らして時刻と問題を記録し、残りが無くなったら脱落させる
+func (p *Participant) LoseLife(questionID string) {
+	now := time.Now()
+	p.LifeLostAt = append(p.LifeLostAt, now)
+	p.LifeLostFor = append(p.LifeLostFor, questionID)
+	if p.Lives > 0 {
+		p.Lives--
+	}
+	if p.Lives == 0 {
+		p.Eliminate()
+	}
+}
+
+// LostLifeFor 指定した問題でライフを失ったか
+func (p *Participant) LostLifeFor(questionID string) bool {
+	for _, lostFor := range p.LifeLostFor {
+		if lostFor == questionID {
+			return true
+		}
+	}
+	return false
 }
 
 func (p *Participant) AddCorrectAnswer(points int) {
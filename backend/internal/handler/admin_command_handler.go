@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"quiz-app/internal/domain"
+	"quiz-app/internal/usecase"
+	"quiz-app/internal/websocket"
+)
+
+// AdminCommandHandler WebSocket の admin_control のコマンドを REST API と同じ UseCase で実行する
+// 管理者の認証は接続時に管理者セッションで済ませている
+type AdminCommandHandler struct {
+	sessionUseCase usecase.SessionUseCase
+	adminUseCase   usecase.AdminUseCase
+	quizUseCase    usecase.QuizUseCase
+}
+
+func NewAdminCommandHandler(sessionUseCase usecase.SessionUseCase, adminUseCase usecase.AdminUseCase, quizUseCase usecase.QuizUseCase) *AdminCommandHandler {
+	return &AdminCommandHandler{
+		sessionUseCase: sessionUseCase,
+		adminUseCase:   adminUseCase,
+		quizUseCase:    quizUseCase,
+	}
+}
+
+// ExecuteAdminCommand コマンドを実行し、REST API の応答の data と同じ内容を返す
+func (h *AdminCommandHandler) ExecuteAdminCommand(ctx context.Context, sessionID string, req websocket.AdminCommandRequest) (interface{}, error) {
+	switch req.Command {
+	case websocket.AdminCommandStart:
+		if err := h.sessionUseCase.StartSession(ctx, sessionID); err != nil {
+			return nil, adminCommandError(err)
+		}
+		return map[string]string{"message": "Session started successfully"}, nil
+
+	case websocket.AdminCommandFinish:
+		if err := h.sessionUseCase.FinishSession(ctx, sessionID); err != nil {
+			return nil, adminCommandError(err)
+		}
+		return map[string]string{"message": "Session finished successfully"}, nil
+
+	case websocket.AdminCommandNextRound:
+		if err := h.quizUseCase.NextRound(ctx, sessionID); err != nil {
+			return nil, adminCommandError(err)
+		}
+		return map[string]string{"message": "Proceeded to next round"}, nil
+
+	case websocket.AdminCommandSkip:
+		if err := h.adminUseCase.SkipQuestion(ctx, sessionID); err != nil {
+			if err == domain.ErrQuestionNotFound {
+				return nil, &websocket.AdminCommandError{Code: "NOT_FOUND", Message: "No current question to skip"}
+			}
+			return nil, adminCommandError(err)
+		}
+		return map[string]string{"message": "Question skipped successfully"}, nil
+
+	case websocket.AdminCommandProcessResults:
+		if req.QuestionID == "" {
+			return nil, &websocket.AdminCommandError{Code: "BAD_REQUEST", Message: "Question ID is required"}
+		}
+		result, err := h.quizUseCase.ProcessRoundResults(ctx, sessionID, req.QuestionID)
+		if err != nil {
+			return nil, adminCommandError(err)
+		}
+		return websocket.RoundResultData(result), nil
+
+	case websocket.AdminCommandRevival:
+		if req.Count < 1 {
+			return nil, &websocket.AdminCommandError{Code: "BAD_REQUEST", Message: "count must be at least 1"}
+		}
+		revived, err := h.adminUseCase.StartRevival(ctx, sessionID, req.Count)
+		if err != nil {
+			return nil, adminCommandError(err)
+		}
+		return revivalResponse(revived), nil
+
+	default:
+		return nil, &websocket.AdminCommandError{
+			Code:    "BAD_REQUEST",
+			Message: "Invalid command. Use 'start', 'finish', 'next_round', 'skip', 'process_results' or 'revival'",
+		}
+	}
+}
+
+// adminCommandError REST API と同じエラーコード・メッセージに変換する（該当しないものは内部エラーとして返す）
+func adminCommandError(err error) error {
+	switch err {
+	case domain.ErrSessionNotFound:
+		return &websocket.AdminCommandError{Code: "NOT_FOUND", Message: "Session not found"}
+	case domain.ErrQuestionNotFound:
+		return &websocket.AdminCommandError{Code: "NOT_FOUND", Message: "Question not found"}
+	case domain.ErrSessionNotActive:
+		return &websocket.AdminCommandError{Code: "CONFLICT", Message: "Session is not active"}
+	case domain.ErrInvalidSessionStatus:
+		return &websocket.AdminCommandError{Code: "CONFLICT", Message: "Invalid session status for this action"}
+	case domain.ErrRoundAlreadyProcessed:
+		return &websocket.AdminCommandError{Code: "CONFLICT", Message: "Round results are already processed"}
+	case domain.ErrInvalidInput:
+		return &websocket.AdminCommandError{Code: "BAD_REQUEST", Message: "Invalid input"}
+	default:
+		return err
+	}
+}
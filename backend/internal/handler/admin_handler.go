@@ -22,11 +22,19 @@ func NewAdminHandler(sessionUseCase usecase.SessionUseCase, adminUseCase usecase
 }
 
 type CreateSessionRequest struct {
-	Title           string `json:"title" binding:"required"`
-	MaxParticipants int    `json:"maxParticipants"`
-	TimeLimit       int    `json:"timeLimit"`
-	RevivalEnabled  bool   `json:"revivalEnabled"`
-	RevivalCount    int    `json:"revivalCount"`
+	Title           string                    `json:"title" binding:"required"`
+	MaxParticipants int                       `json:"maxParticipants"`
+	TimeLimit       int                       `json:"timeLimit"`
+	RevivalEnabled  bool                      `json:"revivalEnabled"`
+	RevivalCount    int                       `json:"revivalCount"`
+	Scoring         string                    `json:"scoring"`  // "flat", "speed_bonus", "streak", "kahoot"
+	GameMode        string                    `json:"gameMode"` // "survival", "classic", "lives"
+	TotalRounds     int                       `json:"totalRounds"`
+	Lives           int                       `json:"lives"`          // 初期ライフ数（survival は未指定で1）
+	QuestionBankID  string                    `json:"questionBankId"` // 指定した場合は問題バンクから出題
+	HistoryGroup    string                    `json:"historyGroup"`   // 同じグループのセッション間で出題済みの問題を避ける
+	Generation      domain.GenerationSettings `json:"generation"`     // AI 問題生成のテーマ・カテゴリ・テンプレート
+	Difficulty      domain.DifficultySettings `json:"difficulty"`     // 難易度の自動調整（policy: "round" / "adaptive"）
 }
 
 type ControlSessionRequest struct {
@@ -55,11 +63,52 @@ func (h *AdminHandler) CreateSession(c *gin.Context) {
 	if req.RevivalCount <= 0 {
 		req.RevivalCount = 3
 	}
+	if req.Scoring == "" {
+		req.Scoring = string(domain.ScoringModeFlat)
+	}
+	if !domain.IsValidScoringMode(domain.ScoringMode(req.Scoring)) {
+		utils.BadRequestError(c, "Invalid scoring mode")
+		return
+	}
+	if req.GameMode == "" {
+		req.GameMode = string(domain.GameModeSurvival)
+	}
+	if !domain.IsValidGameMode(domain.GameMode(req.GameMode)) {
+		utils.BadRequestError(c, "Invalid game mode")
+		return
+	}
+	if req.GameMode == string(domain.GameModeClassic) && req.TotalRounds <= 0 {
+		req.TotalRounds = domain.DefaultClassicRounds
+	}
+	if req.Lives < 0 {
+		utils.BadRequestError(c, "Lives must not be negative")
+		return
+	}
+	if req.GameMode == string(domain.GameModeLives) && req.Lives == 0 {
+		req.Lives = domain.DefaultLives
+	}
+	req.Generation.Normalize()
+	if err := req.Generation.Validate(); err != nil {
+		utils.BadRequestError(c, "Invalid generation settings")
+		return
+	}
+	if err := req.Difficulty.Validate(); err != nil {
+		utils.BadRequestError(c, "Invalid difficulty settings (policy must be round or adaptive, targetEliminationRate 0-99)")
+		return
+	}
 
 	settings := domain.Settings{
 		TimeLimit:      req.TimeLimit,
 		RevivalEnabled: req.RevivalEnabled,
 		RevivalCount:   req.RevivalCount,
+		Scoring:        domain.ScoringMode(req.Scoring),
+		GameMode:       domain.GameMode(req.GameMode),
+		TotalRounds:    req.TotalRounds,
+		Lives:          req.Lives,
+		QuestionBankID: req.QuestionBankID,
+		HistoryGroup:   req.HistoryGroup,
+		Generation:     req.Generation,
+		Difficulty:     req.Difficulty,
 	}
 
 	session, err := h.sessionUseCase.CreateSession(c.Request.Context(), req.Title, req.MaxParticipants, settings)
@@ -78,12 +127,50 @@ func (h *AdminHandler) CreateSession(c *gin.Context) {
 			"timeLimit":      session.Settings.TimeLimit,
 			"revivalEnabled": session.Settings.RevivalEnabled,
 			"revivalCount":   session.Settings.RevivalCount,
+			"scoring":        string(session.Settings.Scoring),
+			"gameMode":       string(session.Settings.GameMode),
+			"totalRounds":    session.Settings.TotalRounds,
+			"lives":          session.Settings.Lives,
+			"questionBankId": session.Settings.QuestionBankID,
+			"historyGroup":   session.Settings.HistoryGroup,
+			"generation":     session.Settings.Generation,
+			"difficulty":     session.Settings.Difficulty,
 		},
 	}
 
 	utils.SuccessResponse(c, http.StatusCreated, response)
 }
 
+// PUT /api/v1/admin/sessions/:id/generation
+// AI 問題生成のテーマ・カテゴリ・テンプレートを変更する（次に生成する問題から反映）
+func (h *AdminHandler) UpdateGenerationSettings(c *gin.Context) {
+	var req domain.GenerationSettings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestError(c, "Invalid request body", err.Error())
+		return
+	}
+
+	session, err := h.sessionUseCase.UpdateGenerationSettings(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidGenerationSettings:
+			utils.BadRequestError(c, "Invalid generation settings")
+		case domain.ErrSessionNotFound:
+			utils.NotFoundError(c, "Session not found")
+		case domain.ErrSessionNotActive:
+			utils.ConflictError(c, "Session is already finished")
+		default:
+			utils.InternalServerError(c, "Failed to update generation settings")
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"id":         session.ID,
+		"generation": session.Settings.Generation,
+	})
+}
+
 // PUT /api/v1/admin/sessions/:id/control
 func (h *AdminHandler) ControlSession(c *gin.Context) {
 	sessionID := c.Param("id")
@@ -178,6 +265,11 @@ func (h *AdminHandler) StartRevival(c *gin.Context) {
 		return
 	}
 
+	utils.SuccessResponse(c, http.StatusOK, revivalResponse(revivedParticipants))
+}
+
+// revivalResponse 敗者復活の結果（REST API と WebSocket の admin_control で共通）
+func revivalResponse(revivedParticipants []*domain.Participant) map[string]interface{} {
 	revivedData := make([]map[string]interface{}, len(revivedParticipants))
 	for i, p := range revivedParticipants {
 		revivedData[i] = map[string]interface{}{
@@ -187,12 +279,10 @@ func (h *AdminHandler) StartRevival(c *gin.Context) {
 		}
 	}
 
-	response := map[string]interface{}{
+	return map[string]interface{}{
 		"revived": revivedData,
 		"count":   len(revivedParticipants),
 	}
-
-	utils.SuccessResponse(c, http.StatusOK, response)
 }
 
 // GET /api/v1/admin/sessions/:id/results
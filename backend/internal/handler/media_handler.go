@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"quiz-app/internal/domain"
+	"quiz-app/internal/usecase"
+	"quiz-app/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead アップロードのリクエストで、ファイル以外（境界・代替テキストなど）に許容するサイズ
+const multipartOverhead = 1 << 20
+
+type MediaHandler struct {
+	mediaUseCase  usecase.MediaUseCase
+	maxUploadSize int64
+}
+
+// NewMediaHandler maxUploadSize はアップロードできるファイルの上限（バイト）
+func NewMediaHandler(mediaUseCase usecase.MediaUseCase, maxUploadSize int64) *MediaHandler {
+	return &MediaHandler{
+		mediaUseCase:  mediaUseCase,
+		maxUploadSize: maxUploadSize,
+	}
+}
+
+// POST /api/v1/admin/media
+// multipart/form-data の file（画像・音声）と altText（代替テキスト）を受け取る
+// 返されたメディアのキーを問題・バンク問題の media / optionMedia に指定して添付する
+func (h *MediaHandler) UploadMedia(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize+multipartOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.respondError(c, domain.ErrMediaTooLarge, "")
+			return
+		}
+		utils.BadRequestError(c, "file is required", err.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.BadRequestError(c, "Failed to read uploaded file", err.Error())
+		return
+	}
+	defer file.Close()
+
+	contentType, err := detectMediaContentType(fileHeader.Header.Get("Content-Type"), file)
+	if err != nil {
+		utils.BadRequestError(c, "Failed to read uploaded file", err.Error())
+		return
+	}
+
+	media, err := h.mediaUseCase.Upload(c.Request.Context(), contentType, fileHeader.Size, file, c.PostForm("altText"))
+	if err != nil {
+		h.respondError(c, err, "Failed to upload media")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, media)
+}
+
+// GET /api/v1/media/:key
+// 参加者の画面で表示・再生するため認証不要（キーは推測できない UUID）
+func (h *MediaHandler) GetMedia(c *gin.Context) {
+	r, contentType, err := h.mediaUseCase.Open(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		h.respondError(c, err, "Failed to get media")
+		return
+	}
+	defer r.Close()
+
+	// 同じキーの内容は変わらないため長くキャッシュさせる
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, -1, contentType, r, nil)
+}
+
+// DELETE /api/v1/admin/media/:key
+func (h *MediaHandler) DeleteMedia(c *gin.Context) {
+	key := c.Param("key")
+	if err := h.mediaUseCase.Delete(c.Request.Context(), key); err != nil {
+		h.respondError(c, err, "Failed to delete media")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"key":     key,
+		"deleted": true,
+	})
+}
+
+func (h *MediaHandler) respondError(c *gin.Context, err error, message string) {
+	switch err {
+	case domain.ErrMediaNotFound:
+		utils.NotFoundError(c, "Media not found")
+	case domain.ErrInvalidMedia:
+		utils.BadRequestError(c, "Unsupported media (PNG, JPEG, GIF, WebP, MP3, Ogg, WAV or M4A)")
+	case domain.ErrMediaTooLarge:
+		utils.ErrorResponse(c, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", "Media file is too large",
+			map[string]interface{}{"maxSize": h.maxUploadSize})
+	default:
+		utils.InternalServerError(c, message)
+	}
+}
+
+// detectMediaContentType アップロード時の Content-Type が未指定・汎用の場合は内容から判定する
+func detectMediaContentType(declared string, file io.ReadSeeker) (string, error) {
+	if declared != "" && declared != "application/octet-stream" {
+		return declared, nil
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return http.DetectContentType(head[:n]), nil
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"quiz-app/internal/domain"
+	"quiz-app/internal/service"
+	"quiz-app/internal/usecase"
+	"quiz-app/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromptTemplateHandler struct {
+	templateUseCase usecase.PromptTemplateUseCase
+}
+
+func NewPromptTemplateHandler(templateUseCase usecase.PromptTemplateUseCase) *PromptTemplateHandler {
+	return &PromptTemplateHandler{
+		templateUseCase: templateUseCase,
+	}
+}
+
+type PromptTemplateRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Body        string `json:"body" binding:"required"`
+}
+
+// PreviewPromptTemplateRequest テンプレートの展開結果を確認するためのリクエスト
+// body を省略した場合は既定のテンプレートを展開する
+type PreviewPromptTemplateRequest struct {
+	Body          string            `json:"body"`
+	Difficulty    domain.Difficulty `json:"difficulty"`
+	Category      string            `json:"category"`
+	Language      string            `json:"language"`
+	Audience      string            `json:"audience"`
+	Theme         string            `json:"theme"`
+	ReferenceText string            `json:"referenceText"`
+}
+
+// GET /api/v1/admin/prompt-templates
+func (h *PromptTemplateHandler) ListTemplates(c *gin.Context) {
+	templates, err := h.templateUseCase.ListTemplates(c.Request.Context())
+	if err != nil {
+		utils.InternalServerError(c, "Failed to list prompt templates")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, templates)
+}
+
+// GET /api/v1/admin/prompt-templates/default
+// 既定のテンプレートと使用できる変数を返す
+func (h *PromptTemplateHandler) GetDefaultTemplate(c *gin.Context) {
+	utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"body":      service.DefaultPromptTemplate,
+		"variables": service.PromptVariables,
+	})
+}
+
+// POST /api/v1/admin/prompt-templates
+func (h *PromptTemplateHandler) CreateTemplate(c *gin.Context) {
+	var req PromptTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestError(c, "Invalid request body", err.Error())
+		return
+	}
+
+	template, err := h.templateUseCase.CreateTemplate(c.Request.Context(), req.Name, req.Description, req.Body)
+	if err != nil {
+		h.respondError(c, err, "Failed to create prompt template")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, template)
+}
+
+// GET /api/v1/admin/prompt-templates/:id
+func (h *PromptTemplateHandler) GetTemplate(c *gin.Context) {
+	template, err := h.templateUseCase.GetTemplate(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to get prompt template")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, template)
+}
+
+// PUT /api/v1/admin/prompt-templates/:id
+func (h *PromptTemplateHandler) UpdateTemplate(c *gin.Context) {
+	var req PromptTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestError(c, "Invalid request body", err.Error())
+		return
+	}
+
+	template, err := h.templateUseCase.UpdateTemplate(c.Request.Context(), c.Param("id"), req.Name, req.Description, req.Body)
+	if err != nil {
+		h.respondError(c, err, "Failed to update prompt template")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, template)
+}
+
+// DELETE /api/v1/admin/prompt-templates/:id
+func (h *PromptTemplateHandler) DeleteTemplate(c *gin.Context) {
+	templateID := c.Param("id")
+	if err := h.templateUseCase.DeleteTemplate(c.Request.Context(), templateID); err != nil {
+		h.respondError(c, err, "Failed to delete prompt template")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"id":      templateID,
+		"deleted": true,
+	})
+}
+
+// POST /api/v1/admin/prompt-templates/preview
+func (h *PromptTemplateHandler) PreviewTemplate(c *gin.Context) {
+	var req PreviewPromptTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestError(c, "Invalid request body", err.Error())
+		return
+	}
+
+	prompt, err := h.templateUseCase.PreviewTemplate(c.Request.Context(), req.Body, service.QuestionGenerationRequest{
+		Difficulty:    req.Difficulty,
+		Category:      req.Category,
+		Language:      req.Language,
+		Audience:      req.Audience,
+		Theme:         req.Theme,
+		ReferenceText: req.ReferenceText,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to preview prompt template")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, map[string]string{
+		"prompt": prompt,
+	})
+}
+
+func (h *PromptTemplateHandler) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrPromptTemplateNotFound):
+		utils.NotFoundError(c, "Prompt template not found")
+	case errors.Is(err, domain.ErrInvalidPromptTemplate):
+		utils.BadRequestError(c, "Invalid prompt template", err.Error())
+	case errors.Is(err, domain.ErrInvalidInput):
+		utils.BadRequestError(c, "Invalid input")
+	default:
+		utils.InternalServerError(c, message)
+	}
+}
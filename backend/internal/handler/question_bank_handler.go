@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"quiz-app/internal/domain"
+	"quiz-app/internal/usecase"
+	"quiz-app/pkg/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxQuestionBankImportSize インポートファイルの上限サイズ
+const maxQuestionBankImportSize = 5 << 20
+
+type QuestionBankHandler struct {
+	bankUseCase usecase.QuestionBankUseCase
+}
+
+func NewQuestionBankHandler(bankUseCase usecase.QuestionBankUseCase) *QuestionBankHandler {
+	return &QuestionBankHandler{
+		bankUseCase: bankUseCase,
+	}
+}
+
+type QuestionBankRequest struct {
+	Name        string                `json:"name" binding:"required"`
+	Description string                `json:"description"`
+	Questions   []domain.BankQuestion `json:"questions"`
+}
+
+// GET /api/v1/admin/question-banks
+func (h *QuestionBankHandler) ListBanks(c *gin.Context) {
+	banks, err := h.bankUseCase.ListBanks(c.Request.Context())
+	if err != nil {
+		utils.InternalServerError(c, "Failed to list question banks")
+		return
+	}
+
+	// 一覧では問題本文を返さず件数のみ返す
+	bankData := make([]map[string]interface{}, len(banks))
+	for i, bank := range banks {
+		bankData[i] = map[string]interface{}{
+			"id":            bank.ID,
+			"name":          bank.Name,
+			"description":   bank.Description,
+			"questionCount": len(bank.Questions),
+			"createdAt":     bank.CreatedAt,
+			"updatedAt":     bank.UpdatedAt,
+		}
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, bankData)
+}
+
+// POST /api/v1/admin/question-banks
+func (h *QuestionBankHandler) CreateBank(c *gin.Context) {
+	var req QuestionBankRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestError(c, "Invalid request body", err.Error())
+		return
+	}
+
+	bank, err := h.bankUseCase.CreateBank(c.Request.Context(), req.Name, req.Description, req.Questions)
+	if err != nil {
+		h.respondError(c, err, "Failed to create question bank")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, bank)
+}
+
+// GET /api/v1/admin/question-banks/:id
+func (h *QuestionBankHandler) GetBank(c *gin.Context) {
+	bank, err := h.bankUseCase.GetBank(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to get question bank")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, bank)
+}
+
+// PUT /api/v1/admin/question-banks/:id
+func (h *QuestionBankHandler) UpdateBank(c *gin.Context) {
+	var req QuestionBankRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestError(c, "Invalid request body", err.Error())
+		return
+	}
+
+	bank, err := h.bankUseCase.UpdateBank(c.Request.Context(), c.Param("id"), req.Name, req.Description, req.Questions)
+	if err != nil {
+		h.respondError(c, err, "Failed to update question bank")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, bank)
+}
+
+// DELETE /api/v1/admin/question-banks/:id
+func (h *QuestionBankHandler) DeleteBank(c *gin.Context) {
+	bankID := c.Param("id")
+	if err := h.bankUseCase.DeleteBank(c.Request.Context(), bankID); err != nil {
+		h.respondError(c, err, "Failed to delete question bank")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"id":      bankID,
+		"deleted": true,
+	})
+}
+
+// POST /api/v1/admin/question-banks/import?format=json|csv&name=...
+// リクエストボディ、または multipart の file フィールドでファイルを受け取る
+func (h *QuestionBankHandler) ImportBank(c *gin.Context) {
+	var reader io.Reader = c.Request.Body
+	filename := ""
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			utils.BadRequestError(c, "File is required", err.Error())
+			return
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			utils.BadRequestError(c, "Failed to read file", err.Error())
+			return
+		}
+		defer file.Close()
+		reader = file
+		filename = fileHeader.Filename
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxQuestionBankImportSize+1))
+	if err != nil {
+		utils.BadRequestError(c, "Failed to read file", err.Error())
+		return
+	}
+	if len(data) > maxQuestionBankImportSize {
+		utils.BadRequestError(c, "File is too large")
+		return
+	}
+
+	format := domain.QuestionBankFormat(c.Query("format"))
+	if format == "" {
+		format = detectQuestionBankFormat(c.ContentType(), filename)
+	}
+	if !domain.IsValidQuestionBankFormat(format) {
+		utils.BadRequestError(c, "Invalid format (json or csv)")
+		return
+	}
+
+	name := c.Query("name")
+	if name == "" && filename != "" && format == domain.QuestionBankFormatCSV {
+		name = strings.TrimSuffix(filename, ".csv")
+	}
+
+	bank, err := h.bankUseCase.ImportBank(c.Request.Context(), format, data, name)
+	if err != nil {
+		h.respondError(c, err, "Failed to import question bank")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, bank)
+}
+
+// GET /api/v1/admin/question-banks/:id/export?format=json|csv
+func (h *QuestionBankHandler) ExportBank(c *gin.Context) {
+	bankID := c.Param("id")
+	format := domain.QuestionBankFormat(c.DefaultQuery("format", string(domain.QuestionBankFormatJSON)))
+	if !domain.IsValidQuestionBankFormat(format) {
+		utils.BadRequestError(c, "Invalid format (json or csv)")
+		return
+	}
+
+	data, err := h.bankUseCase.ExportBank(c.Request.Context(), bankID, format)
+	if err != nil {
+		h.respondError(c, err, "Failed to export question bank")
+		return
+	}
+
+	// バンク名をファイル名に使用
+	bank, err := h.bankUseCase.GetBank(c.Request.Context(), bankID)
+	if err != nil {
+		h.respondError(c, err, "Failed to export question bank")
+		return
+	}
+
+	contentType := "application/json"
+	if format == domain.QuestionBankFormatCSV {
+		contentType = "text/csv"
+	}
+	filename := bank.Name + "." + string(format)
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, contentType, data)
+}
+
+func (h *QuestionBankHandler) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrQuestionBankNotFound):
+		utils.NotFoundError(c, "Question bank not found")
+	case errors.Is(err, domain.ErrInvalidQuestionBank):
+		utils.BadRequestError(c, "Invalid question bank", err.Error())
+	case errors.Is(err, domain.ErrInvalidInput):
+		utils.BadRequestError(c, "Question bank ID is required")
+	case errors.Is(err, domain.ErrInvalidMedia):
+		utils.BadRequestError(c, "Invalid media (uploaded media key, the same number of option media as options)")
+	case errors.Is(err, domain.ErrMediaNotFound):
+		utils.NotFoundError(c, "Media not found")
+	default:
+		utils.InternalServerError(c, message)
+	}
+}
+
+// detectQuestionBankFormat Content-Type とファイル名から形式を推定する（不明な場合は JSON）
+func detectQuestionBankFormat(contentType, filename string) domain.QuestionBankFormat {
+	if contentType == "text/csv" || strings.HasSuffix(strings.ToLower(filename), ".csv") {
+		return domain.QuestionBankFormatCSV
+	}
+	return domain.QuestionBankFormatJSON
+}
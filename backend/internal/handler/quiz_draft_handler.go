@@ -0,0 +1,302 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"quiz-app/internal/domain"
+	"quiz-app/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GenerateDraftQuestionsRequest struct {
+	Count        int                 `json:"count" binding:"required,min=1,max=50"`
+	Difficulties []domain.Difficulty `json:"difficulties"` // 順番に割り当てる（未指定は出題順に応じて自動）
+	Categories   []string            `json:"categories"`   // 順番に割り当てる（未指定は自動）
+}
+
+// UpdateDraftQuestionRequest 問題の内容と正解（正解の項目は domain.AnswerKey を参照、type 未指定は単一選択）
+type UpdateDraftQuestionRequest struct {
+	Text           string              `json:"text" binding:"required"`
+	Options        []string            `json:"options"` // 数値問題は空
+	Type           domain.QuestionType `json:"type"`
+	CorrectAnswer  int                 `json:"correctAnswer" binding:"min=0"`
+	CorrectAnswers []int               `json:"correctAnswers"`
+	NumericAnswer  float64             `json:"numericAnswer"`
+	Tolerance      float64             `json:"tolerance" binding:"min=0"`
+	Unit           string              `json:"unit"`
+	Difficulty     domain.Difficulty   `json:"difficulty" binding:"required"`
+	Category       string              `json:"category"`
+	Explanation    string              `json:"explanation"`
+}
+
+type UpdateQuestionExplanationRequest struct {
+	Explanation string `json:"explanation"`
+}
+
+// UpdateQuestionTranslationRequest 他言語版（選択肢は元の問題と同じ順序・同じ数）
+type UpdateQuestionTranslationRequest struct {
+	Text        string   `json:"text" binding:"required"`
+	Options     []string `json:"options" binding:"required"`
+	Explanation string   `json:"explanation"`
+}
+
+// UpdateQuestionMediaRequest 問題に添付するメディア（アップロードで返されたキーと代替テキスト）
+// optionMedia は選択肢と同じ順序・同じ数で、メディアのない選択肢は null
+type UpdateQuestionMediaRequest struct {
+	Media       *domain.Media   `json:"media"`
+	OptionMedia []*domain.Media `json:"optionMedia"`
+}
+
+type ReorderDraftQuestionsRequest struct {
+	QuestionIDs []string `json:"questionIds" binding:"required"`
+}
+
+// POST /api/v1/admin/sessions/:id/drafts/generate
+func (h *QuizHandler) GenerateDraftQuestions(c *gin.Context) {
+	var req GenerateDraftQuestionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestError(c, "Invalid request body", err.Error())
+		return
+	}
+
+	drafts, err := h.quizUseCase.GenerateDraftQuestions(c.Request.Context(), c.Param("id"), req.Count, req.Difficulties, req.Categories)
+	if err != nil {
+		// 途中まで生成した問題は保存済みのため、件数を添えて返す
+		h.respondDraftError(c, err, "Failed to generate draft questions", map[string]interface{}{
+			"generated": len(drafts),
+			"error":     err.Error(),
+		})
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, draftQuestionsData(drafts))
+}
+
+// GET /api/v1/admin/sessions/:id/drafts
+func (h *QuizHandler) GetDraftQuestions(c *gin.Context) {
+	drafts, err := h.quizUseCase.GetDraftQuestions(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondDraftError(c, err, "Failed to get draft questions")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, draftQuestionsData(drafts))
+}
+
+// PUT /api/v1/admin/sessions/:id/drafts/:questionId
+func (h *QuizHandler) UpdateDraftQuestion(c *gin.Context) {
+	var req UpdateDraftQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestError(c, "Invalid request body", err.Error())
+		return
+	}
+
+	key := domain.AnswerKey{
+		Type:           req.Type,
+		CorrectAnswer:  req.CorrectAnswer,
+		CorrectAnswers: req.CorrectAnswers,
+		NumericAnswer:  req.NumericAnswer,
+		Tolerance:      req.Tolerance,
+		Unit:           req.Unit,
+	}
+	question, err := h.quizUseCase.UpdateDraftQuestion(c.Request.Context(), c.Param("id"), c.Param("questionId"),
+		req.Text, req.Options, key, req.Difficulty, req.Category, req.Explanation)
+	if err != nil {
+		h.respondDraftError(c, err, "Failed to update draft question")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, draftQuestionData(question))
+}
+
+// POST /api/v1/admin/sessions/:id/drafts/reorder
+func (h *QuizHandler) ReorderDraftQuestions(c *gin.Context) {
+	var req ReorderDraftQuestionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestError(c, "Invalid request body", err.Error())
+		return
+	}
+
+	drafts, err := h.quizUseCase.ReorderDraftQuestions(c.Request.Context(), c.Param("id"), req.QuestionIDs)
+	if err != nil {
+		h.respondDraftError(c, err, "Failed to reorder draft questions")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, draftQuestionsData(drafts))
+}
+
+// POST /api/v1/admin/sessions/:id/drafts/:questionId/approve
+func (h *QuizHandler) ApproveDraftQuestion(c *gin.Context) {
+	h.reviewDraftQuestion(c, true)
+}
+
+// POST /api/v1/admin/sessions/:id/drafts/:questionId/reject
+func (h *QuizHandler) RejectDraftQuestion(c *gin.Context) {
+	h.reviewDraftQuestion(c, false)
+}
+
+func (h *QuizHandler) reviewDraftQuestion(c *gin.Context, approve bool) {
+	question, err := h.quizUseCase.ReviewDraftQuestion(c.Request.Context(), c.Param("id"), c.Param("questionId"), approve)
+	if err != nil {
+		h.respondDraftError(c, err, "Failed to review draft question")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, draftQuestionData(question))
+}
+
+// POST /api/v1/admin/sessions/:id/publish-question
+func (h *QuizHandler) PublishNextQuestion(c *gin.Context) {
+	question, err := h.quizUseCase.PublishNextQuestion(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondDraftError(c, err, "Failed to publish question")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, draftQuestionData(question))
+}
+
+// PUT /api/v1/admin/sessions/:id/questions/:questionId/explanation
+func (h *QuizHandler) UpdateQuestionExplanation(c *gin.Context) {
+	var req UpdateQuestionExplanationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestError(c, "Invalid request body", err.Error())
+		return
+	}
+
+	question, err := h.quizUseCase.UpdateQuestionExplanation(c.Request.Context(), c.Param("id"), c.Param("questionId"), req.Explanation)
+	if err != nil {
+		if err == domain.ErrQuestionNotFound {
+			utils.NotFoundError(c, "Question not found")
+			return
+		}
+		h.respondDraftError(c, err, "Failed to update explanation")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, draftQuestionData(question))
+}
+
+// PUT /api/v1/admin/sessions/:id/questions/:questionId/translations/:language
+func (h *QuizHandler) UpdateQuestionTranslation(c *gin.Context) {
+	var req UpdateQuestionTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestError(c, "Invalid request body", err.Error())
+		return
+	}
+
+	question, err := h.quizUseCase.UpdateQuestionTranslation(c.Request.Context(), c.Param("id"), c.Param("questionId"), c.Param("language"),
+		domain.QuestionTranslation{Text: req.Text, Options: req.Options, Explanation: req.Explanation})
+	if err != nil {
+		switch err {
+		case domain.ErrQuestionNotFound:
+			utils.NotFoundError(c, "Question not found")
+		case domain.ErrInvalidInput:
+			utils.BadRequestError(c, "Unsupported language (must differ from the question's language)")
+		case domain.ErrInvalidQuestion:
+			utils.BadRequestError(c, "Invalid translation (non-empty text and the same number of options as the question)")
+		default:
+			h.respondDraftError(c, err, "Failed to update translation")
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, draftQuestionData(question))
+}
+
+// PUT /api/v1/admin/sessions/:id/questions/:questionId/media
+// 出題前の問題に画像・音声を添付する（null で外す）
+func (h *QuizHandler) UpdateQuestionMedia(c *gin.Context) {
+	var req UpdateQuestionMediaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestError(c, "Invalid request body", err.Error())
+		return
+	}
+
+	question, err := h.quizUseCase.UpdateQuestionMedia(c.Request.Context(), c.Param("id"), c.Param("questionId"), req.Media, req.OptionMedia)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidMedia:
+			utils.BadRequestError(c, "Invalid media (uploaded media key, the same number of option media as options)")
+		case domain.ErrMediaNotFound:
+			utils.NotFoundError(c, "Media not found")
+		default:
+			h.respondDraftError(c, err, "Failed to update question media")
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, draftQuestionData(question))
+}
+
+func (h *QuizHandler) respondDraftError(c *gin.Context, err error, message string, details ...interface{}) {
+	// テンプレートの展開エラーは原因を含めて返す
+	if errors.Is(err, domain.ErrInvalidPromptTemplate) {
+		utils.BadRequestError(c, "Invalid prompt template", err.Error())
+		return
+	}
+
+	switch err {
+	case domain.ErrInvalidInput:
+		utils.BadRequestError(c, "Invalid input")
+	case domain.ErrInvalidQuestion:
+		utils.BadRequestError(c, "Invalid question (options and answer must match the question type, valid difficulty)")
+	case domain.ErrSessionNotFound:
+		utils.NotFoundError(c, "Session not found")
+	case domain.ErrQuestionNotFound:
+		utils.NotFoundError(c, "Draft question not found")
+	case domain.ErrQuestionBankNotFound:
+		utils.NotFoundError(c, "Question bank not found")
+	case domain.ErrPromptTemplateNotFound:
+		utils.NotFoundError(c, "Prompt template not found")
+	case domain.ErrInvalidSessionStatus:
+		utils.ConflictError(c, "Draft questions can only be generated before the session starts")
+	case domain.ErrSessionNotActive:
+		utils.ConflictError(c, "Session is not active")
+	case domain.ErrQuestionAlreadyPublished:
+		utils.ConflictError(c, "Question is already published")
+	case domain.ErrNoApprovedQuestion:
+		utils.ConflictError(c, "No approved question to publish")
+	case domain.ErrQuestionBankExhausted:
+		utils.ConflictError(c, "No unused questions left in question bank", details...)
+	case domain.ErrDuplicateQuestion:
+		utils.ConflictError(c, "Could not generate a question that differs from previous questions", details...)
+	case domain.ErrAIServiceUnavailable:
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "AI service is unavailable", details...)
+	default:
+		utils.InternalServerError(c, message, details...)
+	}
+}
+
+func draftQuestionData(q *domain.Question) map[string]interface{} {
+	return map[string]interface{}{
+		"id":            q.ID,
+		"text":          q.Text,
+		"options":       q.Options,
+		"correctAnswer": q.CorrectAnswer,
+		"round":         q.Round,
+		"type":          string(q.Kind()),
+		"answerKey":     q.AnswerKey(),
+		"media":         q.Media,
+		"optionMedia":   q.OptionMedia,
+		"category":      q.Category,
+		"difficulty":    string(q.Difficulty),
+		"aiProvider":    string(q.AIProvider),
+		"status":        string(q.Status),
+		"position":      q.Position,
+		"explanation":   q.Explanation,
+		"language":      q.SourceLanguage(),
+		"translations":  q.Translations,
+		"createdAt":     q.CreatedAt,
+	}
+}
+
+func draftQuestionsData(questions []*domain.Question) []map[string]interface{} {
+	data := make([]map[string]interface{}, len(questions))
+	for i, q := range questions {
+		data[i] = draftQuestionData(q)
+	}
+	return data
+}
@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"quiz-app/internal/domain"
 	"quiz-app/internal/middleware"
 	"quiz-app/internal/usecase"
+	"quiz-app/internal/websocket"
 	"quiz-app/pkg/utils"
 	"strconv"
 
@@ -24,10 +26,13 @@ func NewQuizHandler(quizUseCase usecase.QuizUseCase, sessionUseCase usecase.Sess
 	}
 }
 
+// SubmitAnswerRequest 回答は問題形式に応じて selectedOption / selectedOptions / numericValue のいずれかを指定する
 type SubmitAnswerRequest struct {
-	QuestionID     string `json:"questionId" binding:"required"`
-	SelectedOption int    `json:"selectedOption" binding:"required,min=0,max=3"`
-	ResponseTime   int    `json:"responseTime" binding:"required,min=0"`
+	QuestionID      string   `json:"questionId" binding:"required"`
+	SelectedOption  *int     `json:"selectedOption" binding:"omitempty,min=0,max=3"`   // 単一選択・○×
+	SelectedOptions []int    `json:"selectedOptions" binding:"max=4,dive,min=0,max=3"` // 複数選択・並べ替え
+	NumericValue    *float64 `json:"numericValue"`                                     // 数値問題
+	ResponseTime    int      `json:"responseTime" binding:"min=0"`                     // クライアント申告値（診断用）
 }
 
 // GET /api/v1/sessions/:id/current-question
@@ -81,6 +86,10 @@ func (h *QuizHandler) GetCurrentQuestion(c *gin.Context) {
 		"round":    question.Round,
 		"category": question.Category,
 		"difficulty": string(question.Difficulty),
+		"type":     string(question.Kind()),
+		"unit":     question.Unit,
+		"media":    question.Media,
+		"optionMedia": question.OptionMedia,
 		"createdAt": question.CreatedAt,
 	}
 
@@ -111,6 +120,10 @@ func (h *QuizHandler) GetAdminCurrentQuestion(c *gin.Context) {
 		"questionText": question.Text, // フロントエンド互換性のため
 		"options":     question.Options,
 		"correctAnswer": question.CorrectAnswer,
+		"type":        string(question.Kind()),
+		"answerKey":   question.AnswerKey(),
+		"media":       question.Media,
+		"optionMedia": question.OptionMedia,
 		"round":       question.Round,
 		"category":    question.Category,
 		"difficulty":  string(question.Difficulty),
@@ -169,6 +182,10 @@ func (h *QuizHandler) GetAllQuestions(c *gin.Context) {
 			"round":     q.Round,
 			"category":  q.Category,
 			"difficulty": string(q.Difficulty),
+			"type":      string(q.Kind()),
+			"unit":      q.Unit,
+			"media":     q.Media,
+			"optionMedia": q.OptionMedia,
 			"createdAt": q.CreatedAt,
 		}
 	}
@@ -198,6 +215,10 @@ func (h *QuizHandler) GetAdminAllQuestions(c *gin.Context) {
 			"questionText": q.Text, // フロントエンド互換性のため
 			"options":      q.Options,
 			"correctAnswer": q.CorrectAnswer,
+			"type":         string(q.Kind()),
+			"answerKey":    q.AnswerKey(),
+			"media":        q.Media,
+			"optionMedia":  q.OptionMedia,
 			"round":        q.Round,
 			"category":     q.Category,
 			"difficulty":   string(q.Difficulty),
@@ -233,7 +254,11 @@ func (h *QuizHandler) SubmitAnswer(c *gin.Context) {
 		sessionID,
 		userID,
 		req.QuestionID,
-		req.SelectedOption,
+		domain.AnswerInput{
+			SelectedOption:  req.SelectedOption,
+			SelectedOptions: req.SelectedOptions,
+			NumericValue:    req.NumericValue,
+		},
 		req.ResponseTime,
 	)
 
@@ -251,6 +276,10 @@ func (h *QuizHandler) SubmitAnswer(c *gin.Context) {
 			utils.NotFoundError(c, "Question not found")
 		case domain.ErrAnswerExists:
 			utils.ConflictError(c, "Answer already submitted")
+		case domain.ErrTimeExpired:
+			utils.ConflictError(c, "Answer time expired")
+		case domain.ErrInvalidAnswer:
+			utils.BadRequestError(c, "Answer does not match the question type")
 		default:
 			utils.InternalServerError(c, "Failed to submit answer")
 		}
@@ -258,12 +287,17 @@ func (h *QuizHandler) SubmitAnswer(c *gin.Context) {
 	}
 
 	response := map[string]interface{}{
-		"answerId":       answer.ID,
-		"questionId":     answer.QuestionID,
-		"selectedOption": answer.SelectedOption,
-		"isCorrect":      answer.IsCorrect,
-		"responseTime":   answer.ResponseTime,
-		"answeredAt":     answer.AnsweredAt,
+		"answerId":           answer.ID,
+		"questionId":         answer.QuestionID,
+		"selectedOption":     answer.SelectedOption,
+		"selectedOptions":    answer.SelectedOptions,
+		"numericValue":       answer.NumericValue,
+		"isCorrect":          answer.IsCorrect,
+		"pending":            answer.Pending, // 最も近い回答が正解となる数値問題は締め切り後に判定する
+		"responseTime":       answer.ResponseTime,
+		"clientResponseTime": answer.ClientResponseTime,
+		"points":             answer.Points,
+		"answeredAt":         answer.AnsweredAt,
 	}
 
 	utils.SuccessResponse(c, http.StatusCreated, response)
@@ -325,7 +359,25 @@ func (h *QuizHandler) GenerateQuestion(c *gin.Context) {
 
 	question, err := h.quizUseCase.GenerateQuestion(c.Request.Context(), sessionID, round, difficulty, category)
 	if err != nil {
-		utils.InternalServerError(c, "Failed to generate question", err.Error())
+		if errors.Is(err, domain.ErrInvalidPromptTemplate) {
+			utils.BadRequestError(c, "Invalid prompt template", err.Error())
+			return
+		}
+
+		switch err {
+		case domain.ErrQuestionBankNotFound:
+			utils.NotFoundError(c, "Question bank not found")
+		case domain.ErrPromptTemplateNotFound:
+			utils.NotFoundError(c, "Prompt template not found")
+		case domain.ErrQuestionBankExhausted:
+			utils.ConflictError(c, "No unused questions left in question bank")
+		case domain.ErrAIServiceUnavailable:
+			utils.ErrorResponse(c, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "AI service is unavailable")
+		case domain.ErrDuplicateQuestion:
+			utils.ConflictError(c, "Could not generate a question that differs from previous questions")
+		default:
+			utils.InternalServerError(c, "Failed to generate question", err.Error())
+		}
 		return
 	}
 
@@ -339,6 +391,13 @@ func (h *QuizHandler) GenerateQuestion(c *gin.Context) {
 		"aiProvider": string(question.AIProvider),
 		"createdAt":  question.CreatedAt,
 	}
+	if question.BankQuestionID != "" {
+		response["bankQuestionId"] = question.BankQuestionID
+	}
+	// 難易度を自動で決めた場合はその根拠（方式・正解率・生存者数など）
+	if question.DifficultyDecision != nil {
+		response["difficultyDecision"] = question.DifficultyDecision
+	}
 
 	utils.SuccessResponse(c, http.StatusCreated, response)
 }
@@ -370,36 +429,59 @@ func (h *QuizHandler) ProcessRoundResults(c *gin.Context) {
 		return
 	}
 
-	survivors, eliminated, err := h.quizUseCase.ProcessRoundResults(c.Request.Context(), sessionID, questionID)
+	result, err := h.quizUseCase.ProcessRoundResults(c.Request.Context(), sessionID, questionID)
 	if err != nil {
-		utils.InternalServerError(c, "Failed to process round results", err.Error())
+		switch err {
+		case domain.ErrRoundAlreadyProcessed:
+			utils.ErrorResponse(c, http.StatusConflict, "CONFLICT", "Round results are already processed")
+		default:
+			utils.InternalServerError(c, "Failed to process round results", err.Error())
+		}
 		return
 	}
 
-	survivorData := make([]map[string]interface{}, len(survivors))
-	for i, p := range survivors {
-		survivorData[i] = map[string]interface{}{
-			"userId":      p.UserID,
-			"displayName": p.DisplayName,
-			"score":       p.Score,
-		}
+	utils.SuccessResponse(c, http.StatusOK, websocket.RoundResultData(result))
+}
+
+// GET /api/v1/sessions/:id/leaderboard
+func (h *QuizHandler) GetLeaderboard(c *gin.Context) {
+	sessionID := c.Param("id")
+	if sessionID == "" {
+		utils.BadRequestError(c, "Session ID is required")
+		return
 	}
 
-	eliminatedData := make([]map[string]interface{}, len(eliminated))
-	for i, p := range eliminated {
-		eliminatedData[i] = map[string]interface{}{
-			"userId":      p.UserID,
-			"displayName": p.DisplayName,
-			"score":       p.Score,
+	leaderboard, err := h.quizUseCase.GetLeaderboard(c.Request.Context(), sessionID)
+	if err != nil {
+		switch err {
+		case domain.ErrSessionNotFound:
+			utils.NotFoundError(c, "Session not found")
+		default:
+			utils.InternalServerError(c, "Failed to get leaderboard")
 		}
+		return
 	}
 
-	response := map[string]interface{}{
-		"survivors":  survivorData,
-		"eliminated": eliminatedData,
+	utils.SuccessResponse(c, http.StatusOK, map[string]interface{}{
+		"sessionId":   sessionID,
+		"leaderboard": leaderboard,
+	})
+}
+
+// GET /api/v1/admin/ai/providers
+func (h *QuizHandler) GetAIProviderHealth(c *gin.Context) {
+	health, err := h.quizUseCase.GetAIProviderHealth(c.Request.Context())
+	if err != nil {
+		switch err {
+		case domain.ErrAIServiceUnavailable:
+			utils.ErrorResponse(c, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "AI service is unavailable")
+		default:
+			utils.InternalServerError(c, "Failed to get AI provider stats")
+		}
+		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, response)
+	utils.SuccessResponse(c, http.StatusOK, health)
 }
 
 // POST /api/v1/sessions/:id/next-round
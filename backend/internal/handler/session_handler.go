@@ -24,6 +24,12 @@ func NewSessionHandler(sessionUseCase usecase.SessionUseCase, userUseCase usecas
 
 type JoinSessionRequest struct {
 	DisplayName string `json:"displayName" binding:"required"`
+	Language    string `json:"language"` // 問題を表示する言語（"en" など。未指定は問題の元の言語）
+}
+
+// validLanguage 参加リクエストの言語が対応言語か（未指定は可）
+func (r *JoinSessionRequest) validLanguage() bool {
+	return r.Language == "" || domain.IsSupportedLanguage(domain.NormalizeLanguage(r.Language))
 }
 
 // GET /api/v1/sessions
@@ -133,6 +139,10 @@ func (h *SessionHandler) JoinSession(c *gin.Context) {
 		utils.BadRequestError(c, "Invalid request body", err.Error())
 		return
 	}
+	if !req.validLanguage() {
+		utils.BadRequestError(c, "Unsupported language")
+		return
+	}
 
 	// ユーザー作成または取得
 	_, err := h.userUseCase.GetUser(c.Request.Context(), userID)
@@ -145,7 +155,7 @@ func (h *SessionHandler) JoinSession(c *gin.Context) {
 		}
 	}
 
-	participant, err := h.sessionUseCase.JoinSession(c.Request.Context(), sessionID, userID, req.DisplayName)
+	participant, err := h.sessionUseCase.JoinSession(c.Request.Context(), sessionID, userID, req.DisplayName, req.Language)
 	if err != nil {
 		switch err.Error() {
 		case "session not found":
@@ -167,6 +177,7 @@ func (h *SessionHandler) JoinSession(c *gin.Context) {
 		"displayName":  participant.DisplayName,
 		"status":       string(participant.Status),
 		"joinedAt":     participant.JoinedAt,
+		"language":     participant.Language,
 	}
 
 	utils.SuccessResponse(c, http.StatusCreated, response)
@@ -198,11 +209,15 @@ func (h *SessionHandler) AdminJoinSession(c *gin.Context) {
 		utils.BadRequestError(c, "Invalid request body", err.Error())
 		return
 	}
+	if !req.validLanguage() {
+		utils.BadRequestError(c, "Unsupported language")
+		return
+	}
 
 	// 管理者用のIDとして、User.IDを使用
 	userID := domainUser.ID
 
-	participant, err := h.sessionUseCase.JoinSession(c.Request.Context(), sessionID, userID, req.DisplayName)
+	participant, err := h.sessionUseCase.JoinSession(c.Request.Context(), sessionID, userID, req.DisplayName, req.Language)
 	if err != nil {
 		switch err.Error() {
 		case "session not found":
@@ -224,6 +239,7 @@ func (h *SessionHandler) AdminJoinSession(c *gin.Context) {
 		"displayName":  participant.DisplayName,
 		"status":       string(participant.Status),
 		"joinedAt":     participant.JoinedAt,
+		"language":     participant.Language,
 	}
 
 	utils.SuccessResponse(c, http.StatusCreated, response)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"quiz-app/internal/domain"
 	"quiz-app/internal/repository"
 )
 
@@ -108,4 +109,19 @@ func AdminSessionMiddleware(userRepo repository.UserRepository) gin.HandlerFunc
 		c.Set("user_id", userID)
 		c.Next()
 	})
-}
\ No newline at end of file
+}
+
+// GetSessionAdmin AdminSessionMiddleware と同じ Cookie セッションでログインしている管理者を取得する
+// 未ログイン・管理者以外の場合は false（WebSocket のように中断せず権限だけを判定する場合に使う）
+func GetSessionAdmin(c *gin.Context, userRepo repository.UserRepository) (*domain.User, bool) {
+	userID, ok := sessions.Default(c).Get("user_id").(string)
+	if !ok || userID == "" {
+		return nil, false
+	}
+
+	user, err := userRepo.GetByID(c.Request.Context(), userID)
+	if err != nil || !user.IsAdmin() {
+		return nil, false
+	}
+	return user, true
+}
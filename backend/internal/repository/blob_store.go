@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"quiz-app/internal/domain"
+	"quiz-app/pkg/config"
+	"strings"
+	"sync"
+)
+
+// defaultMediaURL メディアをサーバーから配信する場合の URL の接頭辞（handler.MediaHandler.GetMedia）
+const defaultMediaURL = "/api/v1/media/"
+
+// NewBlobStore 設定に応じたメディアの保存先を作成する
+func NewBlobStore(ctx context.Context, cfg *config.Config) (BlobStore, error) {
+	publicURL := cfg.Media.PublicURL
+	if publicURL == "" {
+		publicURL = defaultMediaURL
+	}
+
+	switch cfg.Media.Backend {
+	case "memory":
+		return NewMemoryBlobStore(publicURL), nil
+	case "bucket":
+		return NewBucketBlobStore(ctx, cfg, publicURL)
+	case "filesystem", "":
+		return NewFileBlobStore(cfg.Media.Dir, publicURL)
+	default:
+		return nil, fmt.Errorf("unknown media backend: %s", cfg.Media.Backend)
+	}
+}
+
+// mediaURL 接頭辞とキーから配信用 URL を作る
+func mediaURL(publicURL, key string) string {
+	return strings.TrimSuffix(publicURL, "/") + "/" + key
+}
+
+// FileBlobStore BlobStoreのファイルシステム実装（ローカル開発・単一サーバー向け）
+// Content-Type はキーの拡張子から決める
+type FileBlobStore struct {
+	dir       string
+	publicURL string
+}
+
+func NewFileBlobStore(dir, publicURL string) (*FileBlobStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create media directory: %w", err)
+	}
+	return &FileBlobStore{dir: dir, publicURL: publicURL}, nil
+}
+
+// path キーに対応するファイルのパス（パスを含むキーは受け付けない）
+func (s *FileBlobStore) path(key string) (string, error) {
+	if _, err := domain.MediaForKey(key, ""); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, key), nil
+}
+
+// Put 一時ファイルに書き込んでから置き換え、書き込み途中のファイルを配信しないようにする
+func (s *FileBlobStore) Put(ctx context.Context, key, contentType string, r io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *FileBlobStore) Open(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, "", domain.ErrMediaNotFound
+	}
+	media, _ := domain.MediaForKey(key, "")
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, "", domain.ErrMediaNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return file, media.ContentType, nil
+}
+
+func (s *FileBlobStore) Exists(ctx context.Context, key string) (bool, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return false, nil
+	}
+	_, err = os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *FileBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return domain.ErrMediaNotFound
+	}
+	if err := os.Remove(path); errors.Is(err, os.ErrNotExist) {
+		return domain.ErrMediaNotFound
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *FileBlobStore) URL(key string) string {
+	return mediaURL(s.publicURL, key)
+}
+
+// memoryBlob インメモリに保存したメディア
+type memoryBlob struct {
+	data        []byte
+	contentType string
+}
+
+// MemoryBlobStore BlobStoreのインメモリ実装（デモ・テスト用）
+type MemoryBlobStore struct {
+	mu        sync.RWMutex
+	blobs     map[string]memoryBlob
+	publicURL string
+}
+
+func NewMemoryBlobStore(publicURL string) *MemoryBlobStore {
+	return &MemoryBlobStore{
+		blobs:     make(map[string]memoryBlob),
+		publicURL: publicURL,
+	}
+}
+
+func (s *MemoryBlobStore) Put(ctx context.Context, key, contentType string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = memoryBlob{data: data, contentType: contentType}
+	return nil
+}
+
+func (s *MemoryBlobStore) Open(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	blob, ok := s.blobs[key]
+	if !ok {
+		return nil, "", domain.ErrMediaNotFound
+	}
+	return io.NopCloser(bytes.NewReader(blob.data)), blob.contentType, nil
+}
+
+func (s *MemoryBlobStore) Exists(ctx context.Context, key string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.blobs[key]
+	return ok, nil
+}
+
+func (s *MemoryBlobStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.blobs[key]; !ok {
+		return domain.ErrMediaNotFound
+	}
+	delete(s.blobs, key)
+	return nil
+}
+
+func (s *MemoryBlobStore) URL(key string) string {
+	return mediaURL(s.publicURL, key)
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"quiz-app/internal/domain"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobStore(t *testing.T) {
+	ctx := context.Background()
+
+	fileStore, err := NewFileBlobStore(filepath.Join(t.TempDir(), "media"), "https://cdn.example.com/quiz/")
+	require.NoError(t, err)
+
+	stores := map[string]BlobStore{
+		"filesystem": fileStore,
+		"memory":     NewMemoryBlobStore(defaultMediaURL),
+	}
+
+	for name, store := range stores {
+		t.Run(name+": 保存したメディアを読み込み・削除できること", func(t *testing.T) {
+			key, err := domain.NewMediaKey("image/png")
+			require.NoError(t, err)
+
+			exists, err := store.Exists(ctx, key)
+			require.NoError(t, err)
+			assert.False(t, exists)
+
+			require.NoError(t, store.Put(ctx, key, "image/png", strings.NewReader("png-data")))
+			exists, err = store.Exists(ctx, key)
+			require.NoError(t, err)
+			assert.True(t, exists)
+
+			r, contentType, err := store.Open(ctx, key)
+			require.NoError(t, err)
+			data, err := io.ReadAll(r)
+			r.Close()
+			require.NoError(t, err)
+			assert.Equal(t, "png-data", string(data))
+			assert.Equal(t, "image/png", contentType)
+
+			require.NoError(t, store.Delete(ctx, key))
+			_, _, err = store.Open(ctx, key)
+			assert.ErrorIs(t, err, domain.ErrMediaNotFound)
+			assert.ErrorIs(t, store.Delete(ctx, key), domain.ErrMediaNotFound)
+		})
+	}
+
+	t.Run("配信用URLは設定した接頭辞とキーから作られること", func(t *testing.T) {
+		assert.Equal(t, "https://cdn.example.com/quiz/a.png", fileStore.URL("a.png"))
+		assert.Equal(t, "/api/v1/media/a.png", NewMemoryBlobStore(defaultMediaURL).URL("a.png"))
+	})
+
+	t.Run("ファイルシステムの保存先の外を指すキーは受け付けないこと", func(t *testing.T) {
+		dir := t.TempDir()
+		store, err := NewFileBlobStore(filepath.Join(dir, "media"), defaultMediaURL)
+		require.NoError(t, err)
+
+		err = store.Put(ctx, "../secret.png", "image/png", strings.NewReader("x"))
+		assert.ErrorIs(t, err, domain.ErrInvalidMedia)
+		_, err = os.Stat(filepath.Join(dir, "secret.png"))
+		assert.True(t, os.IsNotExist(err))
+
+		_, _, err = store.Open(ctx, "../media/x.png")
+		assert.ErrorIs(t, err, domain.ErrMediaNotFound)
+	})
+}
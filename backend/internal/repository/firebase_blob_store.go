@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"quiz-app/internal/domain"
+	"quiz-app/pkg/config"
+
+	"cloud.google.com/go/storage"
+)
+
+// BucketBlobStore BlobStoreの Cloud Storage 実装（本番環境向け。バケットは FIREBASE_STORAGE_BUCKET）
+// URL は MEDIA_PUBLIC_URL 未設定ならサーバー経由で配信する（バケットを公開する必要はない）
+type BucketBlobStore struct {
+	bucket    *storage.BucketHandle
+	publicURL string
+}
+
+func NewBucketBlobStore(ctx context.Context, cfg *config.Config, publicURL string) (*BucketBlobStore, error) {
+	if cfg.Firebase.StorageBucket == "" {
+		return nil, fmt.Errorf("FIREBASE_STORAGE_BUCKET is required for the bucket media backend")
+	}
+
+	app, err := newFirebaseApp(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	client, err := app.Storage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bucket, err := client.Bucket(cfg.Firebase.StorageBucket)
+	if err != nil {
+		return nil, err
+	}
+	return &BucketBlobStore{bucket: bucket, publicURL: publicURL}, nil
+}
+
+func (s *BucketBlobStore) Put(ctx context.Context, key, contentType string, r io.Reader) error {
+	writer := s.bucket.Object(key).NewWriter(ctx)
+	writer.ContentType = contentType
+	if _, err := io.Copy(writer, r); err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+func (s *BucketBlobStore) Open(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	reader, err := s.bucket.Object(key).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, "", domain.ErrMediaNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return reader, reader.Attrs.ContentType, nil
+}
+
+func (s *BucketBlobStore) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := s.bucket.Object(key).Attrs(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *BucketBlobStore) Delete(ctx context.Context, key string) error {
+	err := s.bucket.Object(key).Delete(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return domain.ErrMediaNotFound
+	}
+	return err
+}
+
+func (s *BucketBlobStore) URL(key string) string {
+	return mediaURL(s.publicURL, key)
+}
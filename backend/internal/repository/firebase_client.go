@@ -15,17 +15,51 @@ import (
 )
 
 type FirebaseClient struct {
-	App             *firebase.App
-	Firestore       *firestore.Client
-	Auth            *auth.Client
-	SessionRepo     SessionRepository
-	UserRepo        UserRepository
-	ParticipantRepo ParticipantRepository
-	QuestionRepo    QuestionRepository
-	AnswerRepo      AnswerRepository
+	Repositories
+	App       *firebase.App
+	Firestore *firestore.Client
+	Auth      *auth.Client
 }
 
 func NewFirebaseClient(ctx context.Context, cfg *config.Config) (*FirebaseClient, error) {
+	app, err := newFirebaseApp(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Firestore クライアント初期化
+	firestoreClient, err := app.Firestore(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Auth クライアント初期化
+	authClient, err := app.Auth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Repository初期化
+	firebaseRepo := NewFirebaseRepository(firestoreClient)
+
+	return &FirebaseClient{
+		Repositories: Repositories{
+			SessionRepo:      &SessionRepositoryImpl{firebaseRepo},
+			UserRepo:         NewFirebaseUserRepository(firestoreClient),
+			ParticipantRepo:  &ParticipantRepositoryImpl{firebaseRepo},
+			QuestionRepo:     &QuestionRepositoryImpl{firebaseRepo},
+			AnswerRepo:       &AnswerRepositoryImpl{firebaseRepo},
+			QuestionBankRepo: &QuestionBankRepositoryImpl{firebaseRepo},
+			TemplateRepo:     &PromptTemplateRepositoryImpl{firebaseRepo},
+		},
+		App:       app,
+		Firestore: firestoreClient,
+		Auth:      authClient,
+	}, nil
+}
+
+// newFirebaseApp 環境に応じた認証情報で Firebase アプリを初期化する
+func newFirebaseApp(ctx context.Context, cfg *config.Config) (*firebase.App, error) {
 	var app *firebase.App
 	var err error
 
@@ -63,36 +97,7 @@ func NewFirebaseClient(ctx context.Context, cfg *config.Config) (*FirebaseClient
 		}
 		app, err = firebase.NewApp(ctx, firebaseConfig)
 	}
-
-	if err != nil {
-		return nil, err
-	}
-
-	// Firestore クライアント初期化
-	firestoreClient, err := app.Firestore(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	// Auth クライアント初期化
-	authClient, err := app.Auth(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	// Repository初期化
-	firebaseRepo := NewFirebaseRepository(firestoreClient)
-
-	return &FirebaseClient{
-		App:             app,
-		Firestore:       firestoreClient,
-		Auth:            authClient,
-		SessionRepo:     &SessionRepositoryImpl{firebaseRepo},
-		UserRepo:        NewFirebaseUserRepository(firestoreClient),
-		ParticipantRepo: &ParticipantRepositoryImpl{firebaseRepo},
-		QuestionRepo:    &QuestionRepositoryImpl{firebaseRepo},
-		AnswerRepo:      &AnswerRepositoryImpl{firebaseRepo},
-	}, nil
+	return app, err
 }
 
 func (fc *FirebaseClient) Close() error {
@@ -242,4 +247,52 @@ func (r *AnswerRepositoryImpl) Delete(ctx context.Context, id string) error {
 
 func (r *AnswerRepositoryImpl) CountCorrectByUserAndSession(ctx context.Context, userID, sessionID string) (int, error) {
 	return r.CountCorrectAnswersByUserAndSession(ctx, sessionID, userID)
-}
\ No newline at end of file
+}
+
+type QuestionBankRepositoryImpl struct {
+	*FirebaseRepository
+}
+
+func (r *QuestionBankRepositoryImpl) Create(ctx context.Context, bank *domain.QuestionBank) error {
+	return r.CreateQuestionBank(ctx, bank)
+}
+
+func (r *QuestionBankRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.QuestionBank, error) {
+	return r.GetQuestionBankByID(ctx, id)
+}
+
+func (r *QuestionBankRepositoryImpl) List(ctx context.Context) ([]*domain.QuestionBank, error) {
+	return r.ListQuestionBanks(ctx)
+}
+
+func (r *QuestionBankRepositoryImpl) Update(ctx context.Context, bank *domain.QuestionBank) error {
+	return r.UpdateQuestionBank(ctx, bank)
+}
+
+func (r *QuestionBankRepositoryImpl) Delete(ctx context.Context, id string) error {
+	return r.DeleteQuestionBank(ctx, id)
+}
+
+type PromptTemplateRepositoryImpl struct {
+	*FirebaseRepository
+}
+
+func (r *PromptTemplateRepositoryImpl) Create(ctx context.Context, template *domain.PromptTemplate) error {
+	return r.CreatePromptTemplate(ctx, template)
+}
+
+func (r *PromptTemplateRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.PromptTemplate, error) {
+	return r.GetPromptTemplateByID(ctx, id)
+}
+
+func (r *PromptTemplateRepositoryImpl) List(ctx context.Context) ([]*domain.PromptTemplate, error) {
+	return r.ListPromptTemplates(ctx)
+}
+
+func (r *PromptTemplateRepositoryImpl) Update(ctx context.Context, template *domain.PromptTemplate) error {
+	return r.UpdatePromptTemplate(ctx, template)
+}
+
+func (r *PromptTemplateRepositoryImpl) Delete(ctx context.Context, id string) error {
+	return r.DeletePromptTemplate(ctx, id)
+}
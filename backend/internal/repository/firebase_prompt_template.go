@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"quiz-app/internal/domain"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+// PromptTemplateRepository Implementation
+func (r *FirebaseRepository) CreatePromptTemplate(ctx context.Context, template *domain.PromptTemplate) error {
+	if template.ID == "" {
+		docRef := r.client.Collection("promptTemplates").NewDoc()
+		template.ID = docRef.ID
+	}
+
+	_, err := r.client.Collection("promptTemplates").Doc(template.ID).Set(ctx, template)
+	return err
+}
+
+func (r *FirebaseRepository) GetPromptTemplateByID(ctx context.Context, id string) (*domain.PromptTemplate, error) {
+	doc, err := r.client.Collection("promptTemplates").Doc(id).Get(ctx)
+	if doc != nil && !doc.Exists() {
+		return nil, domain.ErrPromptTemplateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompt template: %w", err)
+	}
+
+	var template domain.PromptTemplate
+	if err := doc.DataTo(&template); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal prompt template: %w", err)
+	}
+
+	return &template, nil
+}
+
+func (r *FirebaseRepository) ListPromptTemplates(ctx context.Context) ([]*domain.PromptTemplate, error) {
+	iter := r.client.Collection("promptTemplates").OrderBy("createdAt", firestore.Desc).Documents(ctx)
+	defer iter.Stop()
+
+	templates := []*domain.PromptTemplate{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate prompt templates: %w", err)
+		}
+
+		var template domain.PromptTemplate
+		if err := doc.DataTo(&template); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal prompt template: %w", err)
+		}
+		templates = append(templates, &template)
+	}
+
+	return templates, nil
+}
+
+func (r *FirebaseRepository) UpdatePromptTemplate(ctx context.Context, template *domain.PromptTemplate) error {
+	if _, err := r.GetPromptTemplateByID(ctx, template.ID); err != nil {
+		return err
+	}
+
+	_, err := r.client.Collection("promptTemplates").Doc(template.ID).Set(ctx, template)
+	return err
+}
+
+func (r *FirebaseRepository) DeletePromptTemplate(ctx context.Context, id string) error {
+	_, err := r.client.Collection("promptTemplates").Doc(id).Delete(ctx)
+	return err
+}
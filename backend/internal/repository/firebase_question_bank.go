@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"quiz-app/internal/domain"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+// QuestionBankRepository Implementation
+func (r *FirebaseRepository) CreateQuestionBank(ctx context.Context, bank *domain.QuestionBank) error {
+	if bank.ID == "" {
+		docRef := r.client.Collection("questionBanks").NewDoc()
+		bank.ID = docRef.ID
+	}
+
+	_, err := r.client.Collection("questionBanks").Doc(bank.ID).Set(ctx, bank)
+	return err
+}
+
+func (r *FirebaseRepository) GetQuestionBankByID(ctx context.Context, id string) (*domain.QuestionBank, error) {
+	doc, err := r.client.Collection("questionBanks").Doc(id).Get(ctx)
+	if doc != nil && !doc.Exists() {
+		return nil, domain.ErrQuestionBankNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question bank: %w", err)
+	}
+
+	var bank domain.QuestionBank
+	if err := doc.DataTo(&bank); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal question bank: %w", err)
+	}
+
+	return &bank, nil
+}
+
+func (r *FirebaseRepository) ListQuestionBanks(ctx context.Context) ([]*domain.QuestionBank, error) {
+	iter := r.client.Collection("questionBanks").OrderBy("createdAt", firestore.Desc).Documents(ctx)
+	defer iter.Stop()
+
+	banks := []*domain.QuestionBank{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate question banks: %w", err)
+		}
+
+		var bank domain.QuestionBank
+		if err := doc.DataTo(&bank); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal question bank: %w", err)
+		}
+		banks = append(banks, &bank)
+	}
+
+	return banks, nil
+}
+
+func (r *FirebaseRepository) UpdateQuestionBank(ctx context.Context, bank *domain.QuestionBank) error {
+	if _, err := r.GetQuestionBankByID(ctx, bank.ID); err != nil {
+		return err
+	}
+
+	_, err := r.client.Collection("questionBanks").Doc(bank.ID).Set(ctx, bank)
+	return err
+}
+
+func (r *FirebaseRepository) DeleteQuestionBank(ctx context.Context, id string) error {
+	_, err := r.client.Collection("questionBanks").Doc(id).Delete(ctx)
+	return err
+}
@@ -7,6 +7,8 @@ import (
 
 	"cloud.google.com/go/firestore"
 	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type FirebaseRepository struct {
@@ -33,7 +35,10 @@ func (r *FirebaseRepository) CreateSession(ctx context.Context, session *domain.
 func (r *FirebaseRepository) GetSessionByID(ctx context.Context, id string) (*domain.Session, error) {
 	doc, err := r.client.Collection("sessions").Doc(id).Get(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("session not found: %w", err)
+		if status.Code(err) == codes.NotFound {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 
 	var session domain.Session
@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"io"
 	"quiz-app/internal/domain"
+	"time"
 )
 
 type SessionRepository interface {
@@ -63,4 +65,60 @@ type AnswerRepository interface {
 	Update(ctx context.Context, answer *domain.Answer) error
 	Delete(ctx context.Context, id string) error
 	CountCorrectByUserAndSession(ctx context.Context, userID, sessionID string) (int, error)
-}
\ No newline at end of file
+}
+
+// QuestionBankRepository 事前作成した問題バンクの保存先
+type QuestionBankRepository interface {
+	Create(ctx context.Context, bank *domain.QuestionBank) error
+	GetByID(ctx context.Context, id string) (*domain.QuestionBank, error)
+	List(ctx context.Context) ([]*domain.QuestionBank, error)
+	Update(ctx context.Context, bank *domain.QuestionBank) error
+	Delete(ctx context.Context, id string) error
+}
+
+// PromptTemplateRepository 問題生成プロンプトのテンプレートの保存先
+type PromptTemplateRepository interface {
+	Create(ctx context.Context, template *domain.PromptTemplate) error
+	GetByID(ctx context.Context, id string) (*domain.PromptTemplate, error)
+	List(ctx context.Context) ([]*domain.PromptTemplate, error)
+	Update(ctx context.Context, template *domain.PromptTemplate) error
+	Delete(ctx context.Context, id string) error
+}
+
+// BlobStore 問題に添付する画像・音声の保存先（キーは domain.NewMediaKey で作成する）
+type BlobStore interface {
+	Put(ctx context.Context, key, contentType string, r io.Reader) error
+	// Open 保存したメディアと Content-Type を返す（存在しない場合は domain.ErrMediaNotFound）
+	Open(ctx context.Context, key string) (io.ReadCloser, string, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	Delete(ctx context.Context, key string) error
+	// URL 参加者に配信する URL
+	URL(key string) string
+}
+
+// RoundStore 出題中の問題の回答受付の状態をセッションごとに保持する
+// 複数のサーバーで動かす場合は全サーバーで共有し、どのサーバーでも同じ締め切りで回答を判定し、結果処理を1回だけ行う
+type RoundStore interface {
+	// Start セッションの問題を差し替える（retainUntil を過ぎたら破棄してよい。ゼロ値は締め切るまで保持する）
+	Start(ctx context.Context, round *domain.Round, retainUntil time.Time) error
+	// Get セッションの問題を返す（存在しない場合は domain.ErrRoundNotFound）
+	Get(ctx context.Context, sessionID string) (*domain.Round, error)
+	// RecordAnswer 問題の回答者数を1増やし、増やした後の数を返す（出題中の問題でない場合は domain.ErrRoundNotFound）
+	RecordAnswer(ctx context.Context, sessionID, questionID string) (int, error)
+	// Process 結果処理のために問題を締め切る
+	// 出題中の問題でない場合は domain.ErrRoundNotFound、既に結果処理を始めていた場合は domain.ErrRoundAlreadyProcessed
+	Process(ctx context.Context, sessionID, questionID string, at, retainUntil time.Time) error
+	// Cancel 結果処理を行わずにセッションの問題を締め切る（存在しない・締め切り済みの場合は何もしない）
+	Cancel(ctx context.Context, sessionID string, at, retainUntil time.Time) error
+}
+
+// Repositories ストレージ実装ごとのリポジトリ一式
+type Repositories struct {
+	SessionRepo      SessionRepository
+	UserRepo         UserRepository
+	ParticipantRepo  ParticipantRepository
+	QuestionRepo     QuestionRepository
+	AnswerRepo       AnswerRepository
+	QuestionBankRepo QuestionBankRepository
+	TemplateRepo     PromptTemplateRepository
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"quiz-app/internal/domain"
+)
+
+func copyPromptTemplate(t *domain.PromptTemplate) *domain.PromptTemplate {
+	c := *t
+	return &c
+}
+
+// MemoryPromptTemplateRepository PromptTemplateRepositoryのインメモリ実装
+type MemoryPromptTemplateRepository struct {
+	store *MemoryStore
+}
+
+func (r *MemoryPromptTemplateRepository) Create(ctx context.Context, template *domain.PromptTemplate) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if template.ID == "" {
+		template.ID = newMemoryID()
+	}
+	r.store.templates[template.ID] = copyPromptTemplate(template)
+	return nil
+}
+
+func (r *MemoryPromptTemplateRepository) GetByID(ctx context.Context, id string) (*domain.PromptTemplate, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	template, ok := r.store.templates[id]
+	if !ok {
+		return nil, domain.ErrPromptTemplateNotFound
+	}
+	return copyPromptTemplate(template), nil
+}
+
+// List 作成日時の降順でテンプレートを取得
+func (r *MemoryPromptTemplateRepository) List(ctx context.Context) ([]*domain.PromptTemplate, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	templates := make([]*domain.PromptTemplate, 0, len(r.store.templates))
+	for _, template := range r.store.templates {
+		templates = append(templates, copyPromptTemplate(template))
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].CreatedAt.After(templates[j].CreatedAt)
+	})
+	return templates, nil
+}
+
+func (r *MemoryPromptTemplateRepository) Update(ctx context.Context, template *domain.PromptTemplate) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.templates[template.ID]; !ok {
+		return domain.ErrPromptTemplateNotFound
+	}
+	r.store.templates[template.ID] = copyPromptTemplate(template)
+	return nil
+}
+
+func (r *MemoryPromptTemplateRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.templates, id)
+	return nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"quiz-app/internal/domain"
+)
+
+func copyQuestionBank(b *domain.QuestionBank) *domain.QuestionBank {
+	c := *b
+	c.Questions = make([]domain.BankQuestion, len(b.Questions))
+	for i, q := range b.Questions {
+		q.Options = append([]string(nil), q.Options...)
+		q.Tags = append([]string(nil), q.Tags...)
+		q.Media, q.OptionMedia = copyMedia(q.Media, q.OptionMedia)
+		c.Questions[i] = q
+	}
+	return &c
+}
+
+// MemoryQuestionBankRepository QuestionBankRepositoryのインメモリ実装
+type MemoryQuestionBankRepository struct {
+	store *MemoryStore
+}
+
+func (r *MemoryQuestionBankRepository) Create(ctx context.Context, bank *domain.QuestionBank) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if bank.ID == "" {
+		bank.ID = newMemoryID()
+	}
+	r.store.banks[bank.ID] = copyQuestionBank(bank)
+	return nil
+}
+
+func (r *MemoryQuestionBankRepository) GetByID(ctx context.Context, id string) (*domain.QuestionBank, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	bank, ok := r.store.banks[id]
+	if !ok {
+		return nil, domain.ErrQuestionBankNotFound
+	}
+	return copyQuestionBank(bank), nil
+}
+
+// List 作成日時の降順で問題バンクを取得
+func (r *MemoryQuestionBankRepository) List(ctx context.Context) ([]*domain.QuestionBank, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	banks := make([]*domain.QuestionBank, 0, len(r.store.banks))
+	for _, bank := range r.store.banks {
+		banks = append(banks, copyQuestionBank(bank))
+	}
+	sort.Slice(banks, func(i, j int) bool {
+		return banks[i].CreatedAt.After(banks[j].CreatedAt)
+	})
+	return banks, nil
+}
+
+func (r *MemoryQuestionBankRepository) Update(ctx context.Context, bank *domain.QuestionBank) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.banks[bank.ID]; !ok {
+		return domain.ErrQuestionBankNotFound
+	}
+	r.store.banks[bank.ID] = copyQuestionBank(bank)
+	return nil
+}
+
+func (r *MemoryQuestionBankRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.banks, id)
+	return nil
+}
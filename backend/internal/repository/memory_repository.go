@@ -0,0 +1,487 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"quiz-app/internal/domain"
+)
+
+// MemoryStore プロセス内で完結するインメモリストレージ（ローカル開発・デモ・結合テスト用）
+// 保存・取得時にはコピーを受け渡し、呼び出し側での変更がストアに漏れないようにする
+type MemoryStore struct {
+	mu           sync.RWMutex
+	sessions     map[string]*domain.Session
+	participants map[string]*domain.Participant
+	questions    map[string]*domain.Question
+	answers      map[string]*domain.Answer
+	users        map[string]*domain.User
+	passwords    map[string]string // userID -> パスワードハッシュ
+	banks        map[string]*domain.QuestionBank
+	templates    map[string]*domain.PromptTemplate
+}
+
+// NewMemoryStore 空のインメモリストレージを作成
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sessions:     make(map[string]*domain.Session),
+		participants: make(map[string]*domain.Participant),
+		questions:    make(map[string]*domain.Question),
+		answers:      make(map[string]*domain.Answer),
+		users:        make(map[string]*domain.User),
+		passwords:    make(map[string]string),
+		banks:        make(map[string]*domain.QuestionBank),
+		templates:    make(map[string]*domain.PromptTemplate),
+	}
+}
+
+// NewMemoryRepositories インメモリストレージを使用したリポジトリ一式を作成
+func NewMemoryRepositories() *Repositories {
+	store := NewMemoryStore()
+	return &Repositories{
+		SessionRepo:      &MemorySessionRepository{store},
+		UserRepo:         &MemoryUserRepository{store},
+		ParticipantRepo:  &MemoryParticipantRepository{store},
+		QuestionRepo:     &MemoryQuestionRepository{store},
+		AnswerRepo:       &MemoryAnswerRepository{store},
+		QuestionBankRepo: &MemoryQuestionBankRepository{store},
+		TemplateRepo:     &MemoryPromptTemplateRepository{store},
+	}
+}
+
+func newMemoryID() string {
+	return uuid.New().String()
+}
+
+func copySession(s *domain.Session) *domain.Session {
+	c := *s
+	c.Settings.Generation.Categories = append([]string(nil), s.Settings.Generation.Categories...)
+	c.Settings.Generation.Languages = append([]string(nil), s.Settings.Generation.Languages...)
+	c.Settings.Generation.QuestionTypes = append([]domain.QuestionType(nil), s.Settings.Generation.QuestionTypes...)
+	return &c
+}
+
+func copyParticipant(p *domain.Participant) *domain.Participant {
+	c := *p
+	if p.EliminatedAt != nil {
+		t := *p.EliminatedAt
+		c.EliminatedAt = &t
+	}
+	if p.RevivedAt != nil {
+		t := *p.RevivedAt
+		c.RevivedAt = &t
+	}
+	c.LifeLostAt = append([]time.Time(nil), p.LifeLostAt...)
+	c.LifeLostFor = append([]string(nil), p.LifeLostFor...)
+	return &c
+}
+
+func copyQuestion(q *domain.Question) *domain.Question {
+	c := *q
+	c.Options = append([]string(nil), q.Options...)
+	c.CorrectAnswers = append([]int(nil), q.CorrectAnswers...)
+	if q.Translations != nil {
+		c.Translations = make(map[string]domain.QuestionTranslation, len(q.Translations))
+		for language, translation := range q.Translations {
+			translation.Options = append([]string(nil), translation.Options...)
+			c.Translations[language] = translation
+		}
+	}
+	c.Media, c.OptionMedia = copyMedia(q.Media, q.OptionMedia)
+	if q.ProcessedAt != nil {
+		processedAt := *q.ProcessedAt
+		c.ProcessedAt = &processedAt
+	}
+	return &c
+}
+
+// copyMedia 問題文と選択肢のメディアを複製する
+func copyMedia(media *domain.Media, optionMedia []*domain.Media) (*domain.Media, []*domain.Media) {
+	clone := func(m *domain.Media) *domain.Media {
+		if m == nil {
+			return nil
+		}
+		c := *m
+		return &c
+	}
+
+	var options []*domain.Media
+	if optionMedia != nil {
+		options = make([]*domain.Media, len(optionMedia))
+		for i, m := range optionMedia {
+			options[i] = clone(m)
+		}
+	}
+	return clone(media), options
+}
+
+func copyAnswer(a *domain.Answer) *domain.Answer {
+	c := *a
+	c.SelectedOptions = append([]int(nil), a.SelectedOptions...)
+	if a.NumericValue != nil {
+		value := *a.NumericValue
+		c.NumericValue = &value
+	}
+	return &c
+}
+
+func copyUser(u *domain.User) *domain.User {
+	c := *u
+	return &c
+}
+
+// MemorySessionRepository SessionRepositoryのインメモリ実装
+type MemorySessionRepository struct {
+	store *MemoryStore
+}
+
+func (r *MemorySessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if session.ID == "" {
+		session.ID = newMemoryID()
+	}
+	r.store.sessions[session.ID] = copySession(session)
+	return nil
+}
+
+func (r *MemorySessionRepository) GetByID(ctx context.Context, id string) (*domain.Session, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	session, ok := r.store.sessions[id]
+	if !ok {
+		return nil, domain.ErrSessionNotFound
+	}
+	return copySession(session), nil
+}
+
+func (r *MemorySessionRepository) Update(ctx context.Context, session *domain.Session) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.sessions[session.ID]; !ok {
+		return domain.ErrSessionNotFound
+	}
+	r.store.sessions[session.ID] = copySession(session)
+	return nil
+}
+
+func (r *MemorySessionRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.sessions, id)
+	return nil
+}
+
+// List 作成日時の降順でセッションを取得（Firestore実装と同じ並び順）
+func (r *MemorySessionRepository) List(ctx context.Context, limit int, offset int) ([]*domain.Session, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	sessions := make([]*domain.Session, 0, len(r.store.sessions))
+	for _, session := range r.store.sessions {
+		sessions = append(sessions, copySession(session))
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+
+	if offset >= len(sessions) {
+		return []*domain.Session{}, nil
+	}
+	sessions = sessions[offset:]
+	if limit > 0 && limit < len(sessions) {
+		sessions = sessions[:limit]
+	}
+	return sessions, nil
+}
+
+// MemoryParticipantRepository ParticipantRepositoryのインメモリ実装
+type MemoryParticipantRepository struct {
+	store *MemoryStore
+}
+
+func (r *MemoryParticipantRepository) Create(ctx context.Context, participant *domain.Participant) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if participant.ID == "" {
+		participant.ID = newMemoryID()
+	}
+	r.store.participants[participant.ID] = copyParticipant(participant)
+	return nil
+}
+
+func (r *MemoryParticipantRepository) GetByID(ctx context.Context, id string) (*domain.Participant, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	participant, ok := r.store.participants[id]
+	if !ok {
+		return nil, domain.ErrParticipantNotFound
+	}
+	return copyParticipant(participant), nil
+}
+
+func (r *MemoryParticipantRepository) GetByUserAndSession(ctx context.Context, userID, sessionID string) (*domain.Participant, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, participant := range r.store.participants {
+		if participant.UserID == userID && participant.SessionID == sessionID {
+			return copyParticipant(participant), nil
+		}
+	}
+	return nil, domain.ErrParticipantNotFound
+}
+
+func (r *MemoryParticipantRepository) GetBySession(ctx context.Context, sessionID string) ([]*domain.Participant, error) {
+	return r.filter(sessionID, func(p *domain.Participant) bool { return true }), nil
+}
+
+// GetActiveBySession アクティブ・復活済みの参加者を取得
+func (r *MemoryParticipantRepository) GetActiveBySession(ctx context.Context, sessionID string) ([]*domain.Participant, error) {
+	return r.filter(sessionID, func(p *domain.Participant) bool {
+		return p.Status == domain.ParticipantStatusActive || p.Status == domain.ParticipantStatusRevived
+	}), nil
+}
+
+func (r *MemoryParticipantRepository) GetEliminatedBySession(ctx context.Context, sessionID string) ([]*domain.Participant, error) {
+	return r.filter(sessionID, func(p *domain.Participant) bool {
+		return p.Status == domain.ParticipantStatusEliminated
+	}), nil
+}
+
+// filter セッション内の参加者を条件で絞り込み、参加順に並べて返す
+func (r *MemoryParticipantRepository) filter(sessionID string, match func(p *domain.Participant) bool) []*domain.Participant {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	participants := []*domain.Participant{}
+	for _, participant := range r.store.participants {
+		if participant.SessionID == sessionID && match(participant) {
+			participants = append(participants, copyParticipant(participant))
+		}
+	}
+	sort.Slice(participants, func(i, j int) bool {
+		return participants[i].JoinedAt.Before(participants[j].JoinedAt)
+	})
+	return participants
+}
+
+func (r *MemoryParticipantRepository) Update(ctx context.Context, participant *domain.Participant) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.participants[participant.ID]; !ok {
+		return domain.ErrParticipantNotFound
+	}
+	r.store.participants[participant.ID] = copyParticipant(participant)
+	return nil
+}
+
+func (r *MemoryParticipantRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.participants, id)
+	return nil
+}
+
+func (r *MemoryParticipantRepository) CountBySession(ctx context.Context, sessionID string) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for _, participant := range r.store.participants {
+		if participant.SessionID == sessionID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// MemoryQuestionRepository QuestionRepositoryのインメモリ実装
+type MemoryQuestionRepository struct {
+	store *MemoryStore
+}
+
+func (r *MemoryQuestionRepository) Create(ctx context.Context, question *domain.Question) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if question.ID == "" {
+		question.ID = newMemoryID()
+	}
+	r.store.questions[question.ID] = copyQuestion(question)
+	return nil
+}
+
+func (r *MemoryQuestionRepository) GetByID(ctx context.Context, id string) (*domain.Question, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	question, ok := r.store.questions[id]
+	if !ok {
+		return nil, domain.ErrQuestionNotFound
+	}
+	return copyQuestion(question), nil
+}
+
+// GetBySessionAndRound 指定ラウンドの問題を取得（複数ある場合は最初に作成されたもの）
+func (r *MemoryQuestionRepository) GetBySessionAndRound(ctx context.Context, sessionID string, round int) (*domain.Question, error) {
+	questions, _ := r.GetBySession(ctx, sessionID)
+	for _, question := range questions {
+		if question.Round == round {
+			return question, nil
+		}
+	}
+	return nil, domain.ErrQuestionNotFound
+}
+
+// GetBySession ラウンドの昇順で問題を取得
+func (r *MemoryQuestionRepository) GetBySession(ctx context.Context, sessionID string) ([]*domain.Question, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	questions := []*domain.Question{}
+	for _, question := range r.store.questions {
+		if question.SessionID == sessionID {
+			questions = append(questions, copyQuestion(question))
+		}
+	}
+	sort.Slice(questions, func(i, j int) bool {
+		if questions[i].Round != questions[j].Round {
+			return questions[i].Round < questions[j].Round
+		}
+		return questions[i].CreatedAt.Before(questions[j].CreatedAt)
+	})
+	return questions, nil
+}
+
+func (r *MemoryQuestionRepository) Update(ctx context.Context, question *domain.Question) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.questions[question.ID]; !ok {
+		return domain.ErrQuestionNotFound
+	}
+	r.store.questions[question.ID] = copyQuestion(question)
+	return nil
+}
+
+func (r *MemoryQuestionRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.questions, id)
+	return nil
+}
+
+// MemoryAnswerRepository AnswerRepositoryのインメモリ実装
+type MemoryAnswerRepository struct {
+	store *MemoryStore
+}
+
+func (r *MemoryAnswerRepository) Create(ctx context.Context, answer *domain.Answer) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if answer.ID == "" {
+		answer.ID = newMemoryID()
+	}
+	r.store.answers[answer.ID] = copyAnswer(answer)
+	return nil
+}
+
+func (r *MemoryAnswerRepository) GetByID(ctx context.Context, id string) (*domain.Answer, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	answer, ok := r.store.answers[id]
+	if !ok {
+		return nil, domain.ErrAnswerNotFound
+	}
+	return copyAnswer(answer), nil
+}
+
+func (r *MemoryAnswerRepository) GetByUserAndQuestion(ctx context.Context, userID, questionID string) (*domain.Answer, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, answer := range r.store.answers {
+		if answer.UserID == userID && answer.QuestionID == questionID {
+			return copyAnswer(answer), nil
+		}
+	}
+	return nil, domain.ErrAnswerNotFound
+}
+
+func (r *MemoryAnswerRepository) GetByQuestion(ctx context.Context, questionID string) ([]*domain.Answer, error) {
+	return r.filter(func(a *domain.Answer) bool { return a.QuestionID == questionID }), nil
+}
+
+func (r *MemoryAnswerRepository) GetBySession(ctx context.Context, sessionID string) ([]*domain.Answer, error) {
+	return r.filter(func(a *domain.Answer) bool { return a.SessionID == sessionID }), nil
+}
+
+func (r *MemoryAnswerRepository) GetByUserAndSession(ctx context.Context, userID, sessionID string) ([]*domain.Answer, error) {
+	return r.filter(func(a *domain.Answer) bool {
+		return a.UserID == userID && a.SessionID == sessionID
+	}), nil
+}
+
+// filter 条件に一致する回答を回答日時の降順で返す（Firestore実装と同じ並び順）
+func (r *MemoryAnswerRepository) filter(match func(a *domain.Answer) bool) []*domain.Answer {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	answers := []*domain.Answer{}
+	for _, answer := range r.store.answers {
+		if match(answer) {
+			answers = append(answers, copyAnswer(answer))
+		}
+	}
+	sort.Slice(answers, func(i, j int) bool {
+		return answers[i].AnsweredAt.After(answers[j].AnsweredAt)
+	})
+	return answers
+}
+
+func (r *MemoryAnswerRepository) Update(ctx context.Context, answer *domain.Answer) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.answers[answer.ID]; !ok {
+		return domain.ErrAnswerNotFound
+	}
+	r.store.answers[answer.ID] = copyAnswer(answer)
+	return nil
+}
+
+func (r *MemoryAnswerRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.answers, id)
+	return nil
+}
+
+func (r *MemoryAnswerRepository) CountCorrectByUserAndSession(ctx context.Context, userID, sessionID string) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for _, answer := range r.store.answers {
+		if answer.UserID == userID && answer.SessionID == sessionID && answer.IsCorrect {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"quiz-app/internal/domain"
+)
+
+func TestMemorySessionRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("保存したセッションを取得できること", func(t *testing.T) {
+		repos := NewMemoryRepositories()
+		session := domain.NewSession("テストセッション", 100, domain.Settings{TimeLimit: 30})
+		require.NoError(t, repos.SessionRepo.Create(ctx, session))
+
+		got, err := repos.SessionRepo.GetByID(ctx, session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, session.Title, got.Title)
+	})
+
+	t.Run("存在しないセッションはErrSessionNotFoundになること", func(t *testing.T) {
+		repos := NewMemoryRepositories()
+		_, err := repos.SessionRepo.GetByID(ctx, "missing")
+		assert.ErrorIs(t, err, domain.ErrSessionNotFound)
+	})
+
+	t.Run("取得したセッションを変更しても保存内容に影響しないこと", func(t *testing.T) {
+		repos := NewMemoryRepositories()
+		session := domain.NewSession("元のタイトル", 100, domain.Settings{Generation: domain.GenerationSettings{
+			Categories:    []string{"歴史"},
+			Languages:     []string{"ja", "en"},
+			QuestionTypes: []domain.QuestionType{domain.QuestionTypeChoice},
+		}})
+		require.NoError(t, repos.SessionRepo.Create(ctx, session))
+
+		got, _ := repos.SessionRepo.GetByID(ctx, session.ID)
+		got.Title = "変更後"
+		got.Settings.Generation.Categories[0] = "科学"
+		got.Settings.Generation.Languages[1] = "zh"
+		got.Settings.Generation.QuestionTypes[0] = domain.QuestionTypeNumeric
+
+		again, _ := repos.SessionRepo.GetByID(ctx, session.ID)
+		assert.Equal(t, "元のタイトル", again.Title)
+		assert.Equal(t, []string{"歴史"}, again.Settings.Generation.Categories)
+		assert.Equal(t, []string{"ja", "en"}, again.Settings.Generation.Languages)
+		assert.Equal(t, []domain.QuestionType{domain.QuestionTypeChoice}, again.Settings.Generation.QuestionTypes)
+	})
+
+	t.Run("一覧が作成日時の降順でページングされること", func(t *testing.T) {
+		repos := NewMemoryRepositories()
+		base := time.Now()
+		for i := 0; i < 3; i++ {
+			session := domain.NewSession(fmt.Sprintf("session-%d", i), 100, domain.Settings{})
+			session.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+			require.NoError(t, repos.SessionRepo.Create(ctx, session))
+		}
+
+		sessions, err := repos.SessionRepo.List(ctx, 2, 0)
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		assert.Equal(t, "session-2", sessions[0].Title)
+		assert.Equal(t, "session-1", sessions[1].Title)
+
+		sessions, err = repos.SessionRepo.List(ctx, 2, 2)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, "session-0", sessions[0].Title)
+	})
+}
+
+func TestMemoryParticipantRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ステータスで参加者を絞り込めること", func(t *testing.T) {
+		repos := NewMemoryRepositories()
+		active := domain.NewParticipant("user1", "s1", "アクティブ")
+		eliminated := domain.NewParticipant("user2", "s1", "脱落")
+		eliminated.Eliminate()
+		revived := domain.NewParticipant("user3", "s1", "復活")
+		revived.Eliminate()
+		revived.Revive()
+		other := domain.NewParticipant("user4", "s2", "別セッション")
+
+		for _, p := range []*domain.Participant{active, eliminated, revived, other} {
+			require.NoError(t, repos.ParticipantRepo.Create(ctx, p))
+		}
+
+		all, err := repos.ParticipantRepo.GetBySession(ctx, "s1")
+		require.NoError(t, err)
+		assert.Len(t, all, 3)
+
+		actives, err := repos.ParticipantRepo.GetActiveBySession(ctx, "s1")
+		require.NoError(t, err)
+		assert.Len(t, actives, 2)
+
+		eliminateds, err := repos.ParticipantRepo.GetEliminatedBySession(ctx, "s1")
+		require.NoError(t, err)
+		require.Len(t, eliminateds, 1)
+		assert.Equal(t, "user2", eliminateds[0].UserID)
+
+		count, err := repos.ParticipantRepo.CountBySession(ctx, "s1")
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("ユーザーとセッションで参加者を取得できること", func(t *testing.T) {
+		repos := NewMemoryRepositories()
+		participant := domain.NewParticipant("user1", "s1", "参加者")
+		require.NoError(t, repos.ParticipantRepo.Create(ctx, participant))
+
+		got, err := repos.ParticipantRepo.GetByUserAndSession(ctx, "user1", "s1")
+		require.NoError(t, err)
+		assert.Equal(t, participant.ID, got.ID)
+
+		_, err = repos.ParticipantRepo.GetByUserAndSession(ctx, "user1", "s2")
+		assert.ErrorIs(t, err, domain.ErrParticipantNotFound)
+	})
+}
+
+func TestMemoryQuestionRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ラウンドで問題を取得できること", func(t *testing.T) {
+		repos := NewMemoryRepositories()
+		for round := 3; round >= 1; round-- {
+			question := domain.NewQuestion("s1", round, fmt.Sprintf("問題%d", round), []string{"A", "B"}, 0, domain.DifficultyEasy, "general", domain.AIProviderGemini)
+			require.NoError(t, repos.QuestionRepo.Create(ctx, question))
+		}
+
+		question, err := repos.QuestionRepo.GetBySessionAndRound(ctx, "s1", 2)
+		require.NoError(t, err)
+		assert.Equal(t, "問題2", question.Text)
+
+		_, err = repos.QuestionRepo.GetBySessionAndRound(ctx, "s1", 5)
+		assert.ErrorIs(t, err, domain.ErrQuestionNotFound)
+
+		questions, err := repos.QuestionRepo.GetBySession(ctx, "s1")
+		require.NoError(t, err)
+		require.Len(t, questions, 3)
+		assert.Equal(t, 1, questions[0].Round)
+		assert.Equal(t, 3, questions[2].Round)
+	})
+}
+
+func TestMemoryAnswerRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ユーザーごとの正解数を数えられること", func(t *testing.T) {
+		repos := NewMemoryRepositories()
+		for i := 0; i < 3; i++ {
+			answer := domain.NewAnswer("user1", "s1", fmt.Sprintf("q%d", i), 0, 1000)
+			answer.IsCorrect = i != 1
+			require.NoError(t, repos.AnswerRepo.Create(ctx, answer))
+		}
+
+		count, err := repos.AnswerRepo.CountCorrectByUserAndSession(ctx, "user1", "s1")
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		_, err = repos.AnswerRepo.GetByUserAndQuestion(ctx, "user1", "q9")
+		assert.ErrorIs(t, err, domain.ErrAnswerNotFound)
+	})
+
+	t.Run("同時に書き込んでも全件保存されること", func(t *testing.T) {
+		repos := NewMemoryRepositories()
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				answer := domain.NewAnswer(fmt.Sprintf("user%d", i), "s1", "q1", 0, 1000)
+				assert.NoError(t, repos.AnswerRepo.Create(ctx, answer))
+			}(i)
+		}
+		wg.Wait()
+
+		answers, err := repos.AnswerRepo.GetByQuestion(ctx, "q1")
+		require.NoError(t, err)
+		assert.Len(t, answers, 50)
+	})
+}
+
+func TestMemoryUserRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("パスワードでユーザー認証できること", func(t *testing.T) {
+		repos := NewMemoryRepositories()
+		user := &domain.User{Username: "admin", DisplayName: "管理者"}
+		require.NoError(t, repos.UserRepo.CreateWithPassword(ctx, user, "secret"))
+
+		got, err := repos.UserRepo.ValidateUserCredentials(ctx, "admin", "secret")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, got.ID)
+
+		_, err = repos.UserRepo.ValidateUserCredentials(ctx, "admin", "wrong")
+		assert.EqualError(t, err, "invalid credentials")
+
+		_, err = repos.UserRepo.ValidateUserCredentials(ctx, "nobody", "secret")
+		assert.EqualError(t, err, "user not found")
+	})
+}
+
+func TestMemoryQuestionBankRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("問題バンクを保存・更新・削除できること", func(t *testing.T) {
+		repos := NewMemoryRepositories()
+		bank := domain.NewQuestionBank("社内クイズ", "", []domain.BankQuestion{
+			{Text: "問題", Options: []string{"A", "B"}, CorrectAnswer: 1, Tags: []string{"tag"}},
+		})
+		require.NoError(t, repos.QuestionBankRepo.Create(ctx, bank))
+
+		got, err := repos.QuestionBankRepo.GetByID(ctx, bank.ID)
+		require.NoError(t, err)
+		got.Questions[0].Tags[0] = "changed"
+
+		again, _ := repos.QuestionBankRepo.GetByID(ctx, bank.ID)
+		assert.Equal(t, []string{"tag"}, again.Questions[0].Tags)
+
+		again.Name = "更新後"
+		require.NoError(t, repos.QuestionBankRepo.Update(ctx, again))
+		banks, err := repos.QuestionBankRepo.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, banks, 1)
+		assert.Equal(t, "更新後", banks[0].Name)
+
+		require.NoError(t, repos.QuestionBankRepo.Delete(ctx, bank.ID))
+		_, err = repos.QuestionBankRepo.GetByID(ctx, bank.ID)
+		assert.ErrorIs(t, err, domain.ErrQuestionBankNotFound)
+		assert.ErrorIs(t, repos.QuestionBankRepo.Update(ctx, bank), domain.ErrQuestionBankNotFound)
+	})
+}
+
+func TestMemoryPromptTemplateRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("テンプレートを保存・更新・削除できること", func(t *testing.T) {
+		repos := NewMemoryRepositories()
+		template := domain.NewPromptTemplate("社内向け", "", "{{.Theme}}の問題")
+		require.NoError(t, repos.TemplateRepo.Create(ctx, template))
+
+		got, err := repos.TemplateRepo.GetByID(ctx, template.ID)
+		require.NoError(t, err)
+		got.Body = "changed"
+
+		again, _ := repos.TemplateRepo.GetByID(ctx, template.ID)
+		assert.Equal(t, "{{.Theme}}の問題", again.Body)
+
+		again.Name = "更新後"
+		require.NoError(t, repos.TemplateRepo.Update(ctx, again))
+		templates, err := repos.TemplateRepo.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, templates, 1)
+		assert.Equal(t, "更新後", templates[0].Name)
+
+		require.NoError(t, repos.TemplateRepo.Delete(ctx, template.ID))
+		_, err = repos.TemplateRepo.GetByID(ctx, template.ID)
+		assert.ErrorIs(t, err, domain.ErrPromptTemplateNotFound)
+		assert.ErrorIs(t, repos.TemplateRepo.Update(ctx, template), domain.ErrPromptTemplateNotFound)
+	})
+}
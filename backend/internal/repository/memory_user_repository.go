@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"golang.org/x/crypto/bcrypt"
+	"quiz-app/internal/domain"
+)
+
+// MemoryUserRepository UserRepositoryのインメモリ実装
+type MemoryUserRepository struct {
+	store *MemoryStore
+}
+
+// Create ユーザーを作成
+func (r *MemoryUserRepository) Create(ctx context.Context, user *domain.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if user.ID == "" {
+		user.ID = generateUserID()
+	}
+	r.store.users[user.ID] = copyUser(user)
+	return nil
+}
+
+// CreateWithPassword パスワード付きでユーザーを作成
+func (r *MemoryUserRepository) CreateWithPassword(ctx context.Context, user *domain.User, password string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if user.ID == "" {
+		user.ID = generateUserID()
+	}
+	r.store.users[user.ID] = copyUser(user)
+	r.store.passwords[user.ID] = string(hashedPassword)
+	return nil
+}
+
+// GetByID ユーザーを取得
+func (r *MemoryUserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	user, ok := r.store.users[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	return copyUser(user), nil
+}
+
+// GetByEmail メールアドレスでユーザーを取得
+func (r *MemoryUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.findOne(func(u *domain.User) bool { return u.Email == email })
+}
+
+// GetByUsername ユーザー名でユーザーを取得
+func (r *MemoryUserRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return r.findOne(func(u *domain.User) bool { return u.Username == username })
+}
+
+func (r *MemoryUserRepository) findOne(match func(u *domain.User) bool) (*domain.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, user := range r.store.users {
+		if match(user) {
+			return copyUser(user), nil
+		}
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+// ValidateUserCredentials ユーザー認証情報を検証
+func (r *MemoryUserRepository) ValidateUserCredentials(ctx context.Context, username, password string) (*domain.User, error) {
+	user, err := r.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	r.store.mu.RLock()
+	passwordHash, ok := r.store.passwords[user.ID]
+	r.store.mu.RUnlock()
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(password)); err != nil {
+		return nil, errors.New("invalid credentials")
+	}
+
+	return user, nil
+}
+
+// Update ユーザーを更新
+func (r *MemoryUserRepository) Update(ctx context.Context, user *domain.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.users[user.ID] = copyUser(user)
+	return nil
+}
+
+// Delete ユーザーとパスワード情報を削除
+func (r *MemoryUserRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.users, id)
+	delete(r.store.passwords, id)
+	return nil
+}
+
+// BulkCreateUsers 一括ユーザー作成
+func (r *MemoryUserRepository) BulkCreateUsers(ctx context.Context, users []UserCredentials) error {
+	for _, userCred := range users {
+		user := &domain.User{
+			ID:          generateUserID(),
+			Username:    userCred.Username,
+			DisplayName: userCred.DisplayName,
+		}
+		if err := r.CreateWithPassword(ctx, user, userCred.Password); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAll すべてのユーザーを作成日時順に取得
+func (r *MemoryUserRepository) GetAll(ctx context.Context) ([]*domain.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	users := make([]*domain.User, 0, len(r.store.users))
+	for _, user := range r.store.users {
+		users = append(users, copyUser(user))
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].CreatedAt.Before(users[j].CreatedAt)
+	})
+	return users, nil
+}
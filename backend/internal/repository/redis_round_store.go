@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"quiz-app/internal/domain"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisRoundMaxTTL 締め切りのない問題を締め切らずに放置した場合に破棄するまでの期間
+const redisRoundMaxTTL = 24 * time.Hour
+
+// 各スクリプトは KEYS[1] の問題の hash だけを操作する（クラスタでも1つのスロットで完結する）
+
+// redisRecordAnswerScript 出題中の問題であれば回答者数を増やす（出題中の問題でなければ -1）
+// ARGV[1]: 問題ID
+var redisRecordAnswerScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'questionId') ~= ARGV[1] then
+	return -1
+end
+return redis.call('HINCRBY', KEYS[1], 'answered', 1)
+`)
+
+// redisProcessRoundScript 結果処理のために締め切る（出題中の問題でなければ 0、結果処理済みなら -1）
+// ARGV[1]: 問題ID、ARGV[2]: 締め切った時刻（Unix ナノ秒）、ARGV[3]: 保持期限（Unix ミリ秒）
+var redisProcessRoundScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'questionId') ~= ARGV[1] then
+	return 0
+end
+if redis.call('HGET', KEYS[1], 'processed') == '1' then
+	return -1
+end
+redis.call('HSET', KEYS[1], 'closed', '1', 'closedAt', ARGV[2], 'processed', '1')
+redis.call('PEXPIREAT', KEYS[1], ARGV[3])
+return 1
+`)
+
+// redisCancelRoundScript 結果処理を行わずに締め切る（存在しない・締め切り済みなら何もしない）
+// ARGV[1]: 締め切った時刻（Unix ナノ秒）、ARGV[2]: 保持期限（Unix ミリ秒）
+var redisCancelRoundScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 or redis.call('HGET', KEYS[1], 'closed') == '1' then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'closed', '1', 'closedAt', ARGV[1])
+redis.call('PEXPIREAT', KEYS[1], ARGV[2])
+return 1
+`)
+
+// RedisRoundStore RoundStoreの Redis 実装（セッションごとの hash に保持し、複数のサーバーで共有する）
+type RedisRoundStore struct {
+	client *redis.Client
+}
+
+// NewRedisRoundStore redisURL（redis://[:password@]host:port/db）の Redis に接続する
+func NewRedisRoundStore(ctx context.Context, redisURL string) (*RedisRoundStore, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	return &RedisRoundStore{client: client}, nil
+}
+
+func redisRoundKey(sessionID string) string {
+	return "quiz:round:" + sessionID
+}
+
+// unixNano 時刻を hash に保存する形式にする（ゼロ値は0）
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func fromUnixNano(value string) (time.Time, error) {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n == 0 {
+		return time.Time{}, err
+	}
+	return time.Unix(0, n), nil
+}
+
+func (s *RedisRoundStore) Start(ctx context.Context, round *domain.Round, retainUntil time.Time) error {
+	key := redisRoundKey(round.SessionID)
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key,
+			"questionId", round.QuestionID,
+			"openedAt", unixNano(round.OpenedAt),
+			"deadline", unixNano(round.Deadline),
+			"closed", "0",
+			"closedAt", 0,
+			"processed", "0",
+			"answered", 0,
+		)
+		if retainUntil.IsZero() {
+			pipe.Expire(ctx, key, redisRoundMaxTTL)
+		} else {
+			pipe.PExpireAt(ctx, key, retainUntil)
+		}
+		return nil
+	})
+	return err
+}
+
+func (s *RedisRoundStore) Get(ctx context.Context, sessionID string) (*domain.Round, error) {
+	values, err := s.client.HGetAll(ctx, redisRoundKey(sessionID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, domain.ErrRoundNotFound
+	}
+
+	round := &domain.Round{
+		SessionID:  sessionID,
+		QuestionID: values["questionId"],
+		Closed:     values["closed"] == "1",
+		Processed:  values["processed"] == "1",
+	}
+	if round.OpenedAt, err = fromUnixNano(values["openedAt"]); err != nil {
+		return nil, fmt.Errorf("malformed round openedAt: %w", err)
+	}
+	if round.Deadline, err = fromUnixNano(values["deadline"]); err != nil {
+		return nil, fmt.Errorf("malformed round deadline: %w", err)
+	}
+	if round.ClosedAt, err = fromUnixNano(values["closedAt"]); err != nil {
+		return nil, fmt.Errorf("malformed round closedAt: %w", err)
+	}
+	if round.Answered, err = strconv.Atoi(values["answered"]); err != nil {
+		return nil, fmt.Errorf("malformed round answered: %w", err)
+	}
+	return round, nil
+}
+
+func (s *RedisRoundStore) RecordAnswer(ctx context.Context, sessionID, questionID string) (int, error) {
+	answered, err := redisRecordAnswerScript.Run(ctx, s.client, []string{redisRoundKey(sessionID)}, questionID).Int()
+	if err != nil {
+		return 0, err
+	}
+	if answered < 0 {
+		return 0, domain.ErrRoundNotFound
+	}
+	return answered, nil
+}
+
+func (s *RedisRoundStore) Process(ctx context.Context, sessionID, questionID string, at, retainUntil time.Time) error {
+	keys := []string{redisRoundKey(sessionID)}
+	result, err := redisProcessRoundScript.Run(ctx, s.client, keys, questionID, unixNano(at), retainUntil.UnixMilli()).Int()
+	if err != nil {
+		return err
+	}
+	switch result {
+	case 0:
+		return domain.ErrRoundNotFound
+	case -1:
+		return domain.ErrRoundAlreadyProcessed
+	}
+	return nil
+}
+
+func (s *RedisRoundStore) Cancel(ctx context.Context, sessionID string, at, retainUntil time.Time) error {
+	keys := []string{redisRoundKey(sessionID)}
+	return redisCancelRoundScript.Run(ctx, s.client, keys, unixNano(at), retainUntil.UnixMilli()).Err()
+}
+
+func (s *RedisRoundStore) Close() error {
+	return s.client.Close()
+}
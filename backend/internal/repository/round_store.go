@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"quiz-app/internal/domain"
+	"quiz-app/pkg/config"
+	"sync"
+	"time"
+)
+
+// NewRoundStore 設定に応じた出題状態の保存先を作成する
+// WebSocket の通知を Redis で共有する（複数のサーバーで動かす）場合は、出題状態も同じ Redis で共有する
+func NewRoundStore(ctx context.Context, cfg *config.Config) (RoundStore, error) {
+	if cfg.WebSocket.Backplane == "redis" {
+		return NewRedisRoundStore(ctx, cfg.WebSocket.RedisURL)
+	}
+	return NewMemoryRoundStore(), nil
+}
+
+// MemoryRoundStore RoundStoreのインメモリ実装（単一サーバー向け）
+type MemoryRoundStore struct {
+	mu     sync.Mutex
+	rounds map[string]*memoryRound // sessionID -> 出題中の問題
+}
+
+type memoryRound struct {
+	round       domain.Round
+	retainUntil time.Time // ゼロ値の場合は締め切るまで保持
+}
+
+func NewMemoryRoundStore() *MemoryRoundStore {
+	return &MemoryRoundStore{
+		rounds: make(map[string]*memoryRound),
+	}
+}
+
+func (s *MemoryRoundStore) Start(ctx context.Context, round *domain.Round, retainUntil time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for sessionID, r := range s.rounds {
+		if r.expired(now) {
+			delete(s.rounds, sessionID)
+		}
+	}
+
+	s.rounds[round.SessionID] = &memoryRound{round: *round, retainUntil: retainUntil}
+	return nil
+}
+
+func (s *MemoryRoundStore) Get(ctx context.Context, sessionID string) (*domain.Round, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.get(sessionID)
+	if !ok {
+		return nil, domain.ErrRoundNotFound
+	}
+	round := r.round
+	return &round, nil
+}
+
+func (s *MemoryRoundStore) RecordAnswer(ctx context.Context, sessionID, questionID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.get(sessionID)
+	if !ok || r.round.QuestionID != questionID {
+		return 0, domain.ErrRoundNotFound
+	}
+	r.round.Answered++
+	return r.round.Answered, nil
+}
+
+func (s *MemoryRoundStore) Process(ctx context.Context, sessionID, questionID string, at, retainUntil time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.get(sessionID)
+	if !ok || r.round.QuestionID != questionID {
+		return domain.ErrRoundNotFound
+	}
+	if r.round.Processed {
+		return domain.ErrRoundAlreadyProcessed
+	}
+
+	r.round.Closed = true
+	r.round.ClosedAt = at
+	r.round.Processed = true
+	r.retainUntil = retainUntil
+	return nil
+}
+
+func (s *MemoryRoundStore) Cancel(ctx context.Context, sessionID string, at, retainUntil time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r, ok := s.get(sessionID); ok && !r.round.Closed {
+		r.round.Closed = true
+		r.round.ClosedAt = at
+		r.retainUntil = retainUntil
+	}
+	return nil
+}
+
+// get 保持期間内の問題を返す（期限切れの問題は破棄する。s.mu を取得済みで呼ぶ）
+func (s *MemoryRoundStore) get(sessionID string) (*memoryRound, bool) {
+	r, ok := s.rounds[sessionID]
+	if !ok {
+		return nil, false
+	}
+	if r.expired(time.Now()) {
+		delete(s.rounds, sessionID)
+		return nil, false
+	}
+	return r, true
+}
+
+func (r *memoryRound) expired(at time.Time) bool {
+	return !r.retainUntil.IsZero() && at.After(r.retainUntil)
+}
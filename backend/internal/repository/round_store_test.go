@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"quiz-app/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundStore(t *testing.T) {
+	ctx := context.Background()
+
+	mr := miniredis.RunT(t)
+	redisStore, err := NewRedisRoundStore(ctx, "redis://"+mr.Addr()+"/0")
+	require.NoError(t, err)
+	t.Cleanup(func() { redisStore.Close() })
+
+	stores := map[string]RoundStore{
+		"memory": NewMemoryRoundStore(),
+		"redis":  redisStore,
+	}
+
+	for name, store := range stores {
+		t.Run(name+": 開始した問題を取得でき、次の問題で差し替わること", func(t *testing.T) {
+			openedAt := time.Now()
+			round := &domain.Round{SessionID: "start", QuestionID: "q1", OpenedAt: openedAt, Deadline: openedAt.Add(10 * time.Second)}
+			require.NoError(t, store.Start(ctx, round, openedAt.Add(time.Hour)))
+
+			got, err := store.Get(ctx, "start")
+			require.NoError(t, err)
+			assert.Equal(t, "q1", got.QuestionID)
+			assert.True(t, got.OpenedAt.Equal(openedAt))
+			assert.True(t, got.Deadline.Equal(openedAt.Add(10*time.Second)))
+			assert.False(t, got.Closed)
+			assert.False(t, got.Processed)
+
+			_, err = store.RecordAnswer(ctx, "start", "q1")
+			require.NoError(t, err)
+			require.NoError(t, store.Start(ctx, &domain.Round{SessionID: "start", QuestionID: "q2", OpenedAt: openedAt}, time.Time{}))
+
+			got, err = store.Get(ctx, "start")
+			require.NoError(t, err)
+			assert.Equal(t, "q2", got.QuestionID)
+			assert.True(t, got.Deadline.IsZero())
+			assert.Zero(t, got.Answered)
+
+			_, err = store.Get(ctx, "unknown")
+			assert.ErrorIs(t, err, domain.ErrRoundNotFound)
+		})
+
+		t.Run(name+": 回答者数は出題中の問題のみ数えること", func(t *testing.T) {
+			require.NoError(t, store.Start(ctx, &domain.Round{SessionID: "count", QuestionID: "q1", OpenedAt: time.Now()}, time.Time{}))
+
+			store.RecordAnswer(ctx, "count", "q1")
+			answered, err := store.RecordAnswer(ctx, "count", "q1")
+			require.NoError(t, err)
+			assert.Equal(t, 2, answered)
+
+			_, err = store.RecordAnswer(ctx, "count", "q0")
+			assert.ErrorIs(t, err, domain.ErrRoundNotFound)
+			_, err = store.RecordAnswer(ctx, "unknown", "q1")
+			assert.ErrorIs(t, err, domain.ErrRoundNotFound)
+		})
+
+		t.Run(name+": 結果処理は1回だけ行え、中止した問題も結果処理できること", func(t *testing.T) {
+			now := time.Now()
+			require.NoError(t, store.Start(ctx, &domain.Round{SessionID: "process", QuestionID: "q1", OpenedAt: now}, time.Time{}))
+
+			assert.ErrorIs(t, store.Process(ctx, "process", "q0", now, now.Add(time.Hour)), domain.ErrRoundNotFound)
+
+			require.NoError(t, store.Cancel(ctx, "process", now, now.Add(time.Hour)))
+			got, err := store.Get(ctx, "process")
+			require.NoError(t, err)
+			assert.True(t, got.Closed)
+			assert.False(t, got.Processed)
+
+			require.NoError(t, store.Process(ctx, "process", "q1", now, now.Add(time.Hour)))
+			assert.ErrorIs(t, store.Process(ctx, "process", "q1", now, now.Add(time.Hour)), domain.ErrRoundAlreadyProcessed)
+
+			got, err = store.Get(ctx, "process")
+			require.NoError(t, err)
+			assert.True(t, got.Processed)
+			assert.True(t, got.ClosedAt.Equal(now))
+
+			// 存在しない問題の中止は何もしない
+			require.NoError(t, store.Cancel(ctx, "unknown", now, now.Add(time.Hour)))
+			_, err = store.Get(ctx, "unknown")
+			assert.ErrorIs(t, err, domain.ErrRoundNotFound)
+		})
+
+		t.Run(name+": 保持期限を過ぎた問題は破棄されること", func(t *testing.T) {
+			past := time.Now().Add(-time.Hour)
+			require.NoError(t, store.Start(ctx, &domain.Round{SessionID: "expired", QuestionID: "q1", OpenedAt: past}, past.Add(time.Minute)))
+
+			_, err := store.Get(ctx, "expired")
+			assert.ErrorIs(t, err, domain.ErrRoundNotFound)
+
+			// 締め切った問題は締め切り時に指定した保持期限で破棄される
+			require.NoError(t, store.Start(ctx, &domain.Round{SessionID: "closed", QuestionID: "q1", OpenedAt: past}, time.Time{}))
+			require.NoError(t, store.Process(ctx, "closed", "q1", past, past.Add(time.Minute)))
+			_, err = store.Get(ctx, "closed")
+			assert.ErrorIs(t, err, domain.ErrRoundNotFound)
+		})
+	}
+
+	t.Run("redis: 締め切りのない問題も上限の期間で破棄されること", func(t *testing.T) {
+		require.NoError(t, redisStore.Start(ctx, &domain.Round{SessionID: "open", QuestionID: "q1", OpenedAt: time.Now()}, time.Time{}))
+
+		assert.Equal(t, redisRoundMaxTTL, mr.TTL(redisRoundKey("open")))
+	})
+}
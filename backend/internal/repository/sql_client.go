@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+	"quiz-app/pkg/config"
+)
+
+// SQLClient database/sql を使用したリポジトリ一式（SQLite / PostgreSQL）
+type SQLClient struct {
+	Repositories
+	DB *sql.DB
+}
+
+// NewSQLClient 設定に従ってデータベースへ接続し、マイグレーションを適用する
+func NewSQLClient(ctx context.Context, cfg *config.Config) (*SQLClient, error) {
+	driver, err := sqlDriverName(cfg.Storage.Backend)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open(driver, cfg.Storage.DatabaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if driver == "sqlite3" {
+		// SQLite は同時書き込みでロック競合するため接続を1本に制限する
+		db.SetMaxOpenConns(1)
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect database: %w", err)
+	}
+
+	repos, err := NewSQLRepositories(ctx, db, driver)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SQLClient{
+		Repositories: *repos,
+		DB:           db,
+	}, nil
+}
+
+func (c *SQLClient) Close() error {
+	return c.DB.Close()
+}
+
+// NewSQLRepositories 接続済みのデータベースにマイグレーションを適用し、リポジトリ一式を作成
+// driver は database/sql のドライバー名（"sqlite3" または "postgres"）
+func NewSQLRepositories(ctx context.Context, db *sql.DB, driver string) (*Repositories, error) {
+	store := &sqlStore{db: db, postgres: driver == "postgres"}
+	if err := store.migrate(ctx); err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return &Repositories{
+		SessionRepo:      &SQLSessionRepository{store},
+		UserRepo:         &SQLUserRepository{store},
+		ParticipantRepo:  &SQLParticipantRepository{store},
+		QuestionRepo:     &SQLQuestionRepository{store},
+		AnswerRepo:       &SQLAnswerRepository{store},
+		QuestionBankRepo: &SQLQuestionBankRepository{store},
+		TemplateRepo:     &SQLPromptTemplateRepository{store},
+	}, nil
+}
+
+func sqlDriverName(backend string) (string, error) {
+	switch backend {
+	case "sqlite":
+		return "sqlite3", nil
+	case "postgres":
+		return "postgres", nil
+	default:
+		return "", fmt.Errorf("unsupported sql backend: %s", backend)
+	}
+}
+
+type sqlStore struct {
+	db       *sql.DB
+	postgres bool
+}
+
+// rebind プレースホルダー "?" をドライバーの形式に変換する（PostgreSQL は $1, $2, ...）
+func (s *sqlStore) rebind(query string) string {
+	if !s.postgres {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func (s *sqlStore) exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return s.db.ExecContext(ctx, s.rebind(query), args...)
+}
+
+func (s *sqlStore) query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return s.db.QueryContext(ctx, s.rebind(query), args...)
+}
+
+func (s *sqlStore) queryRow(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return s.db.QueryRowContext(ctx, s.rebind(query), args...)
+}
+
+// execAffecting 更新・削除を実行し、対象行が無ければ notFound を返す
+func (s *sqlStore) execAffecting(ctx context.Context, notFound error, query string, args ...interface{}) error {
+	result, err := s.exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// isUniqueViolation 一意制約の違反によるエラーか判定する
+func isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23505"
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// sqlMigrations スキーマ変更の履歴（適用済みのものは schema_migrations に記録される）
+// 既存の要素は変更せず、末尾に追加していくこと
+var sqlMigrations = []string{
+	// 1: 初期スキーマ
+	`CREATE TABLE IF NOT EXISTS sessions (
+		id               VARCHAR(64) PRIMARY KEY,
+		title            TEXT NOT NULL,
+		status           VARCHAR(32) NOT NULL,
+		current_round    INTEGER NOT NULL DEFAULT 0,
+		max_participants INTEGER NOT NULL DEFAULT 0,
+		settings         TEXT NOT NULL,
+		created_at       TIMESTAMP NOT NULL,
+		updated_at       TIMESTAMP NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_sessions_created_at ON sessions (created_at);
+
+	CREATE TABLE IF NOT EXISTS users (
+		id            VARCHAR(64) PRIMARY KEY,
+		username      VARCHAR(255) NOT NULL DEFAULT '',
+		display_name  TEXT NOT NULL DEFAULT '',
+		email         VARCHAR(255) NOT NULL DEFAULT '',
+		is_anonymous  BOOLEAN NOT NULL DEFAULT FALSE,
+		access_code   VARCHAR(255) NOT NULL DEFAULT '',
+		created_at    TIMESTAMP NOT NULL,
+		last_login_at TIMESTAMP NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_users_username ON users (username);
+	CREATE INDEX IF NOT EXISTS idx_users_email ON users (email);
+
+	CREATE TABLE IF NOT EXISTS user_passwords (
+		user_id       VARCHAR(64) PRIMARY KEY,
+		username      VARCHAR(255) NOT NULL,
+		password_hash TEXT NOT NULL,
+		created_at    TIMESTAMP NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS participants (
+		id              VARCHAR(64) PRIMARY KEY,
+		user_id         VARCHAR(64) NOT NULL,
+		session_id      VARCHAR(64) NOT NULL,
+		display_name    TEXT NOT NULL,
+		status          VARCHAR(32) NOT NULL,
+		joined_at       TIMESTAMP NOT NULL,
+		eliminated_at   TIMESTAMP NULL,
+		revived_at      TIMESTAMP NULL,
+		score           INTEGER NOT NULL DEFAULT 0,
+		correct_answers INTEGER NOT NULL DEFAULT 0
+	);
+	CREATE INDEX IF NOT EXISTS idx_participants_session_status ON participants (session_id, status);
+	CREATE INDEX IF NOT EXISTS idx_participants_user_session ON participants (user_id, session_id);
+
+	CREATE TABLE IF NOT EXISTS questions (
+		id             VARCHAR(64) PRIMARY KEY,
+		session_id     VARCHAR(64) NOT NULL,
+		round          INTEGER NOT NULL,
+		text           TEXT NOT NULL,
+		options        TEXT NOT NULL,
+		correct_answer INTEGER NOT NULL,
+		difficulty     VARCHAR(32) NOT NULL,
+		category       VARCHAR(255) NOT NULL DEFAULT '',
+		ai_provider    VARCHAR(32) NOT NULL DEFAULT '',
+		created_at     TIMESTAMP NOT NULL,
+		opened_at      TIMESTAMP NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_questions_session_round ON questions (session_id, round);
+
+	CREATE TABLE IF NOT EXISTS answers (
+		id                   VARCHAR(64) PRIMARY KEY,
+		user_id              VARCHAR(64) NOT NULL,
+		session_id           VARCHAR(64) NOT NULL,
+		question_id          VARCHAR(64) NOT NULL,
+		selected_option      INTEGER NOT NULL,
+		is_correct           BOOLEAN NOT NULL DEFAULT FALSE,
+		answered_at          TIMESTAMP NOT NULL,
+		response_time        INTEGER NOT NULL DEFAULT 0,
+		client_response_time INTEGER NOT NULL DEFAULT 0
+	);
+	CREATE INDEX IF NOT EXISTS idx_answers_user_question ON answers (user_id, question_id);
+	CREATE INDEX IF NOT EXISTS idx_answers_question ON answers (question_id);
+	CREATE INDEX IF NOT EXISTS idx_answers_session ON answers (session_id, answered_at);`,
+
+	// 2: 回答ごとの獲得点数
+	`ALTER TABLE answers ADD COLUMN points INTEGER NOT NULL DEFAULT 0;`,
+
+	// 3: 参加者の残りライフ
+	`ALTER TABLE participants ADD COLUMN lives INTEGER NOT NULL DEFAULT 0;`,
+
+	// 4: ライフを失った時刻の履歴（JSON配列）
+	`ALTER TABLE participants ADD COLUMN life_lost_at TEXT NOT NULL DEFAULT '[]';`,
+
+	// 5: 問題バンク（問題一覧はJSON配列）と出題元のバンク問題ID
+	`CREATE TABLE IF NOT EXISTS question_banks (
+		id          VARCHAR(64) PRIMARY KEY,
+		name        TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		questions   TEXT NOT NULL DEFAULT '[]',
+		created_at  TIMESTAMP NOT NULL,
+		updated_at  TIMESTAMP NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_question_banks_created_at ON question_banks (created_at);
+	ALTER TABLE questions ADD COLUMN bank_question_id VARCHAR(64) NOT NULL DEFAULT '';`,
+
+	// 6: 問題の下書きレビュー状態と出題順
+	`ALTER TABLE questions ADD COLUMN status VARCHAR(32) NOT NULL DEFAULT '';
+	ALTER TABLE questions ADD COLUMN position INTEGER NOT NULL DEFAULT 0;`,
+
+	// 7: 正解の解説
+	`ALTER TABLE questions ADD COLUMN explanation TEXT NOT NULL DEFAULT '';`,
+
+	// 8: 問題生成プロンプトのテンプレート
+	`CREATE TABLE IF NOT EXISTS prompt_templates (
+		id          VARCHAR(64) PRIMARY KEY,
+		name        TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		body        TEXT NOT NULL,
+		created_at  TIMESTAMP NOT NULL,
+		updated_at  TIMESTAMP NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_prompt_templates_created_at ON prompt_templates (created_at);`,
+
+	// 9: 問題の言語と他言語版（言語コードごとのJSONオブジェクト）、参加者の表示言語
+	`ALTER TABLE questions ADD COLUMN language VARCHAR(16) NOT NULL DEFAULT '';
+	ALTER TABLE questions ADD COLUMN translations TEXT NOT NULL DEFAULT '{}';
+	ALTER TABLE participants ADD COLUMN language VARCHAR(16) NOT NULL DEFAULT '';`,
+
+	// 10: 問題の形式と形式ごとの正解（選択肢番号はJSON配列）、選択肢番号以外の回答
+	`ALTER TABLE questions ADD COLUMN question_type VARCHAR(20) NOT NULL DEFAULT '';
+	ALTER TABLE questions ADD COLUMN correct_answers TEXT NOT NULL DEFAULT '[]';
+	ALTER TABLE questions ADD COLUMN numeric_answer DOUBLE PRECISION NOT NULL DEFAULT 0;
+	ALTER TABLE questions ADD COLUMN tolerance DOUBLE PRECISION NOT NULL DEFAULT 0;
+	ALTER TABLE questions ADD COLUMN unit VARCHAR(20) NOT NULL DEFAULT '';
+	ALTER TABLE answers ADD COLUMN selected_options TEXT NOT NULL DEFAULT '[]';
+	ALTER TABLE answers ADD COLUMN numeric_value DOUBLE PRECISION;`,
+
+	// 11: 問題文と選択肢に添付するメディア（JSON。メディアがなければ空文字・空配列）
+	`ALTER TABLE questions ADD COLUMN media TEXT NOT NULL DEFAULT '';
+	ALTER TABLE questions ADD COLUMN option_media TEXT NOT NULL DEFAULT '[]';`,
+
+	// 12: 同じ問題への回答は1人1件（重複している回答は最初のもの以外を削除してから一意にする）
+	`DELETE FROM answers WHERE id IN (
+		SELECT a.id FROM answers a JOIN answers b
+		ON a.user_id = b.user_id AND a.question_id = b.question_id
+		AND (b.answered_at < a.answered_at OR (b.answered_at = a.answered_at AND b.id < a.id))
+	);
+	DROP INDEX IF EXISTS idx_answers_user_question;
+	CREATE UNIQUE INDEX idx_answers_user_question ON answers (user_id, question_id);`,
+
+	// 13: 結果処理を行った時刻（未処理は NULL）
+	`ALTER TABLE questions ADD COLUMN processed_at TIMESTAMP NULL;`,
+
+	// 14: ライフを失った問題IDの履歴（JSON配列）
+	`ALTER TABLE participants ADD COLUMN life_lost_for TEXT NOT NULL DEFAULT '[]';`,
+}
+
+// migrate 未適用のマイグレーションを順番に適用する
+func (s *sqlStore) migrate(ctx context.Context) error {
+	if _, err := s.exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`); err != nil {
+		return err
+	}
+
+	var current int
+	if err := s.queryRow(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return err
+	}
+
+	for i := current; i < len(sqlMigrations); i++ {
+		version := i + 1
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, sqlMigrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %w", version, err)
+		}
+		if _, err := tx.ExecContext(ctx, s.rebind(`INSERT INTO schema_migrations (version) VALUES (?)`), version); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %w", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"quiz-app/internal/domain"
+)
+
+// SQLPromptTemplateRepository PromptTemplateRepositoryのSQL実装
+type SQLPromptTemplateRepository struct {
+	store *sqlStore
+}
+
+const promptTemplateColumns = `id, name, description, body, created_at, updated_at`
+
+func scanPromptTemplate(row scanner) (*domain.PromptTemplate, error) {
+	var template domain.PromptTemplate
+	if err := row.Scan(&template.ID, &template.Name, &template.Description, &template.Body,
+		&template.CreatedAt, &template.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *SQLPromptTemplateRepository) Create(ctx context.Context, template *domain.PromptTemplate) error {
+	if template.ID == "" {
+		template.ID = uuid.New().String()
+	}
+
+	_, err := r.store.exec(ctx, `INSERT INTO prompt_templates (`+promptTemplateColumns+`) VALUES (?, ?, ?, ?, ?, ?)`,
+		template.ID, template.Name, template.Description, template.Body, template.CreatedAt, template.UpdatedAt)
+	return err
+}
+
+func (r *SQLPromptTemplateRepository) GetByID(ctx context.Context, id string) (*domain.PromptTemplate, error) {
+	template, err := scanPromptTemplate(r.store.queryRow(ctx, `SELECT `+promptTemplateColumns+` FROM prompt_templates WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrPromptTemplateNotFound
+	}
+	return template, err
+}
+
+// List 作成日時の降順でテンプレートを取得
+func (r *SQLPromptTemplateRepository) List(ctx context.Context) ([]*domain.PromptTemplate, error) {
+	rows, err := r.store.query(ctx, `SELECT `+promptTemplateColumns+` FROM prompt_templates ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := []*domain.PromptTemplate{}
+	for rows.Next() {
+		template, err := scanPromptTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+func (r *SQLPromptTemplateRepository) Update(ctx context.Context, template *domain.PromptTemplate) error {
+	return r.store.execAffecting(ctx, domain.ErrPromptTemplateNotFound,
+		`UPDATE prompt_templates SET name = ?, description = ?, body = ?, updated_at = ? WHERE id = ?`,
+		template.Name, template.Description, template.Body, template.UpdatedAt, template.ID)
+}
+
+func (r *SQLPromptTemplateRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.store.exec(ctx, `DELETE FROM prompt_templates WHERE id = ?`, id)
+	return err
+}
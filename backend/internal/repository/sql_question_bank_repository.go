@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"quiz-app/internal/domain"
+)
+
+// SQLQuestionBankRepository QuestionBankRepositoryのSQL実装
+// バンク内の問題はバンク単位でまとめて読み書きするため、JSON配列として1列に保存する
+type SQLQuestionBankRepository struct {
+	store *sqlStore
+}
+
+const questionBankColumns = `id, name, description, questions, created_at, updated_at`
+
+func scanQuestionBank(row scanner) (*domain.QuestionBank, error) {
+	var bank domain.QuestionBank
+	var questions string
+	if err := row.Scan(&bank.ID, &bank.Name, &bank.Description, &questions,
+		&bank.CreatedAt, &bank.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(questions), &bank.Questions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bank questions: %w", err)
+	}
+	return &bank, nil
+}
+
+func marshalBankQuestions(questions []domain.BankQuestion) (string, error) {
+	if questions == nil {
+		questions = []domain.BankQuestion{}
+	}
+	b, err := json.Marshal(questions)
+	return string(b), err
+}
+
+func (r *SQLQuestionBankRepository) Create(ctx context.Context, bank *domain.QuestionBank) error {
+	if bank.ID == "" {
+		bank.ID = uuid.New().String()
+	}
+	questions, err := marshalBankQuestions(bank.Questions)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.store.exec(ctx, `INSERT INTO question_banks (`+questionBankColumns+`) VALUES (?, ?, ?, ?, ?, ?)`,
+		bank.ID, bank.Name, bank.Description, questions, bank.CreatedAt, bank.UpdatedAt)
+	return err
+}
+
+func (r *SQLQuestionBankRepository) GetByID(ctx context.Context, id string) (*domain.QuestionBank, error) {
+	bank, err := scanQuestionBank(r.store.queryRow(ctx, `SELECT `+questionBankColumns+` FROM question_banks WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrQuestionBankNotFound
+	}
+	return bank, err
+}
+
+// List 作成日時の降順で問題バンクを取得
+func (r *SQLQuestionBankRepository) List(ctx context.Context) ([]*domain.QuestionBank, error) {
+	rows, err := r.store.query(ctx, `SELECT `+questionBankColumns+` FROM question_banks ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	banks := []*domain.QuestionBank{}
+	for rows.Next() {
+		bank, err := scanQuestionBank(rows)
+		if err != nil {
+			return nil, err
+		}
+		banks = append(banks, bank)
+	}
+	return banks, rows.Err()
+}
+
+func (r *SQLQuestionBankRepository) Update(ctx context.Context, bank *domain.QuestionBank) error {
+	questions, err := marshalBankQuestions(bank.Questions)
+	if err != nil {
+		return err
+	}
+
+	return r.store.execAffecting(ctx, domain.ErrQuestionBankNotFound,
+		`UPDATE question_banks SET name = ?, description = ?, questions = ?, updated_at = ? WHERE id = ?`,
+		bank.Name, bank.Description, questions, bank.UpdatedAt, bank.ID)
+}
+
+func (r *SQLQuestionBankRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.store.exec(ctx, `DELETE FROM question_banks WHERE id = ?`, id)
+	return err
+}
@@ -0,0 +1,573 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"quiz-app/internal/domain"
+)
+
+// scanner *sql.Row と *sql.Rows の共通インターフェース
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time
+	return &v
+}
+
+// marshalTimes 時刻の配列をJSON文字列に変換する（nil は空配列）
+func marshalTimes(times []time.Time) (string, error) {
+	if times == nil {
+		times = []time.Time{}
+	}
+	b, err := json.Marshal(times)
+	return string(b), err
+}
+
+// marshalLifeHistory 参加者がライフを失った時刻と問題IDの履歴をJSON文字列に変換する
+func marshalLifeHistory(participant *domain.Participant) (lifeLostAt, lifeLostFor string, err error) {
+	if lifeLostAt, err = marshalTimes(participant.LifeLostAt); err != nil {
+		return "", "", err
+	}
+	questionIDs := participant.LifeLostFor
+	if questionIDs == nil {
+		questionIDs = []string{}
+	}
+	b, err := json.Marshal(questionIDs)
+	return lifeLostAt, string(b), err
+}
+
+// SQLSessionRepository SessionRepositoryのSQL実装
+type SQLSessionRepository struct {
+	store *sqlStore
+}
+
+const sessionColumns = `id, title, status, current_round, max_participants, settings, created_at, updated_at`
+
+func scanSession(row scanner) (*domain.Session, error) {
+	var session domain.Session
+	var settings string
+	if err := row.Scan(&session.ID, &session.Title, &session.Status, &session.CurrentRound,
+		&session.MaxParticipants, &settings, &session.CreatedAt, &session.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(settings), &session.Settings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session settings: %w", err)
+	}
+	return &session, nil
+}
+
+func (r *SQLSessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	if session.ID == "" {
+		session.ID = uuid.New().String()
+	}
+	settings, err := json.Marshal(session.Settings)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.store.exec(ctx, `INSERT INTO sessions (`+sessionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		session.ID, session.Title, session.Status, session.CurrentRound,
+		session.MaxParticipants, string(settings), session.CreatedAt, session.UpdatedAt)
+	return err
+}
+
+func (r *SQLSessionRepository) GetByID(ctx context.Context, id string) (*domain.Session, error) {
+	session, err := scanSession(r.store.queryRow(ctx, `SELECT `+sessionColumns+` FROM sessions WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrSessionNotFound
+	}
+	return session, err
+}
+
+func (r *SQLSessionRepository) Update(ctx context.Context, session *domain.Session) error {
+	settings, err := json.Marshal(session.Settings)
+	if err != nil {
+		return err
+	}
+
+	return r.store.execAffecting(ctx, domain.ErrSessionNotFound,
+		`UPDATE sessions SET title = ?, status = ?, current_round = ?, max_participants = ?, settings = ?, updated_at = ? WHERE id = ?`,
+		session.Title, session.Status, session.CurrentRound, session.MaxParticipants,
+		string(settings), session.UpdatedAt, session.ID)
+}
+
+func (r *SQLSessionRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.store.exec(ctx, `DELETE FROM sessions WHERE id = ?`, id)
+	return err
+}
+
+// List 作成日時の降順でセッションを取得
+func (r *SQLSessionRepository) List(ctx context.Context, limit int, offset int) ([]*domain.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM sessions ORDER BY created_at DESC`
+	args := []interface{}{}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	} else if !r.store.postgres {
+		query += ` LIMIT -1` // SQLite は OFFSET の前に LIMIT が必須
+	}
+	query += ` OFFSET ?`
+	args = append(args, offset)
+
+	rows, err := r.store.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []*domain.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+// SQLParticipantRepository ParticipantRepositoryのSQL実装
+type SQLParticipantRepository struct {
+	store *sqlStore
+}
+
+const participantColumns = `id, user_id, session_id, display_name, status, joined_at, eliminated_at, revived_at, score, correct_answers, lives, life_lost_at, language, life_lost_for`
+
+func scanParticipant(row scanner) (*domain.Participant, error) {
+	var participant domain.Participant
+	var eliminatedAt, revivedAt sql.NullTime
+	var lifeLostAt, lifeLostFor string
+	if err := row.Scan(&participant.ID, &participant.UserID, &participant.SessionID, &participant.DisplayName,
+		&participant.Status, &participant.JoinedAt, &eliminatedAt, &revivedAt,
+		&participant.Score, &participant.CorrectAnswers, &participant.Lives, &lifeLostAt, &participant.Language, &lifeLostFor); err != nil {
+		return nil, err
+	}
+	participant.EliminatedAt = timePtr(eliminatedAt)
+	participant.RevivedAt = timePtr(revivedAt)
+	if err := json.Unmarshal([]byte(lifeLostAt), &participant.LifeLostAt); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal participant life history: %w", err)
+	}
+	if err := json.Unmarshal([]byte(lifeLostFor), &participant.LifeLostFor); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal participant life history: %w", err)
+	}
+	return &participant, nil
+}
+
+func (r *SQLParticipantRepository) Create(ctx context.Context, participant *domain.Participant) error {
+	if participant.ID == "" {
+		participant.ID = uuid.New().String()
+	}
+	lifeLostAt, lifeLostFor, err := marshalLifeHistory(participant)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.store.exec(ctx, `INSERT INTO participants (`+participantColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		participant.ID, participant.UserID, participant.SessionID, participant.DisplayName,
+		participant.Status, participant.JoinedAt, nullTime(participant.EliminatedAt), nullTime(participant.RevivedAt),
+		participant.Score, participant.CorrectAnswers, participant.Lives, lifeLostAt, participant.Language, lifeLostFor)
+	return err
+}
+
+func (r *SQLParticipantRepository) GetByID(ctx context.Context, id string) (*domain.Participant, error) {
+	return r.get(ctx, `SELECT `+participantColumns+` FROM participants WHERE id = ?`, id)
+}
+
+func (r *SQLParticipantRepository) GetByUserAndSession(ctx context.Context, userID, sessionID string) (*domain.Participant, error) {
+	return r.get(ctx, `SELECT `+participantColumns+` FROM participants WHERE user_id = ? AND session_id = ? LIMIT 1`, userID, sessionID)
+}
+
+func (r *SQLParticipantRepository) get(ctx context.Context, query string, args ...interface{}) (*domain.Participant, error) {
+	participant, err := scanParticipant(r.store.queryRow(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrParticipantNotFound
+	}
+	return participant, err
+}
+
+func (r *SQLParticipantRepository) GetBySession(ctx context.Context, sessionID string) ([]*domain.Participant, error) {
+	return r.list(ctx, `SELECT `+participantColumns+` FROM participants WHERE session_id = ? ORDER BY joined_at`, sessionID)
+}
+
+// GetActiveBySession アクティブ・復活済みの参加者を取得
+func (r *SQLParticipantRepository) GetActiveBySession(ctx context.Context, sessionID string) ([]*domain.Participant, error) {
+	return r.list(ctx, `SELECT `+participantColumns+` FROM participants WHERE session_id = ? AND status IN (?, ?) ORDER BY joined_at`,
+		sessionID, domain.ParticipantStatusActive, domain.ParticipantStatusRevived)
+}
+
+func (r *SQLParticipantRepository) GetEliminatedBySession(ctx context.Context, sessionID string) ([]*domain.Participant, error) {
+	return r.list(ctx, `SELECT `+participantColumns+` FROM participants WHERE session_id = ? AND status = ? ORDER BY joined_at`,
+		sessionID, domain.ParticipantStatusEliminated)
+}
+
+func (r *SQLParticipantRepository) list(ctx context.Context, query string, args ...interface{}) ([]*domain.Participant, error) {
+	rows, err := r.store.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	participants := []*domain.Participant{}
+	for rows.Next() {
+		participant, err := scanParticipant(rows)
+		if err != nil {
+			return nil, err
+		}
+		participants = append(participants, participant)
+	}
+	return participants, rows.Err()
+}
+
+func (r *SQLParticipantRepository) Update(ctx context.Context, participant *domain.Participant) error {
+	lifeLostAt, lifeLostFor, err := marshalLifeHistory(participant)
+	if err != nil {
+		return err
+	}
+
+	return r.store.execAffecting(ctx, domain.ErrParticipantNotFound,
+		`UPDATE participants SET display_name = ?, status = ?, eliminated_at = ?, revived_at = ?, score = ?, correct_answers = ?, lives = ?, life_lost_at = ?, language = ?, life_lost_for = ? WHERE id = ?`,
+		participant.DisplayName, participant.Status, nullTime(participant.EliminatedAt), nullTime(participant.RevivedAt),
+		participant.Score, participant.CorrectAnswers, participant.Lives, lifeLostAt, participant.Language, lifeLostFor, participant.ID)
+}
+
+func (r *SQLParticipantRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.store.exec(ctx, `DELETE FROM participants WHERE id = ?`, id)
+	return err
+}
+
+func (r *SQLParticipantRepository) CountBySession(ctx context.Context, sessionID string) (int, error) {
+	var count int
+	err := r.store.queryRow(ctx, `SELECT COUNT(*) FROM participants WHERE session_id = ?`, sessionID).Scan(&count)
+	return count, err
+}
+
+// SQLQuestionRepository QuestionRepositoryのSQL実装
+type SQLQuestionRepository struct {
+	store *sqlStore
+}
+
+const questionColumns = `id, session_id, round, text, options, correct_answer, difficulty, category, ai_provider, created_at, opened_at, bank_question_id, status, position, explanation, language, translations, question_type, correct_answers, numeric_answer, tolerance, unit, media, option_media, processed_at`
+
+func scanQuestion(row scanner) (*domain.Question, error) {
+	var question domain.Question
+	var options, translations, correctAnswers, media, optionMedia string
+	var processedAt sql.NullTime
+	if err := row.Scan(&question.ID, &question.SessionID, &question.Round, &question.Text, &options,
+		&question.CorrectAnswer, &question.Difficulty, &question.Category, &question.AIProvider,
+		&question.CreatedAt, &question.OpenedAt, &question.BankQuestionID, &question.Status, &question.Position,
+		&question.Explanation, &question.Language, &translations,
+		&question.Type, &correctAnswers, &question.NumericAnswer, &question.Tolerance, &question.Unit,
+		&media, &optionMedia, &processedAt); err != nil {
+		return nil, err
+	}
+	question.ProcessedAt = timePtr(processedAt)
+	if err := unmarshalMedia(media, optionMedia, &question); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal question media: %w", err)
+	}
+	if err := unmarshalIndexes(correctAnswers, &question.CorrectAnswers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal question correct answers: %w", err)
+	}
+	if err := json.Unmarshal([]byte(options), &question.Options); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal question options: %w", err)
+	}
+	if err := json.Unmarshal([]byte(translations), &question.Translations); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal question translations: %w", err)
+	}
+	if len(question.Translations) == 0 {
+		question.Translations = nil
+	}
+	return &question, nil
+}
+
+// marshalIndexes 選択肢番号の一覧をJSON配列にする（nil は空配列）
+func marshalIndexes(indexes []int) (string, error) {
+	if indexes == nil {
+		indexes = []int{}
+	}
+	b, err := json.Marshal(indexes)
+	return string(b), err
+}
+
+// unmarshalIndexes JSON配列の選択肢番号を読み込む（空配列は nil）
+func unmarshalIndexes(data string, indexes *[]int) error {
+	if err := json.Unmarshal([]byte(data), indexes); err != nil {
+		return err
+	}
+	if len(*indexes) == 0 {
+		*indexes = nil
+	}
+	return nil
+}
+
+// marshalMedia 問題文と選択肢のメディアをJSONにする（問題文のメディアがなければ空文字）
+func marshalMedia(question *domain.Question) (string, string, error) {
+	media := ""
+	if question.Media != nil {
+		b, err := json.Marshal(question.Media)
+		if err != nil {
+			return "", "", err
+		}
+		media = string(b)
+	}
+	optionMedia := question.OptionMedia
+	if optionMedia == nil {
+		optionMedia = []*domain.Media{}
+	}
+	b, err := json.Marshal(optionMedia)
+	return media, string(b), err
+}
+
+// unmarshalMedia JSONの問題文と選択肢のメディアを読み込む（空配列は nil）
+func unmarshalMedia(media, optionMedia string, question *domain.Question) error {
+	if media != "" {
+		if err := json.Unmarshal([]byte(media), &question.Media); err != nil {
+			return err
+		}
+	}
+	if err := json.Unmarshal([]byte(optionMedia), &question.OptionMedia); err != nil {
+		return err
+	}
+	if len(question.OptionMedia) == 0 {
+		question.OptionMedia = nil
+	}
+	return nil
+}
+
+func marshalTranslations(translations map[string]domain.QuestionTranslation) (string, error) {
+	if translations == nil {
+		translations = map[string]domain.QuestionTranslation{}
+	}
+	b, err := json.Marshal(translations)
+	return string(b), err
+}
+
+func (r *SQLQuestionRepository) Create(ctx context.Context, question *domain.Question) error {
+	if question.ID == "" {
+		question.ID = uuid.New().String()
+	}
+	options, err := json.Marshal(question.Options)
+	if err != nil {
+		return err
+	}
+	translations, err := marshalTranslations(question.Translations)
+	if err != nil {
+		return err
+	}
+	correctAnswers, err := marshalIndexes(question.CorrectAnswers)
+	if err != nil {
+		return err
+	}
+	media, optionMedia, err := marshalMedia(question)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.store.exec(ctx, `INSERT INTO questions (`+questionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		question.ID, question.SessionID, question.Round, question.Text, string(options),
+		question.CorrectAnswer, question.Difficulty, question.Category, question.AIProvider,
+		question.CreatedAt, question.OpenedAt, question.BankQuestionID, question.Status, question.Position,
+		question.Explanation, question.Language, translations,
+		question.Type, correctAnswers, question.NumericAnswer, question.Tolerance, question.Unit,
+		media, optionMedia, nullTime(question.ProcessedAt))
+	return err
+}
+
+func (r *SQLQuestionRepository) GetByID(ctx context.Context, id string) (*domain.Question, error) {
+	return r.get(ctx, `SELECT `+questionColumns+` FROM questions WHERE id = ?`, id)
+}
+
+func (r *SQLQuestionRepository) GetBySessionAndRound(ctx context.Context, sessionID string, round int) (*domain.Question, error) {
+	return r.get(ctx, `SELECT `+questionColumns+` FROM questions WHERE session_id = ? AND round = ? ORDER BY created_at LIMIT 1`, sessionID, round)
+}
+
+func (r *SQLQuestionRepository) get(ctx context.Context, query string, args ...interface{}) (*domain.Question, error) {
+	question, err := scanQuestion(r.store.queryRow(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrQuestionNotFound
+	}
+	return question, err
+}
+
+// GetBySession ラウンドの昇順で問題を取得
+func (r *SQLQuestionRepository) GetBySession(ctx context.Context, sessionID string) ([]*domain.Question, error) {
+	rows, err := r.store.query(ctx, `SELECT `+questionColumns+` FROM questions WHERE session_id = ? ORDER BY round, created_at`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	questions := []*domain.Question{}
+	for rows.Next() {
+		question, err := scanQuestion(rows)
+		if err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	return questions, rows.Err()
+}
+
+func (r *SQLQuestionRepository) Update(ctx context.Context, question *domain.Question) error {
+	options, err := json.Marshal(question.Options)
+	if err != nil {
+		return err
+	}
+	translations, err := marshalTranslations(question.Translations)
+	if err != nil {
+		return err
+	}
+	correctAnswers, err := marshalIndexes(question.CorrectAnswers)
+	if err != nil {
+		return err
+	}
+	media, optionMedia, err := marshalMedia(question)
+	if err != nil {
+		return err
+	}
+
+	return r.store.execAffecting(ctx, domain.ErrQuestionNotFound,
+		`UPDATE questions SET round = ?, text = ?, options = ?, correct_answer = ?, difficulty = ?, category = ?, ai_provider = ?, opened_at = ?, bank_question_id = ?, status = ?, position = ?, explanation = ?, language = ?, translations = ?, question_type = ?, correct_answers = ?, numeric_answer = ?, tolerance = ?, unit = ?, media = ?, option_media = ?, processed_at = ? WHERE id = ?`,
+		question.Round, question.Text, string(options), question.CorrectAnswer, question.Difficulty,
+		question.Category, question.AIProvider, question.OpenedAt, question.BankQuestionID,
+		question.Status, question.Position, question.Explanation, question.Language, translations,
+		question.Type, correctAnswers, question.NumericAnswer, question.Tolerance, question.Unit,
+		media, optionMedia, nullTime(question.ProcessedAt), question.ID)
+}
+
+func (r *SQLQuestionRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.store.exec(ctx, `DELETE FROM questions WHERE id = ?`, id)
+	return err
+}
+
+// SQLAnswerRepository AnswerRepositoryのSQL実装
+type SQLAnswerRepository struct {
+	store *sqlStore
+}
+
+const answerColumns = `id, user_id, session_id, question_id, selected_option, is_correct, answered_at, response_time, client_response_time, points, selected_options, numeric_value`
+
+func scanAnswer(row scanner) (*domain.Answer, error) {
+	var answer domain.Answer
+	var selectedOptions string
+	var numericValue sql.NullFloat64
+	if err := row.Scan(&answer.ID, &answer.UserID, &answer.SessionID, &answer.QuestionID, &answer.SelectedOption,
+		&answer.IsCorrect, &answer.AnsweredAt, &answer.ResponseTime, &answer.ClientResponseTime, &answer.Points,
+		&selectedOptions, &numericValue); err != nil {
+		return nil, err
+	}
+	if err := unmarshalIndexes(selectedOptions, &answer.SelectedOptions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal selected options: %w", err)
+	}
+	if numericValue.Valid {
+		answer.NumericValue = &numericValue.Float64
+	}
+	return &answer, nil
+}
+
+func (r *SQLAnswerRepository) Create(ctx context.Context, answer *domain.Answer) error {
+	if answer.ID == "" {
+		answer.ID = uuid.New().String()
+	}
+
+	selectedOptions, err := marshalIndexes(answer.SelectedOptions)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.store.exec(ctx, `INSERT INTO answers (`+answerColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		answer.ID, answer.UserID, answer.SessionID, answer.QuestionID, answer.SelectedOption,
+		answer.IsCorrect, answer.AnsweredAt, answer.ResponseTime, answer.ClientResponseTime, answer.Points,
+		selectedOptions, answer.NumericValue)
+	if isUniqueViolation(err) {
+		return domain.ErrAnswerExists
+	}
+	return err
+}
+
+func (r *SQLAnswerRepository) GetByID(ctx context.Context, id string) (*domain.Answer, error) {
+	return r.get(ctx, `SELECT `+answerColumns+` FROM answers WHERE id = ?`, id)
+}
+
+func (r *SQLAnswerRepository) GetByUserAndQuestion(ctx context.Context, userID, questionID string) (*domain.Answer, error) {
+	return r.get(ctx, `SELECT `+answerColumns+` FROM answers WHERE user_id = ? AND question_id = ? LIMIT 1`, userID, questionID)
+}
+
+func (r *SQLAnswerRepository) get(ctx context.Context, query string, args ...interface{}) (*domain.Answer, error) {
+	answer, err := scanAnswer(r.store.queryRow(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAnswerNotFound
+	}
+	return answer, err
+}
+
+func (r *SQLAnswerRepository) GetByQuestion(ctx context.Context, questionID string) ([]*domain.Answer, error) {
+	return r.list(ctx, `SELECT `+answerColumns+` FROM answers WHERE question_id = ? ORDER BY answered_at DESC`, questionID)
+}
+
+func (r *SQLAnswerRepository) GetBySession(ctx context.Context, sessionID string) ([]*domain.Answer, error) {
+	return r.list(ctx, `SELECT `+answerColumns+` FROM answers WHERE session_id = ? ORDER BY answered_at DESC`, sessionID)
+}
+
+func (r *SQLAnswerRepository) GetByUserAndSession(ctx context.Context, userID, sessionID string) ([]*domain.Answer, error) {
+	return r.list(ctx, `SELECT `+answerColumns+` FROM answers WHERE user_id = ? AND session_id = ? ORDER BY answered_at DESC`, userID, sessionID)
+}
+
+func (r *SQLAnswerRepository) list(ctx context.Context, query string, args ...interface{}) ([]*domain.Answer, error) {
+	rows, err := r.store.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	answers := []*domain.Answer{}
+	for rows.Next() {
+		answer, err := scanAnswer(rows)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, answer)
+	}
+	return answers, rows.Err()
+}
+
+func (r *SQLAnswerRepository) Update(ctx context.Context, answer *domain.Answer) error {
+	selectedOptions, err := marshalIndexes(answer.SelectedOptions)
+	if err != nil {
+		return err
+	}
+
+	return r.store.execAffecting(ctx, domain.ErrAnswerNotFound,
+		`UPDATE answers SET selected_option = ?, is_correct = ?, answered_at = ?, response_time = ?, client_response_time = ?, points = ?, selected_options = ?, numeric_value = ? WHERE id = ?`,
+		answer.SelectedOption, answer.IsCorrect, answer.AnsweredAt, answer.ResponseTime, answer.ClientResponseTime, answer.Points,
+		selectedOptions, answer.NumericValue, answer.ID)
+}
+
+func (r *SQLAnswerRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.store.exec(ctx, `DELETE FROM answers WHERE id = ?`, id)
+	return err
+}
+
+func (r *SQLAnswerRepository) CountCorrectByUserAndSession(ctx context.Context, userID, sessionID string) (int, error) {
+	var count int
+	err := r.store.queryRow(ctx, `SELECT COUNT(*) FROM answers WHERE user_id = ? AND session_id = ? AND is_correct = ?`,
+		userID, sessionID, true).Scan(&count)
+	return count, err
+}
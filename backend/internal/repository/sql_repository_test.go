@@ -0,0 +1,396 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"quiz-app/internal/domain"
+)
+
+func newTestSQLRepositories(t *testing.T) (*Repositories, *sql.DB) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "quiz.db"))
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	repos, err := NewSQLRepositories(context.Background(), db, "sqlite3")
+	require.NoError(t, err)
+	return repos, db
+}
+
+func TestSQLMigrations(t *testing.T) {
+	t.Run("マイグレーションを再実行しても失敗しないこと", func(t *testing.T) {
+		_, db := newTestSQLRepositories(t)
+
+		_, err := NewSQLRepositories(context.Background(), db, "sqlite3")
+		require.NoError(t, err)
+
+		var version int
+		require.NoError(t, db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version))
+		assert.Equal(t, len(sqlMigrations), version)
+	})
+
+	t.Run("同じ問題への重複した回答は最初のものだけを残して一意にすること", func(t *testing.T) {
+		repos, db := newTestSQLRepositories(t)
+		ctx := context.Background()
+		// 一意インデックスを追加する前（マイグレーション11まで）の状態に戻して重複した回答を入れる
+		_, err := db.Exec(`DROP INDEX idx_answers_user_question;
+			CREATE INDEX idx_answers_user_question ON answers (user_id, question_id);
+			ALTER TABLE questions DROP COLUMN processed_at;
+			ALTER TABLE participants DROP COLUMN life_lost_for;
+			DELETE FROM schema_migrations WHERE version >= 12`)
+		require.NoError(t, err)
+		first := domain.NewAnswer("user1", "s1", "q1", 0, 0)
+		second := domain.NewAnswer("user1", "s1", "q1", 1, 0)
+		second.AnsweredAt = first.AnsweredAt.Add(time.Second)
+		require.NoError(t, repos.AnswerRepo.Create(ctx, first))
+		require.NoError(t, repos.AnswerRepo.Create(ctx, second))
+
+		repos, err = NewSQLRepositories(ctx, db, "sqlite3")
+		require.NoError(t, err)
+
+		answers, err := repos.AnswerRepo.GetByQuestion(ctx, "q1")
+		require.NoError(t, err)
+		require.Len(t, answers, 1)
+		assert.Equal(t, first.ID, answers[0].ID)
+		assert.ErrorIs(t, repos.AnswerRepo.Create(ctx, domain.NewAnswer("user1", "s1", "q1", 2, 0)), domain.ErrAnswerExists)
+	})
+
+	t.Run("PostgreSQL用にプレースホルダーが変換されること", func(t *testing.T) {
+		store := &sqlStore{postgres: true}
+		assert.Equal(t, "SELECT * FROM answers WHERE user_id = $1 AND question_id = $2",
+			store.rebind("SELECT * FROM answers WHERE user_id = ? AND question_id = ?"))
+	})
+}
+
+func TestSQLSessionRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("設定を含めてセッションを保存・更新できること", func(t *testing.T) {
+		repos, _ := newTestSQLRepositories(t)
+		session := domain.NewSession("SQLセッション", 50, domain.Settings{TimeLimit: 20, RevivalEnabled: true, RevivalCount: 2})
+		require.NoError(t, repos.SessionRepo.Create(ctx, session))
+
+		session.Status = domain.SessionStatusActive
+		session.CurrentRound = 3
+		require.NoError(t, repos.SessionRepo.Update(ctx, session))
+
+		got, err := repos.SessionRepo.GetByID(ctx, session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SessionStatusActive, got.Status)
+		assert.Equal(t, 3, got.CurrentRound)
+		assert.Equal(t, session.Settings, got.Settings)
+	})
+
+	t.Run("存在しないセッションはErrSessionNotFoundになること", func(t *testing.T) {
+		repos, _ := newTestSQLRepositories(t)
+		_, err := repos.SessionRepo.GetByID(ctx, "missing")
+		assert.ErrorIs(t, err, domain.ErrSessionNotFound)
+
+		err = repos.SessionRepo.Update(ctx, &domain.Session{ID: "missing"})
+		assert.ErrorIs(t, err, domain.ErrSessionNotFound)
+	})
+
+	t.Run("一覧が作成日時の降順でページングされること", func(t *testing.T) {
+		repos, _ := newTestSQLRepositories(t)
+		base := time.Now()
+		for i := 0; i < 3; i++ {
+			session := domain.NewSession(fmt.Sprintf("session-%d", i), 100, domain.Settings{})
+			session.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+			require.NoError(t, repos.SessionRepo.Create(ctx, session))
+		}
+
+		sessions, err := repos.SessionRepo.List(ctx, 2, 1)
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		assert.Equal(t, "session-1", sessions[0].Title)
+		assert.Equal(t, "session-0", sessions[1].Title)
+	})
+}
+
+func TestSQLParticipantRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ステータスで参加者を絞り込み、脱落時刻が保存されること", func(t *testing.T) {
+		repos, _ := newTestSQLRepositories(t)
+		active := domain.NewParticipant("user1", "s1", "アクティブ")
+		eliminated := domain.NewParticipant("user2", "s1", "脱落")
+		revived := domain.NewParticipant("user3", "s1", "復活")
+		for _, p := range []*domain.Participant{active, eliminated, revived} {
+			require.NoError(t, repos.ParticipantRepo.Create(ctx, p))
+		}
+
+		eliminated.Lives = 2
+		eliminated.LoseLife("q1")
+		eliminated.LoseLife("q2")
+		require.NoError(t, repos.ParticipantRepo.Update(ctx, eliminated))
+		revived.Eliminate()
+		revived.Revive()
+		require.NoError(t, repos.ParticipantRepo.Update(ctx, revived))
+
+		actives, err := repos.ParticipantRepo.GetActiveBySession(ctx, "s1")
+		require.NoError(t, err)
+		assert.Len(t, actives, 2)
+
+		eliminateds, err := repos.ParticipantRepo.GetEliminatedBySession(ctx, "s1")
+		require.NoError(t, err)
+		require.Len(t, eliminateds, 1)
+		require.NotNil(t, eliminateds[0].EliminatedAt)
+		assert.Nil(t, eliminateds[0].RevivedAt)
+		assert.Equal(t, 0, eliminateds[0].Lives)
+		assert.Len(t, eliminateds[0].LifeLostAt, 2)
+		assert.Equal(t, []string{"q1", "q2"}, eliminateds[0].LifeLostFor)
+
+		got, err := repos.ParticipantRepo.GetByUserAndSession(ctx, "user3", "s1")
+		require.NoError(t, err)
+		assert.NotNil(t, got.RevivedAt)
+	})
+
+	t.Run("参加者の表示言語が保存されること", func(t *testing.T) {
+		repos, _ := newTestSQLRepositories(t)
+		participant := domain.NewParticipant("user1", "s1", "参加者")
+		participant.Language = "en"
+		require.NoError(t, repos.ParticipantRepo.Create(ctx, participant))
+
+		participant.Language = "ko"
+		require.NoError(t, repos.ParticipantRepo.Update(ctx, participant))
+
+		got, err := repos.ParticipantRepo.GetByUserAndSession(ctx, "user1", "s1")
+		require.NoError(t, err)
+		assert.Equal(t, "ko", got.Language)
+	})
+}
+
+func TestSQLQuestionAndAnswerRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ラウンドで問題を取得し、回答を集計できること", func(t *testing.T) {
+		repos, _ := newTestSQLRepositories(t)
+		question := domain.NewQuestion("s1", 1, "問題", []string{"A", "B", "C", "D"}, 2, domain.DifficultyEasy, "general", domain.AIProviderGemini)
+		question.Explanation = "Cが正解です"
+		question.Open(time.Now())
+		require.NoError(t, repos.QuestionRepo.Create(ctx, question))
+
+		got, err := repos.QuestionRepo.GetBySessionAndRound(ctx, "s1", 1)
+		require.NoError(t, err)
+		assert.Equal(t, question.Options, got.Options)
+		assert.Equal(t, "Cが正解です", got.Explanation)
+		assert.WithinDuration(t, question.OpenedAt, got.OpenedAt, time.Millisecond)
+
+		_, err = repos.QuestionRepo.GetBySessionAndRound(ctx, "s1", 2)
+		assert.ErrorIs(t, err, domain.ErrQuestionNotFound)
+
+		correct := domain.NewAnswer("user1", "s1", question.ID, 2, 800)
+		correct.IsCorrect = true
+		wrong := domain.NewAnswer("user2", "s1", question.ID, 0, 900)
+		require.NoError(t, repos.AnswerRepo.Create(ctx, correct))
+		require.NoError(t, repos.AnswerRepo.Create(ctx, wrong))
+
+		answers, err := repos.AnswerRepo.GetByQuestion(ctx, question.ID)
+		require.NoError(t, err)
+		assert.Len(t, answers, 2)
+
+		count, err := repos.AnswerRepo.CountCorrectByUserAndSession(ctx, "user1", "s1")
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		_, err = repos.AnswerRepo.GetByUserAndQuestion(ctx, "user3", question.ID)
+		assert.ErrorIs(t, err, domain.ErrAnswerNotFound)
+	})
+
+	t.Run("問題の言語と翻訳が保存されること", func(t *testing.T) {
+		repos, _ := newTestSQLRepositories(t)
+		question := domain.NewQuestion("s1", 1, "問題", []string{"A", "B", "C", "D"}, 2, domain.DifficultyEasy, "general", domain.AIProviderLocal)
+		question.Language = "ja"
+		require.NoError(t, repos.QuestionRepo.Create(ctx, question))
+
+		got, err := repos.QuestionRepo.GetByID(ctx, question.ID)
+		require.NoError(t, err)
+		assert.Nil(t, got.Translations)
+
+		require.NoError(t, question.SetTranslation("en", domain.QuestionTranslation{Text: "Question", Options: []string{"a", "b", "c", "d"}, Explanation: "C"}))
+		require.NoError(t, repos.QuestionRepo.Update(ctx, question))
+
+		got, err = repos.QuestionRepo.GetByID(ctx, question.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "ja", got.Language)
+		assert.Equal(t, question.Translations, got.Translations)
+	})
+
+	t.Run("結果処理を行った時刻が保存されること", func(t *testing.T) {
+		repos, _ := newTestSQLRepositories(t)
+		question := domain.NewQuestion("s1", 1, "問題", []string{"A", "B", "C", "D"}, 2, domain.DifficultyEasy, "general", domain.AIProviderLocal)
+		require.NoError(t, repos.QuestionRepo.Create(ctx, question))
+
+		got, err := repos.QuestionRepo.GetByID(ctx, question.ID)
+		require.NoError(t, err)
+		assert.False(t, got.IsProcessed())
+
+		processedAt := time.Now()
+		question.MarkProcessed(processedAt)
+		require.NoError(t, repos.QuestionRepo.Update(ctx, question))
+
+		got, err = repos.QuestionRepo.GetByID(ctx, question.ID)
+		require.NoError(t, err)
+		require.True(t, got.IsProcessed())
+		assert.WithinDuration(t, processedAt, *got.ProcessedAt, time.Millisecond)
+	})
+
+	t.Run("問題形式ごとの正解と回答が保存されること", func(t *testing.T) {
+		repos, _ := newTestSQLRepositories(t)
+		ordering := domain.NewQuestion("s1", 1, "古い順に並べてください", []string{"江戸", "平安", "明治", "奈良"}, 0, domain.DifficultyEasy, "歴史", domain.AIProviderLocal)
+		ordering.SetAnswerKey(domain.AnswerKey{Type: domain.QuestionTypeOrdering, CorrectAnswers: []int{3, 1, 0, 2}})
+		numeric := domain.NewQuestion("s1", 2, "富士山の標高は？", nil, 0, domain.DifficultyEasy, "地理", domain.AIProviderLocal)
+		numeric.SetAnswerKey(domain.AnswerKey{Type: domain.QuestionTypeNumeric, NumericAnswer: 3776.5, Tolerance: 10, Unit: "m"})
+		require.NoError(t, repos.QuestionRepo.Create(ctx, ordering))
+		require.NoError(t, repos.QuestionRepo.Create(ctx, numeric))
+
+		got, err := repos.QuestionRepo.GetByID(ctx, ordering.ID)
+		require.NoError(t, err)
+		assert.Equal(t, ordering.AnswerKey(), got.AnswerKey())
+		got, err = repos.QuestionRepo.GetBySessionAndRound(ctx, "s1", 2)
+		require.NoError(t, err)
+		assert.Equal(t, numeric.AnswerKey(), got.AnswerKey())
+		assert.Empty(t, got.Options)
+
+		value := 3780.0
+		numericAnswer := domain.NewAnswer("user1", "s1", numeric.ID, -1, 800)
+		numericAnswer.SetInput(domain.AnswerInput{NumericValue: &value})
+		orderingAnswer := domain.NewAnswer("user1", "s1", ordering.ID, -1, 800)
+		orderingAnswer.SetInput(domain.AnswerInput{SelectedOptions: []int{1, 3, 0, 2}})
+		require.NoError(t, repos.AnswerRepo.Create(ctx, numericAnswer))
+		require.NoError(t, repos.AnswerRepo.Create(ctx, orderingAnswer))
+
+		answer, err := repos.AnswerRepo.GetByUserAndQuestion(ctx, "user1", numeric.ID)
+		require.NoError(t, err)
+		require.NotNil(t, answer.NumericValue)
+		assert.Equal(t, value, *answer.NumericValue)
+		assert.Nil(t, answer.SelectedOptions)
+
+		answer, err = repos.AnswerRepo.GetByUserAndQuestion(ctx, "user1", ordering.ID)
+		require.NoError(t, err)
+		assert.Nil(t, answer.NumericValue)
+		assert.Equal(t, []int{1, 3, 0, 2}, answer.SelectedOptions)
+		assert.Equal(t, -1, answer.SelectedOption)
+	})
+
+	t.Run("問題文と選択肢のメディアが保存されること", func(t *testing.T) {
+		repos, _ := newTestSQLRepositories(t)
+		question := domain.NewQuestion("s1", 1, "この写真はどこ？", []string{"A", "B", "C", "D"}, 0, domain.DifficultyEasy, "", domain.AIProviderLocal)
+		require.NoError(t, repos.QuestionRepo.Create(ctx, question))
+
+		got, err := repos.QuestionRepo.GetByID(ctx, question.ID)
+		require.NoError(t, err)
+		assert.Nil(t, got.Media)
+		assert.Nil(t, got.OptionMedia)
+
+		image, err := domain.MediaForKey("0b0d5f1e-8c1a-4c7b-9f43-2f6f3d1a9e10.png", "東京タワー")
+		require.NoError(t, err)
+		image.URL = "/api/v1/media/" + image.Key
+		audio, err := domain.MediaForKey("5a1f0c2d-3b4e-4f60-8a9b-0c1d2e3f4a5b.mp3", "")
+		require.NoError(t, err)
+		require.NoError(t, got.SetMedia(image, []*domain.Media{nil, audio, nil, nil}))
+		require.NoError(t, repos.QuestionRepo.Update(ctx, got))
+
+		got, err = repos.QuestionRepo.GetByID(ctx, question.ID)
+		require.NoError(t, err)
+		assert.Equal(t, image, got.Media)
+		assert.Equal(t, []*domain.Media{nil, audio, nil, nil}, got.OptionMedia)
+	})
+}
+
+func TestSQLUserRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("user_passwordsテーブルで認証できること", func(t *testing.T) {
+		repos, _ := newTestSQLRepositories(t)
+		user := &domain.User{Username: "admin", DisplayName: "管理者", CreatedAt: time.Now()}
+		require.NoError(t, repos.UserRepo.CreateWithPassword(ctx, user, "secret"))
+
+		got, err := repos.UserRepo.ValidateUserCredentials(ctx, "admin", "secret")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, got.ID)
+
+		_, err = repos.UserRepo.ValidateUserCredentials(ctx, "admin", "wrong")
+		assert.EqualError(t, err, "invalid credentials")
+
+		require.NoError(t, repos.UserRepo.Delete(ctx, user.ID))
+		_, err = repos.UserRepo.ValidateUserCredentials(ctx, "admin", "secret")
+		assert.EqualError(t, err, "user not found")
+	})
+}
+
+func TestSQLQuestionBankRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("問題一覧を含めて保存し、出題元のバンク問題IDとレビュー状態が問題に保存されること", func(t *testing.T) {
+		repos, _ := newTestSQLRepositories(t)
+		bank := domain.NewQuestionBank("社内クイズ", "説明", []domain.BankQuestion{
+			{Text: "問題", Options: []string{"A", "B", "C"}, CorrectAnswer: 2, Difficulty: domain.DifficultyHard, Category: "会社", Tags: []string{"歴史"}},
+		})
+		require.NoError(t, repos.QuestionBankRepo.Create(ctx, bank))
+
+		got, err := repos.QuestionBankRepo.GetByID(ctx, bank.ID)
+		require.NoError(t, err)
+		assert.Equal(t, bank.Questions, got.Questions)
+		assert.Equal(t, "説明", got.Description)
+
+		question := got.Questions[0].ToQuestion("s1", 1)
+		question.MarkDraft(2)
+		require.NoError(t, repos.QuestionRepo.Create(ctx, question))
+		require.NoError(t, question.Approve())
+		require.NoError(t, repos.QuestionRepo.Update(ctx, question))
+
+		saved, err := repos.QuestionRepo.GetByID(ctx, question.ID)
+		require.NoError(t, err)
+		assert.Equal(t, bank.Questions[0].ID, saved.BankQuestionID)
+		assert.Equal(t, domain.QuestionStatusApproved, saved.Status)
+		assert.Equal(t, 2, saved.Position)
+
+		require.NoError(t, repos.QuestionBankRepo.Delete(ctx, bank.ID))
+		_, err = repos.QuestionBankRepo.GetByID(ctx, bank.ID)
+		assert.ErrorIs(t, err, domain.ErrQuestionBankNotFound)
+		assert.ErrorIs(t, repos.QuestionBankRepo.Update(ctx, bank), domain.ErrQuestionBankNotFound)
+	})
+}
+
+func TestSQLPromptTemplateRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("テンプレートを保存・更新・削除でき、セッションの問題生成設定が保存されること", func(t *testing.T) {
+		repos, _ := newTestSQLRepositories(t)
+		template := domain.NewPromptTemplate("社内向け", "説明", "{{.Theme}}の問題")
+		require.NoError(t, repos.TemplateRepo.Create(ctx, template))
+
+		template.Body = "{{.Audience}}向けの{{.Theme}}の問題"
+		require.NoError(t, repos.TemplateRepo.Update(ctx, template))
+
+		templates, err := repos.TemplateRepo.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, templates, 1)
+		assert.Equal(t, template.Body, templates[0].Body)
+		assert.Equal(t, "説明", templates[0].Description)
+
+		session := domain.NewSession("テーマ付き", 10, domain.Settings{Generation: domain.GenerationSettings{
+			Categories:       []string{"社史", "製品"},
+			Theme:            "会社の歴史",
+			ReferenceText:    "1998年に創業。",
+			PromptTemplateID: template.ID,
+		}})
+		require.NoError(t, repos.SessionRepo.Create(ctx, session))
+		got, err := repos.SessionRepo.GetByID(ctx, session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, session.Settings.Generation, got.Settings.Generation)
+
+		require.NoError(t, repos.TemplateRepo.Delete(ctx, template.ID))
+		_, err = repos.TemplateRepo.GetByID(ctx, template.ID)
+		assert.ErrorIs(t, err, domain.ErrPromptTemplateNotFound)
+		assert.ErrorIs(t, repos.TemplateRepo.Update(ctx, template), domain.ErrPromptTemplateNotFound)
+	})
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"quiz-app/internal/domain"
+)
+
+// SQLUserRepository UserRepositoryのSQL実装
+// パスワードハッシュは Firestore 実装と同様に user_passwords テーブルへ分離して保存する
+type SQLUserRepository struct {
+	store *sqlStore
+}
+
+const userColumns = `id, username, display_name, email, is_anonymous, access_code, created_at, last_login_at`
+
+func scanUser(row scanner) (*domain.User, error) {
+	var user domain.User
+	if err := row.Scan(&user.ID, &user.Username, &user.DisplayName, &user.Email,
+		&user.IsAnonymous, &user.AccessCode, &user.CreatedAt, &user.LastLoginAt); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Create ユーザーを作成
+func (r *SQLUserRepository) Create(ctx context.Context, user *domain.User) error {
+	if user.ID == "" {
+		user.ID = generateUserID()
+	}
+
+	_, err := r.store.exec(ctx, `INSERT INTO users (`+userColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		user.ID, user.Username, user.DisplayName, user.Email,
+		user.IsAnonymous, user.AccessCode, user.CreatedAt, user.LastLoginAt)
+	return err
+}
+
+// CreateWithPassword パスワード付きでユーザーを作成（ユーザーとパスワードを同一トランザクションで保存）
+func (r *SQLUserRepository) CreateWithPassword(ctx context.Context, user *domain.User, password string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	if user.ID == "" {
+		user.ID = generateUserID()
+	}
+
+	tx, err := r.store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, r.store.rebind(`INSERT INTO users (`+userColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`),
+		user.ID, user.Username, user.DisplayName, user.Email,
+		user.IsAnonymous, user.AccessCode, user.CreatedAt, user.LastLoginAt); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, r.store.rebind(`INSERT INTO user_passwords (user_id, username, password_hash, created_at) VALUES (?, ?, ?, ?)`),
+		user.ID, user.Username, string(hashedPassword), time.Now()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetByID ユーザーを取得
+func (r *SQLUserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	return r.get(ctx, `SELECT `+userColumns+` FROM users WHERE id = ?`, id)
+}
+
+// GetByEmail メールアドレスでユーザーを取得
+func (r *SQLUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.get(ctx, `SELECT `+userColumns+` FROM users WHERE email = ? LIMIT 1`, email)
+}
+
+// GetByUsername ユーザー名でユーザーを取得
+func (r *SQLUserRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return r.get(ctx, `SELECT `+userColumns+` FROM users WHERE username = ? LIMIT 1`, username)
+}
+
+func (r *SQLUserRepository) get(ctx context.Context, query string, args ...interface{}) (*domain.User, error) {
+	user, err := scanUser(r.store.queryRow(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrUserNotFound
+	}
+	return user, err
+}
+
+// ValidateUserCredentials ユーザー認証情報を検証
+func (r *SQLUserRepository) ValidateUserCredentials(ctx context.Context, username, password string) (*domain.User, error) {
+	var userID, passwordHash string
+	err := r.store.queryRow(ctx, `SELECT user_id, password_hash FROM user_passwords WHERE username = ? LIMIT 1`, username).
+		Scan(&userID, &passwordHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("user not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(password)); err != nil {
+		return nil, errors.New("invalid credentials")
+	}
+
+	return r.GetByID(ctx, userID)
+}
+
+// Update ユーザーを更新
+func (r *SQLUserRepository) Update(ctx context.Context, user *domain.User) error {
+	return r.store.execAffecting(ctx, domain.ErrUserNotFound,
+		`UPDATE users SET username = ?, display_name = ?, email = ?, is_anonymous = ?, access_code = ?, last_login_at = ? WHERE id = ?`,
+		user.Username, user.DisplayName, user.Email, user.IsAnonymous, user.AccessCode, user.LastLoginAt, user.ID)
+}
+
+// Delete ユーザーとパスワード情報を削除
+func (r *SQLUserRepository) Delete(ctx context.Context, id string) error {
+	tx, err := r.store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, r.store.rebind(`DELETE FROM user_passwords WHERE user_id = ?`), id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, r.store.rebind(`DELETE FROM users WHERE id = ?`), id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// BulkCreateUsers 一括ユーザー作成
+func (r *SQLUserRepository) BulkCreateUsers(ctx context.Context, users []UserCredentials) error {
+	for _, userCred := range users {
+		user := &domain.User{
+			ID:          generateUserID(),
+			Username:    userCred.Username,
+			DisplayName: userCred.DisplayName,
+			CreatedAt:   time.Now(),
+		}
+		if err := r.CreateWithPassword(ctx, user, userCred.Password); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAll すべてのユーザーを作成日時順に取得
+func (r *SQLUserRepository) GetAll(ctx context.Context) ([]*domain.User, error) {
+	rows, err := r.store.query(ctx, `SELECT `+userColumns+` FROM users ORDER BY created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*domain.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
@@ -3,10 +3,11 @@ package service
 import (
 	"context"
 	"quiz-app/internal/domain"
+	"strings"
 )
 
 type AIClient interface {
-	GenerateQuestion(ctx context.Context, difficulty domain.Difficulty, category string) (*domain.Question, error)
+	GenerateQuestion(ctx context.Context, request QuestionGenerationRequest) (*domain.Question, error)
 	IsAvailable() bool
 	GetName() string
 }
@@ -17,6 +18,20 @@ type QuestionGenerationRequest struct {
 	Round      int               `json:"round"`
 	SessionID  string            `json:"sessionId"`
 	Language   string            `json:"language"` // "ja" for Japanese
+	// QuestionType 生成する問題の形式（未指定は単一選択）
+	QuestionType domain.QuestionType `json:"questionType,omitempty"`
+	// TranslationLanguages 問題と同時に翻訳を作成する言語（Language 以外の言語コード）
+	TranslationLanguages []string `json:"translationLanguages,omitempty"`
+	// AvoidQuestions 出題済みの問題文（似た問題を避けるようプロンプトで指示する）
+	AvoidQuestions []string `json:"avoidQuestions,omitempty"`
+	// セッションの問題生成設定（プロンプトテンプレートの変数になる）
+	Audience      string `json:"audience,omitempty"`
+	Theme         string `json:"theme,omitempty"`
+	ReferenceText string `json:"referenceText,omitempty"`
+	// PromptTemplate テンプレートの本文（空なら DefaultPromptTemplate）
+	PromptTemplate string `json:"-"`
+	// Prompt テンプレートを展開した指示（AIService が設定し、空ならクライアントが既定のテンプレートで作成する）
+	Prompt string `json:"-"`
 }
 
 type QuestionGenerationResponse struct {
@@ -24,4 +39,36 @@ type QuestionGenerationResponse struct {
 	Options       []string `json:"options"`
 	CorrectAnswer int      `json:"correctAnswer"`
 	Explanation   string   `json:"explanation,omitempty"`
-}
\ No newline at end of file
+	// 単一選択・○×以外の問題形式の正解（domain.AnswerKey を参照）
+	CorrectAnswers []int   `json:"correctAnswers,omitempty"`
+	NumericAnswer  float64 `json:"numericAnswer,omitempty"`
+	Tolerance      float64 `json:"tolerance,omitempty"`
+	Unit           string  `json:"unit,omitempty"`
+	// Translations 言語コードごとの翻訳（TranslationLanguages を指定した場合のみ）
+	Translations map[string]domain.QuestionTranslation `json:"translations,omitempty"`
+}
+
+// applyAnswerKey 応答の問題形式ごとの正解を問題に設定する
+// 問題形式はリクエストに合わせてルーターが設定する
+func (r *QuestionGenerationResponse) applyAnswerKey(question *domain.Question) {
+	question.CorrectAnswers = r.CorrectAnswers
+	question.NumericAnswer = r.NumericAnswer
+	question.Tolerance = r.Tolerance
+	question.Unit = r.Unit
+}
+
+// avoidQuestionsPrompt 出題済みの問題と似た問題を避けるようプロンプトに追記する指示
+func avoidQuestionsPrompt(questions []string) string {
+	if len(questions) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\n【出題済みの問題】\n以下の問題と同じ、または似た内容の問題は避けてください。\n")
+	for _, text := range questions {
+		b.WriteString("- ")
+		b.WriteString(text)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"log"
+	"quiz-app/internal/domain"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RoutingPolicy 問題生成に使うプロバイダーの選び方
+type RoutingPolicy string
+
+const (
+	RoutingPolicyPriority      RoutingPolicy = "priority"       // 設定順に試す
+	RoutingPolicyRoundRobin    RoutingPolicy = "round_robin"    // 呼び出しごとに先頭を入れ替える
+	RoutingPolicyLowestLatency RoutingPolicy = "lowest_latency" // 直近の応答が速い順に試す
+)
+
+func IsValidRoutingPolicy(policy RoutingPolicy) bool {
+	switch policy {
+	case RoutingPolicyPriority, RoutingPolicyRoundRobin, RoutingPolicyLowestLatency:
+		return true
+	}
+	return false
+}
+
+// RouterOptions プロバイダールーターの設定
+type RouterOptions struct {
+	Policy           RoutingPolicy
+	MaxRetries       int           // プロバイダーごとの再試行回数（0 なら再試行しない）
+	BaseBackoff      time.Duration // 1回目の再試行までの待ち時間（以降は倍々）
+	MaxBackoff       time.Duration
+	AttemptTimeout   time.Duration // 1回の呼び出しの上限（0 なら context の期限のみ）
+	FailureThreshold int           // サーキットブレーカーを open にする連続失敗回数
+	Cooldown         time.Duration // open から復旧確認までの時間
+	Validator        *QuestionValidator
+}
+
+func DefaultRouterOptions() RouterOptions {
+	return RouterOptions{
+		Policy:           RoutingPolicyPriority,
+		MaxRetries:       2,
+		BaseBackoff:      200 * time.Millisecond,
+		MaxBackoff:       2 * time.Second,
+		AttemptTimeout:   20 * time.Second,
+		FailureThreshold: 3,
+		Cooldown:         30 * time.Second,
+		Validator:        NewQuestionValidator(nil),
+	}
+}
+
+// latencyWeight 応答時間の移動平均で直近の値に置く重み
+const latencyWeight = 0.3
+
+// providerRoute プロバイダーごとのサーキットブレーカーと呼び出し実績
+type providerRoute struct {
+	client AIClient
+
+	mu            sync.Mutex
+	breaker       circuitBreaker
+	requests      int
+	successes     int
+	failures      int
+	rejections    int // 応答はあったが検証で不合格となった回数（サーキットには影響しない）
+	totalLatency  time.Duration
+	recentLatency time.Duration // 移動平均（lowest_latency の並び替えに使う）
+	lastLatency   time.Duration
+	lastError     string
+	lastErrorAt   time.Time
+}
+
+func (p *providerRoute) allow(now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.breaker.Allow(now)
+}
+
+func (p *providerRoute) record(now time.Time, latency time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests++
+	p.totalLatency += latency
+	p.lastLatency = latency
+	if p.recentLatency == 0 {
+		p.recentLatency = latency
+	} else {
+		p.recentLatency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(p.recentLatency))
+	}
+
+	if err != nil {
+		p.failures++
+		p.lastError = err.Error()
+		p.lastErrorAt = now
+		p.breaker.Failure(now)
+		return
+	}
+	p.successes++
+	p.breaker.Success()
+}
+
+// recordRejection 検証で不合格となった応答を記録する
+// プロバイダー自体は応答しているため、サーキットブレーカーの失敗には数えない
+func (p *providerRoute) recordRejection(now time.Time, latency time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests++
+	p.rejections++
+	p.totalLatency += latency
+	p.lastLatency = latency
+	p.lastError = err.Error()
+	p.lastErrorAt = now
+	p.breaker.Release()
+}
+
+func (p *providerRoute) latency() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.recentLatency
+}
+
+func (p *providerRoute) stats(now time.Time) domain.AIProviderStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	state := p.breaker.State(now)
+	stats := domain.AIProviderStats{
+		Name:          p.client.GetName(),
+		Available:     p.client.IsAvailable() && state != CircuitOpen,
+		CircuitState:  string(state),
+		Requests:      p.requests,
+		Successes:     p.successes,
+		Failures:      p.failures,
+		Rejections:    p.rejections,
+		LastLatencyMs: p.lastLatency.Milliseconds(),
+		LastError:     p.lastError,
+	}
+	if p.requests > 0 {
+		stats.SuccessRate = float64(p.successes) / float64(p.requests)
+		stats.AverageLatencyMs = (p.totalLatency / time.Duration(p.requests)).Milliseconds()
+	}
+	if !p.lastErrorAt.IsZero() {
+		lastErrorAt := p.lastErrorAt
+		stats.LastErrorAt = &lastErrorAt
+	}
+	return stats
+}
+
+// ProviderRouter ルーティング方針に従ってプロバイダーを選び、失敗時は再試行・切り替えを行う
+type ProviderRouter struct {
+	routes  []*providerRoute
+	options RouterOptions
+	cursor  uint64 // round_robin の開始位置
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func NewProviderRouter(clients []AIClient, options RouterOptions) *ProviderRouter {
+	defaults := DefaultRouterOptions()
+	if options.Policy == "" {
+		options.Policy = defaults.Policy
+	}
+	if options.FailureThreshold <= 0 {
+		options.FailureThreshold = defaults.FailureThreshold
+	}
+	if options.Cooldown <= 0 {
+		options.Cooldown = defaults.Cooldown
+	}
+	if options.Validator == nil {
+		options.Validator = defaults.Validator
+	}
+	if options.MaxBackoff < options.BaseBackoff {
+		options.MaxBackoff = options.BaseBackoff
+	}
+
+	routes := make([]*providerRoute, len(clients))
+	for i, client := range clients {
+		routes[i] = &providerRoute{
+			client:  client,
+			breaker: newCircuitBreaker(options.FailureThreshold, options.Cooldown),
+		}
+	}
+
+	return &ProviderRouter{
+		routes:  routes,
+		options: options,
+		now:     time.Now,
+		sleep:   sleepContext,
+	}
+}
+
+// Policy 現在のルーティング方針
+func (r *ProviderRouter) Policy() RoutingPolicy {
+	return r.options.Policy
+}
+
+// GenerateQuestion 方針に従った順にプロバイダーを試し、最初に成功した問題と使用したクライアントを返す
+func (r *ProviderRouter) GenerateQuestion(ctx context.Context, request QuestionGenerationRequest) (*domain.Question, AIClient, error) {
+	for _, route := range r.order() {
+		if !route.client.IsAvailable() || !route.allow(r.now()) {
+			continue
+		}
+
+		question, err := r.tryProvider(ctx, route, request)
+		if err == nil {
+			return question, route.client, nil
+		}
+		log.Printf("Failed to generate question with %s: %v", route.client.GetName(), err)
+
+		// 呼び出し元の期限切れ・キャンセル時は他のプロバイダーも試さない
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return nil, nil, domain.ErrAIServiceUnavailable
+}
+
+// tryProvider 1つのプロバイダーを指数バックオフで再試行しながら呼び出す
+// 検証で不合格となった場合は待たずに生成し直す
+func (r *ProviderRouter) tryProvider(ctx context.Context, route *providerRoute, request QuestionGenerationRequest) (*domain.Question, error) {
+	var lastErr error
+	rejected := false
+	for attempt := 0; attempt <= r.options.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if !rejected {
+				if err := r.sleep(ctx, r.backoff(attempt)); err != nil {
+					return nil, err
+				}
+			}
+			// 再試行中にサーキットが開いた場合はこのプロバイダーを諦める
+			if !route.allow(r.now()) {
+				break
+			}
+		}
+
+		attemptCtx, cancel := r.attemptContext(ctx)
+		start := time.Now()
+		question, err := route.client.GenerateQuestion(attemptCtx, request)
+		cancel()
+		latency := time.Since(start)
+
+		if err == nil {
+			// 問題形式は要求した形式とし、形式に関係しない正解の項目を取り除いてから検証する
+			key := question.AnswerKey()
+			key.Type = questionTypeOf(request)
+			question.SetAnswerKey(key)
+
+			err = r.options.Validator.Validate(question)
+			if err == nil {
+				err = r.options.Validator.ValidateTranslations(question, request.TranslationLanguages)
+			}
+			if err != nil {
+				log.Printf("Rejected question from %s: %v", route.client.GetName(), err)
+				route.recordRejection(r.now(), latency, err)
+				lastErr = err
+				rejected = true
+				continue
+			}
+		}
+
+		route.record(r.now(), latency, err)
+		if err == nil {
+			return question, nil
+		}
+		lastErr = err
+		rejected = false
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+	return nil, lastErr
+}
+
+// attemptContext 1回の呼び出しの期限を設定する（呼び出し元の期限の方が早ければそちらが優先される）
+func (r *ProviderRouter) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.options.AttemptTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, r.options.AttemptTimeout)
+}
+
+// backoff attempt 回目の再試行までの待ち時間
+func (r *ProviderRouter) backoff(attempt int) time.Duration {
+	d := r.options.BaseBackoff
+	for i := 1; i < attempt && d < r.options.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > r.options.MaxBackoff {
+		d = r.options.MaxBackoff
+	}
+	return d
+}
+
+// order ルーティング方針に従ってプロバイダーを並べる
+func (r *ProviderRouter) order() []*providerRoute {
+	routes := make([]*providerRoute, len(r.routes))
+	copy(routes, r.routes)
+	if len(routes) == 0 {
+		return routes
+	}
+
+	switch r.options.Policy {
+	case RoutingPolicyRoundRobin:
+		start := int((atomic.AddUint64(&r.cursor, 1) - 1) % uint64(len(routes)))
+		routes = append(routes[start:], routes[:start]...)
+	case RoutingPolicyLowestLatency:
+		// 未計測のプロバイダーは 0 扱いとし、先に試して計測する
+		latencies := make(map[*providerRoute]time.Duration, len(routes))
+		for _, route := range routes {
+			latencies[route] = route.latency()
+		}
+		sort.SliceStable(routes, func(i, j int) bool {
+			return latencies[routes[i]] < latencies[routes[j]]
+		})
+	}
+	return routes
+}
+
+// Stats プロバイダーごとの稼働状況を設定順に返す
+func (r *ProviderRouter) Stats() []domain.AIProviderStats {
+	now := r.now()
+	stats := make([]domain.AIProviderStats, len(r.routes))
+	for i, route := range r.routes {
+		stats[i] = route.stats(now)
+	}
+	return stats
+}
+
+// sleepContext d だけ待つ。期限までに待ち終わらない場合は待たずにエラーを返す
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return context.DeadlineExceeded
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
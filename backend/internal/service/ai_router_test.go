@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quiz-app/internal/domain"
+)
+
+// fakeRouteClient 呼び出し結果を順番に返すテスト用クライアント
+type fakeRouteClient struct {
+	name  string
+	errs  []error // 呼び出しごとの結果（使い切った後は成功）
+	delay time.Duration
+	mu    sync.Mutex
+	calls int
+}
+
+func (f *fakeRouteClient) GenerateQuestion(ctx context.Context, request QuestionGenerationRequest) (*domain.Question, error) {
+	f.mu.Lock()
+	call := f.calls
+	f.calls++
+	f.mu.Unlock()
+
+	if f.delay > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(f.delay):
+		}
+	}
+	if call < len(f.errs) && f.errs[call] != nil {
+		return nil, f.errs[call]
+	}
+	return domain.NewQuestion("", 0, f.name, []string{"A", "B", "C", "D"}, 0, request.Difficulty, request.Category, domain.AIProviderLocal), nil
+}
+
+func (f *fakeRouteClient) IsAvailable() bool { return true }
+
+func (f *fakeRouteClient) GetName() string { return f.name }
+
+func (f *fakeRouteClient) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+var errFake = errors.New("provider failure")
+
+func newTestRouter(clients []AIClient, options RouterOptions) *ProviderRouter {
+	router := NewProviderRouter(clients, options)
+	router.sleep = func(ctx context.Context, d time.Duration) error { return ctx.Err() }
+	return router
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+
+	t.Run("連続失敗で開き、cooldown後に1回だけ復旧確認を通すこと", func(t *testing.T) {
+		breaker := newCircuitBreaker(2, time.Minute)
+		assert.True(t, breaker.Allow(now))
+
+		breaker.Failure(now)
+		assert.Equal(t, CircuitClosed, breaker.State(now))
+		breaker.Failure(now)
+		assert.Equal(t, CircuitOpen, breaker.State(now))
+		assert.False(t, breaker.Allow(now.Add(30*time.Second)))
+
+		later := now.Add(time.Minute)
+		assert.Equal(t, CircuitHalfOpen, breaker.State(later))
+		assert.True(t, breaker.Allow(later))
+		assert.False(t, breaker.Allow(later))
+
+		breaker.Success()
+		assert.Equal(t, CircuitClosed, breaker.State(later))
+		assert.True(t, breaker.Allow(later))
+	})
+
+	t.Run("復旧確認に失敗すると再び開くこと", func(t *testing.T) {
+		breaker := newCircuitBreaker(1, time.Minute)
+		breaker.Failure(now)
+
+		later := now.Add(time.Minute)
+		require.True(t, breaker.Allow(later))
+		breaker.Failure(later)
+		assert.Equal(t, CircuitOpen, breaker.State(later))
+		assert.False(t, breaker.Allow(later.Add(time.Second)))
+	})
+}
+
+func TestProviderRouter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("失敗したプロバイダーは再試行後に次のプロバイダーへ切り替わること", func(t *testing.T) {
+		first := &fakeRouteClient{name: "first", errs: []error{errFake, errFake, errFake}}
+		second := &fakeRouteClient{name: "second"}
+		router := newTestRouter([]AIClient{first, second}, RouterOptions{MaxRetries: 2, FailureThreshold: 5})
+
+		question, client, err := router.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyEasy})
+		require.NoError(t, err)
+		assert.Equal(t, "second", question.Text)
+		assert.Equal(t, "second", client.GetName())
+		assert.Equal(t, 3, first.callCount())
+
+		stats := router.Stats()
+		assert.Equal(t, 3, stats[0].Failures)
+		assert.Equal(t, 0.0, stats[0].SuccessRate)
+		assert.Equal(t, errFake.Error(), stats[0].LastError)
+		assert.NotNil(t, stats[0].LastErrorAt)
+		assert.Equal(t, 1.0, stats[1].SuccessRate)
+	})
+
+	t.Run("再試行で成功した場合は同じプロバイダーを使うこと", func(t *testing.T) {
+		first := &fakeRouteClient{name: "first", errs: []error{errFake}}
+		second := &fakeRouteClient{name: "second"}
+		router := newTestRouter([]AIClient{first, second}, RouterOptions{MaxRetries: 1})
+
+		_, client, err := router.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyEasy})
+		require.NoError(t, err)
+		assert.Equal(t, "first", client.GetName())
+		assert.Equal(t, 0, second.callCount())
+	})
+
+	t.Run("サーキットが開いたプロバイダーは呼び出さないこと", func(t *testing.T) {
+		first := &fakeRouteClient{name: "first", errs: []error{errFake, errFake}}
+		second := &fakeRouteClient{name: "second"}
+		router := newTestRouter([]AIClient{first, second}, RouterOptions{FailureThreshold: 2, Cooldown: time.Hour})
+
+		for i := 0; i < 4; i++ {
+			_, _, err := router.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyEasy})
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, first.callCount())
+		assert.Equal(t, string(CircuitOpen), router.Stats()[0].CircuitState)
+		assert.False(t, router.Stats()[0].Available)
+	})
+
+	t.Run("round_robinでは呼び出しごとに先頭が入れ替わること", func(t *testing.T) {
+		first := &fakeRouteClient{name: "first"}
+		second := &fakeRouteClient{name: "second"}
+		router := newTestRouter([]AIClient{first, second}, RouterOptions{Policy: RoutingPolicyRoundRobin})
+
+		var names []string
+		for i := 0; i < 4; i++ {
+			_, client, err := router.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyEasy})
+			require.NoError(t, err)
+			names = append(names, client.GetName())
+		}
+		assert.Equal(t, []string{"first", "second", "first", "second"}, names)
+	})
+
+	t.Run("lowest_latencyでは応答の速いプロバイダーが優先されること", func(t *testing.T) {
+		slow := &fakeRouteClient{name: "slow"}
+		fast := &fakeRouteClient{name: "fast"}
+		router := newTestRouter([]AIClient{slow, fast}, RouterOptions{Policy: RoutingPolicyLowestLatency})
+		router.routes[0].record(time.Now(), 800*time.Millisecond, nil)
+		router.routes[1].record(time.Now(), 100*time.Millisecond, nil)
+
+		_, client, err := router.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyEasy})
+		require.NoError(t, err)
+		assert.Equal(t, "fast", client.GetName())
+		assert.Equal(t, int64(800), router.Stats()[0].AverageLatencyMs)
+	})
+
+	t.Run("1回の呼び出しはAttemptTimeoutで打ち切られること", func(t *testing.T) {
+		slow := &fakeRouteClient{name: "slow", delay: time.Second}
+		fast := &fakeRouteClient{name: "fast"}
+		router := newTestRouter([]AIClient{slow, fast}, RouterOptions{AttemptTimeout: 20 * time.Millisecond})
+
+		_, client, err := router.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyEasy})
+		require.NoError(t, err)
+		assert.Equal(t, "fast", client.GetName())
+		assert.Equal(t, context.DeadlineExceeded.Error(), router.Stats()[0].LastError)
+	})
+
+	t.Run("呼び出し元の期限切れ後は他のプロバイダーを試さないこと", func(t *testing.T) {
+		slow := &fakeRouteClient{name: "slow", delay: time.Second}
+		fast := &fakeRouteClient{name: "fast"}
+		router := newTestRouter([]AIClient{slow, fast}, RouterOptions{MaxRetries: 2})
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		_, _, err := router.GenerateQuestion(timeoutCtx, QuestionGenerationRequest{Difficulty: domain.DifficultyEasy})
+		assert.ErrorIs(t, err, domain.ErrAIServiceUnavailable)
+		assert.Equal(t, 1, slow.callCount())
+		assert.Equal(t, 0, fast.callCount())
+	})
+
+	t.Run("再試行の待ち時間は倍々に増えて上限で止まること", func(t *testing.T) {
+		router := NewProviderRouter(nil, RouterOptions{BaseBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond})
+		assert.Equal(t, 100*time.Millisecond, router.backoff(1))
+		assert.Equal(t, 200*time.Millisecond, router.backoff(2))
+		assert.Equal(t, 300*time.Millisecond, router.backoff(3))
+	})
+}
@@ -2,17 +2,52 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"quiz-app/internal/domain"
 	"quiz-app/pkg/config"
+	"slices"
+	"sync"
+	"time"
 )
 
+// 問題生成に使うクライアントの選択（config.AIConfig.Provider）
+const (
+	AIProviderAuto  = "auto"
+	AIProviderLocal = "local"
+)
+
+// ErrNoAIClients API キーが1つも設定されていない
+var ErrNoAIClients = errors.New("no AI clients available")
+
 type AIService struct {
 	clients []AIClient
+	router  *ProviderRouter
+
+	// 出題済みの問題との重複判定
+	history             *QuestionHistory
+	duplicateSimilarity float64
+	duplicateRetries    int
+
+	mu      sync.Mutex
 	current AIClient
 }
 
 func NewAIService(cfg *config.Config) (*AIService, error) {
+	switch cfg.AI.Provider {
+	case AIProviderLocal:
+		return NewLocalAIService(cfg)
+	case AIProviderAuto, "":
+	default:
+		return nil, fmt.Errorf("unknown AI provider: %s", cfg.AI.Provider)
+	}
+
+	options, err := routerOptionsFromConfig(cfg.AI)
+	if err != nil {
+		return nil, err
+	}
+
 	var clients []AIClient
 
 	// Gemini クライアント追加
@@ -40,65 +75,172 @@ func NewAIService(cfg *config.Config) (*AIService, error) {
 	}
 
 	if len(clients) == 0 {
-		return nil, fmt.Errorf("no AI clients available")
+		return nil, ErrNoAIClients
+	}
+
+	aiService := newAIService(clients, options)
+	aiService.applyDuplicateConfig(cfg.AI)
+	return aiService, nil
+}
+
+// NewLocalAIService ネットワークを使わないローカル生成のみの AIService を作成する
+// 問題の検証（禁止語）と重複判定は API を使う場合と同じ設定を使う
+func NewLocalAIService(cfg *config.Config) (*AIService, error) {
+	options, err := routerOptionsFromConfig(cfg.AI)
+	if err != nil {
+		return nil, err
 	}
 
+	aiService := newAIService([]AIClient{NewLocalClient(int64(cfg.AI.LocalSeed))}, options)
+	aiService.applyDuplicateConfig(cfg.AI)
+	return aiService, nil
+}
+
+func newAIService(clients []AIClient, options RouterOptions) *AIService {
 	return &AIService{
 		clients: clients,
+		router:  NewProviderRouter(clients, options),
 		current: clients[0], // デフォルトは最初のクライアント
-	}, nil
+
+		history:             NewQuestionHistory(questionHistorySize),
+		duplicateSimilarity: defaultDuplicateSimilarity,
+		duplicateRetries:    2,
+	}
 }
 
-func (s *AIService) GenerateQuestion(ctx context.Context, sessionID string, round int, difficulty domain.Difficulty, category string) (*domain.Question, error) {
-	// 利用可能なクライアントを順番に試す
-	for _, client := range s.clients {
-		if !client.IsAvailable() {
-			continue
+// applyDuplicateConfig 重複判定の設定を反映する（未設定の項目はデフォルト値）
+func (s *AIService) applyDuplicateConfig(cfg config.AIConfig) {
+	if cfg.DuplicateSimilarity > 0 {
+		s.duplicateSimilarity = float64(cfg.DuplicateSimilarity) / 100
+	}
+	if cfg.DuplicateRetries >= 0 {
+		s.duplicateRetries = cfg.DuplicateRetries
+	}
+}
+
+// routerOptionsFromConfig 設定からルーターの設定を作る（未設定の項目はデフォルト値）
+func routerOptionsFromConfig(cfg config.AIConfig) (RouterOptions, error) {
+	options := DefaultRouterOptions()
+	options.Validator = NewQuestionValidator(cfg.BannedWords)
+	if cfg.RoutingPolicy != "" {
+		policy := RoutingPolicy(cfg.RoutingPolicy)
+		if !IsValidRoutingPolicy(policy) {
+			return options, fmt.Errorf("unknown AI routing policy: %s", cfg.RoutingPolicy)
 		}
+		options.Policy = policy
+	}
+	if cfg.MaxRetries >= 0 {
+		options.MaxRetries = cfg.MaxRetries
+	}
+	if cfg.RequestTimeout > 0 {
+		options.AttemptTimeout = time.Duration(cfg.RequestTimeout) * time.Second
+	}
+	if cfg.CircuitFailureThreshold > 0 {
+		options.FailureThreshold = cfg.CircuitFailureThreshold
+	}
+	if cfg.CircuitCooldown > 0 {
+		options.Cooldown = time.Duration(cfg.CircuitCooldown) * time.Second
+	}
+	return options, nil
+}
+
+// GenerateQuestion 出題済みの問題（history と履歴グループの最近の問題）と重複しない問題を生成する
+// 出題済みの問題はプロンプトで避けるよう指示し、それでも近似重複となった場合は再生成する
+func (s *AIService) GenerateQuestion(ctx context.Context, request QuestionGenerationRequest, history []*domain.Question, historyGroup string) (*domain.Question, error) {
+	request.Language, request.TranslationLanguages = normalizeLanguages(request.Language, request.TranslationLanguages)
 
-		question, err := client.GenerateQuestion(ctx, difficulty, category)
-		if err == nil {
-			// 成功したらセッション情報を設定
-			question.SessionID = sessionID
-			question.Round = round
-			s.current = client
-			return question, nil
+	// テンプレートの展開は再生成やプロバイダーの切り替えに関わらず1回だけ行う
+	if request.Prompt == "" {
+		prompt, err := RenderPrompt(request.PromptTemplate, request)
+		if err != nil {
+			return nil, err
 		}
+		request.Prompt = prompt
+	}
+
+	known := append(s.history.Recent(historyGroup), history...)
+	request.AvoidQuestions = recentQuestionTexts(known, avoidPromptSize)
+
+	for attempt := 0; attempt <= s.duplicateRetries; attempt++ {
+		// ルーティング方針に従ってプロバイダーを選び、失敗時は再試行・切り替えを行う
+		question, client, err := s.router.GenerateQuestion(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		if duplicate, similarity := FindDuplicate(question, known, s.duplicateSimilarity); duplicate != nil {
+			log.Printf("Regenerating near-duplicate question from %s (similarity %.2f): %s", client.GetName(), similarity, question.Text)
+			request.AvoidQuestions = append(request.AvoidQuestions, question.Text)
+			continue
+		}
+
+		// 成功したらセッション情報を設定
+		question.SessionID = request.SessionID
+		question.Round = request.Round
+		question.Language = request.Language
+		s.history.Add(historyGroup, question)
+
+		s.mu.Lock()
+		s.current = client
+		s.mu.Unlock()
 
-		// エラーログ（実際の実装では適切なログライブラリを使用）
-		fmt.Printf("Failed to generate question with %s: %v\n", client.GetName(), err)
+		return question, nil
 	}
 
-	return nil, domain.ErrAIServiceUnavailable
+	return nil, domain.ErrDuplicateQuestion
+}
+
+// normalizeLanguages 生成する言語（未対応なら日本語）と、それ以外の対応言語だけを重複なく並べた翻訳先を返す
+func normalizeLanguages(language string, translations []string) (string, []string) {
+	language = domain.NormalizeLanguage(language)
+	if !domain.IsSupportedLanguage(language) {
+		language = domain.DefaultLanguage
+	}
+
+	var targets []string
+	for _, target := range translations {
+		target = domain.NormalizeLanguage(target)
+		if target == language || !domain.IsSupportedLanguage(target) || slices.Contains(targets, target) {
+			continue
+		}
+		targets = append(targets, target)
+	}
+	return language, targets
 }
 
 func (s *AIService) GetCurrentProvider() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.current != nil {
 		return s.current.GetName()
 	}
 	return "Unknown"
 }
 
+// GetAvailableProviders 呼び出し対象になるプロバイダー（サーキットが開いているものを除く）
 func (s *AIService) GetAvailableProviders() []string {
 	var providers []string
-	for _, client := range s.clients {
-		if client.IsAvailable() {
-			providers = append(providers, client.GetName())
+	for _, stats := range s.router.Stats() {
+		if stats.Available {
+			providers = append(providers, stats.Name)
 		}
 	}
 	return providers
 }
 
 func (s *AIService) IsAvailable() bool {
-	for _, client := range s.clients {
-		if client.IsAvailable() {
-			return true
-		}
+	return len(s.GetAvailableProviders()) > 0
+}
+
+// GetProviderHealth ルーティング方針とプロバイダーごとの成功率・応答時間
+func (s *AIService) GetProviderHealth() *domain.AIProviderHealth {
+	return &domain.AIProviderHealth{
+		Policy:    string(s.router.Policy()),
+		Providers: s.router.Stats(),
 	}
-	return false
 }
 
-// カテゴリのリストを返す
+// 既定のカテゴリのリストを返す（セッションでカテゴリを指定しない場合に使う）
 func (s *AIService) GetCategories() []string {
 	return []string{
 		"一般常識",
@@ -120,12 +262,5 @@ func (s *AIService) GetCategories() []string {
 
 // 難易度の分布を返す（ラウンドに応じて難易度を調整）
 func (s *AIService) GetDifficultyForRound(round int) domain.Difficulty {
-	switch {
-	case round <= 3:
-		return domain.DifficultyEasy
-	case round <= 6:
-		return domain.DifficultyMedium
-	default:
-		return domain.DifficultyHard
-	}
+	return domain.RoundDifficulty(round)
 }
\ No newline at end of file
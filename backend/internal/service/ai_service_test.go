@@ -22,7 +22,7 @@ func TestGeminiClient(t *testing.T) {
 		assert.NoError(t, err)
 		ctx := context.Background()
 		
-		question, err := client.GenerateQuestion(ctx, domain.DifficultyMedium, "general")
+		question, err := client.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyMedium, Category: "general"})
 		
 		assert.NoError(t, err)
 		assert.NotNil(t, question)
@@ -40,7 +40,7 @@ func TestGeminiClient(t *testing.T) {
 		assert.NoError(t, err)
 		ctx := context.Background()
 		
-		question, err := client.GenerateQuestion(ctx, domain.DifficultyEasy, "science")
+		question, err := client.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyEasy, Category: "science"})
 		
 		assert.NoError(t, err)
 		assert.NotNil(t, question)
@@ -54,7 +54,7 @@ func TestGeminiClient(t *testing.T) {
 		assert.NoError(t, err)
 		ctx := context.Background()
 		
-		question, err := client.GenerateQuestion(ctx, domain.DifficultyHard, "history")
+		question, err := client.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyHard, Category: "history"})
 		
 		assert.NoError(t, err)
 		assert.NotNil(t, question)
@@ -72,7 +72,7 @@ func TestGeminiClient(t *testing.T) {
 		}
 		ctx := context.Background()
 		
-		question, err := client.GenerateQuestion(ctx, domain.DifficultyMedium, "general")
+		question, err := client.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyMedium, Category: "general"})
 		
 		assert.Error(t, err)
 		assert.Nil(t, question)
@@ -91,7 +91,7 @@ func TestOpenAIClient(t *testing.T) {
 		client := NewOpenAIClient(apiKey)
 		ctx := context.Background()
 		
-		question, err := client.GenerateQuestion(ctx, domain.DifficultyMedium, "general")
+		question, err := client.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyMedium, Category: "general"})
 		
 		assert.NoError(t, err)
 		assert.NotNil(t, question)
@@ -108,7 +108,7 @@ func TestOpenAIClient(t *testing.T) {
 		client := NewOpenAIClient(apiKey)
 		ctx := context.Background()
 		
-		question, err := client.GenerateQuestion(ctx, domain.DifficultyEasy, "math")
+		question, err := client.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyEasy, Category: "math"})
 		
 		assert.NoError(t, err)
 		assert.NotNil(t, question)
@@ -133,7 +133,7 @@ func TestOpenAIClient(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), 1) // 1ナノ秒
 		defer cancel()
 		
-		question, err := client.GenerateQuestion(ctx, domain.DifficultyMedium, "general")
+		question, err := client.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyMedium, Category: "general"})
 		
 		assert.Error(t, err)
 		assert.Nil(t, question)
@@ -152,7 +152,7 @@ func TestClaudeClient(t *testing.T) {
 		client := NewClaudeClient(apiKey)
 		ctx := context.Background()
 		
-		question, err := client.GenerateQuestion(ctx, domain.DifficultyMedium, "general")
+		question, err := client.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyMedium, Category: "general"})
 		
 		assert.NoError(t, err)
 		assert.NotNil(t, question)
@@ -169,7 +169,7 @@ func TestClaudeClient(t *testing.T) {
 		client := NewClaudeClient("invalid-key")
 		ctx := context.Background()
 		
-		question, err := client.GenerateQuestion(ctx, domain.DifficultyMedium, "general")
+		question, err := client.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyMedium, Category: "general"})
 		
 		assert.Error(t, err)
 		assert.Nil(t, question)
@@ -208,7 +208,7 @@ type MockAIClient struct {
 	response   *domain.Question
 }
 
-func (m *MockAIClient) GenerateQuestion(ctx context.Context, difficulty domain.Difficulty, category string) (*domain.Question, error) {
+func (m *MockAIClient) GenerateQuestion(ctx context.Context, request QuestionGenerationRequest) (*domain.Question, error) {
 	if m.shouldFail {
 		return nil, errors.New("mock API failure")
 	}
@@ -222,8 +222,8 @@ func (m *MockAIClient) GenerateQuestion(ctx context.Context, difficulty domain.D
 		Text:          "Mock Question",
 		Options:       []string{"A", "B", "C", "D"},
 		CorrectAnswer: 0,
-		Difficulty:    difficulty,
-		Category:      category,
+		Difficulty:    request.Difficulty,
+		Category:      request.Category,
 	}, nil
 }
 
@@ -232,7 +232,7 @@ func TestMockAIClient(t *testing.T) {
 		client := &MockAIClient{shouldFail: false}
 		ctx := context.Background()
 		
-		question, err := client.GenerateQuestion(ctx, domain.DifficultyMedium, "test")
+		question, err := client.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyMedium, Category: "test"})
 		
 		assert.NoError(t, err)
 		assert.NotNil(t, question)
@@ -245,7 +245,7 @@ func TestMockAIClient(t *testing.T) {
 		client := &MockAIClient{shouldFail: true}
 		ctx := context.Background()
 		
-		question, err := client.GenerateQuestion(ctx, domain.DifficultyMedium, "test")
+		question, err := client.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyMedium, Category: "test"})
 		
 		assert.Error(t, err)
 		assert.Nil(t, question)
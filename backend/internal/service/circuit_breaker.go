@@ -0,0 +1,80 @@
+package service
+
+import "time"
+
+// CircuitState サーキットブレーカーの状態
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // 通常通り呼び出す
+	CircuitOpen     CircuitState = "open"      // 呼び出しを止めている
+	CircuitHalfOpen CircuitState = "half_open" // 復旧確認のため1回だけ呼び出す
+)
+
+// circuitBreaker 連続して失敗したプロバイダーへの呼び出しを一定時間止める
+// 排他制御は呼び出し側で行う
+type circuitBreaker struct {
+	threshold int           // open にする連続失敗回数
+	cooldown  time.Duration // open から half_open に移るまでの時間
+	failures  int
+	state     CircuitState
+	openedAt  time.Time
+	probing   bool // half_open で復旧確認の呼び出し中
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) circuitBreaker {
+	return circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		state:     CircuitClosed,
+	}
+}
+
+// State 現在の状態を返す（cooldown を過ぎた open は half_open とみなす）
+func (b *circuitBreaker) State(now time.Time) CircuitState {
+	if b.state == CircuitOpen && now.Sub(b.openedAt) >= b.cooldown {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// Allow 呼び出してよいか判定する
+// half_open の間は復旧確認の呼び出しを1つだけ通す
+func (b *circuitBreaker) Allow(now time.Time) bool {
+	switch b.State(now) {
+	case CircuitClosed:
+		return true
+	case CircuitHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.state = CircuitHalfOpen
+		b.probing = true
+		return true
+	default:
+		return false
+	}
+}
+
+// Success 呼び出し成功を記録し、閉じた状態に戻す
+func (b *circuitBreaker) Success() {
+	b.failures = 0
+	b.probing = false
+	b.state = CircuitClosed
+}
+
+// Release 成否を判定しない結果のとき、half_open の復旧確認を終えて次の確認を許可する
+func (b *circuitBreaker) Release() {
+	b.probing = false
+}
+
+// Failure 呼び出し失敗を記録する
+// 連続失敗が閾値に達するか、復旧確認に失敗した場合は open にする
+func (b *circuitBreaker) Failure(now time.Time) {
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+		b.state = CircuitOpen
+		b.openedAt = now
+	}
+	b.probing = false
+}
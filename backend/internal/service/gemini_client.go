@@ -48,12 +48,13 @@ func (g *GeminiClient) GetName() string {
 	return "Gemini"
 }
 
-func (g *GeminiClient) GenerateQuestion(ctx context.Context, difficulty domain.Difficulty, category string) (*domain.Question, error) {
+func (g *GeminiClient) GenerateQuestion(ctx context.Context, request QuestionGenerationRequest) (*domain.Question, error) {
 	if !g.IsAvailable() {
 		return nil, domain.ErrAIServiceUnavailable
 	}
 
-	prompt := g.buildPrompt(difficulty, category)
+	// 指示はセッションのテンプレート・テーマから全プロバイダー共通で作成する
+	prompt := questionPrompt(request)
 	
 	resp, err := g.model.GenerateContent(ctx, genai.Text(prompt))
 	if err != nil {
@@ -71,7 +72,7 @@ func (g *GeminiClient) GenerateQuestion(ctx context.Context, difficulty domain.D
 		}
 	}
 
-	question, err := g.parseResponse(responseText, difficulty, category)
+	question, err := g.parseResponse(responseText, request.Difficulty, request.Category)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
@@ -79,41 +80,6 @@ func (g *GeminiClient) GenerateQuestion(ctx context.Context, difficulty domain.D
 	return question, nil
 }
 
-func (g *GeminiClient) buildPrompt(difficulty domain.Difficulty, category string) string {
-	difficultyDesc := map[domain.Difficulty]string{
-		domain.DifficultyEasy:   "初級レベル（一般常識）",
-		domain.DifficultyMedium: "中級レベル（少し考える必要がある）",
-		domain.DifficultyHard:   "上級レベル（専門知識が必要）",
-	}
-
-	categoryDesc := category
-	if category == "" {
-		categoryDesc = "一般常識・雑学"
-	}
-
-	return fmt.Sprintf(`忘年会で使用するクイズ問題を1問作成してください。
-
-【条件】
-- 難易度: %s
-- カテゴリ: %s
-- 4択問題
-- 日本語で作成
-- 楽しく盛り上がる内容
-- 不適切な内容は避ける
-
-【出力形式】
-以下のJSON形式で回答してください。余計な説明は不要です。
-
-{
-  "text": "問題文",
-  "options": ["選択肢1", "選択肢2", "選択肢3", "選択肢4"],
-  "correctAnswer": 0,
-  "explanation": "解説（省略可）"
-}
-
-※correctAnswerは正解の選択肢のインデックス（0-3）`, difficultyDesc[difficulty], categoryDesc)
-}
-
 func (g *GeminiClient) parseResponse(responseText string, difficulty domain.Difficulty, category string) (*domain.Question, error) {
 	// JSONブロックを抽出
 	jsonStart := strings.Index(responseText, "{")
@@ -130,16 +96,7 @@ func (g *GeminiClient) parseResponse(responseText string, difficulty domain.Diff
 		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
 	}
 
-	// バリデーション
-	if response.Text == "" {
-		return nil, fmt.Errorf("question text is empty")
-	}
-	if len(response.Options) != 4 {
-		return nil, fmt.Errorf("question must have exactly 4 options")
-	}
-	if response.CorrectAnswer < 0 || response.CorrectAnswer >= len(response.Options) {
-		return nil, fmt.Errorf("invalid correct answer index")
-	}
+	// 内容の検証・整形は ProviderRouter の QuestionValidator で共通に行う
 
 	if category == "" {
 		category = "一般"
@@ -155,6 +112,9 @@ func (g *GeminiClient) parseResponse(responseText string, difficulty domain.Diff
 		category,
 		domain.AIProviderGemini,
 	)
+	question.Explanation = strings.TrimSpace(response.Explanation)
+	question.Translations = response.Translations
+	response.applyAnswerKey(question)
 
 	return question, nil
 }
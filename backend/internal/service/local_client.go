@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"quiz-app/internal/domain"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// localOptionCount ローカル生成する問題の選択肢数
+const localOptionCount = 4
+
+// LocalClient ネットワークを使わずにテンプレートから問題を生成するクライアント
+// 同じシードからは同じ順序で同じ問題を生成するため、オフライン環境や CI で再現性のある進行に使う
+type LocalClient struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func NewLocalClient(seed int64) *LocalClient {
+	return &LocalClient{
+		rng: rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (l *LocalClient) IsAvailable() bool {
+	return true
+}
+
+func (l *LocalClient) GetName() string {
+	return "Local"
+}
+
+// 出題済みの問題は参照しない（同じ問題が出た場合は呼び出し側で再生成する）
+func (l *LocalClient) GenerateQuestion(ctx context.Context, request QuestionGenerationRequest) (*domain.Question, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// 乱数列を共有するため、生成順が同じなら同じ問題になる
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	difficulty, category := request.Difficulty, request.Category
+	generated := l.build(questionTypeOf(request), difficulty)
+	text, options, explanation := generated.render(localPhrasesFor(request.Language))
+
+	if category == "" {
+		category = "一般"
+	}
+
+	question := domain.NewQuestion(
+		"", // SessionID will be set by usecase
+		0,  // Round will be set by usecase
+		text,
+		options,
+		generated.key.CorrectAnswer,
+		difficulty,
+		category,
+		domain.AIProviderLocal,
+	)
+	question.SetAnswerKey(generated.key)
+	question.Explanation = explanation
+
+	// 翻訳は選択肢（数値・式）を共有し、問題文・解説・○×の選択肢だけを各言語の定型文で作る
+	for _, language := range request.TranslationLanguages {
+		text, options, explanation := generated.render(localPhrasesFor(language))
+		if question.Translations == nil {
+			question.Translations = make(map[string]domain.QuestionTranslation)
+		}
+		question.Translations[language] = domain.QuestionTranslation{
+			Text:        text,
+			Options:     options,
+			Explanation: explanation,
+		}
+	}
+
+	return question, nil
+}
+
+// localPhrases ローカル生成する問題文・解説の言語ごとの定型文
+type localPhrases struct {
+	question    string // 式を埋め込む問題文
+	explanation string // 計算過程を埋め込む解説
+	separator   string // 計算過程の区切り
+
+	trueFalse   string // 式と答えを埋め込む○×問題の文
+	trueLabel   string
+	falseLabel  string
+	multiSelect string // 基準値を埋め込む複数選択問題の文
+	ordering    string // 並べ替え問題の文
+}
+
+var localPhrasesByLanguage = map[string]localPhrases{
+	"ja": {
+		question: "%s はいくつ？", explanation: "%s です。", separator: "、",
+		trueFalse: "%s = %d である。", trueLabel: "正しい", falseLabel: "誤り",
+		multiSelect: "答えが %d より大きい式をすべて選んでください。", ordering: "式を答えが小さい順に並べてください。",
+	},
+	"en": {
+		question: "What is %s?", explanation: "%s.", separator: ", ",
+		trueFalse: "%s = %d.", trueLabel: "True", falseLabel: "False",
+		multiSelect: "Select all expressions whose value is greater than %d.", ordering: "Order the expressions from smallest to largest value.",
+	},
+	"zh": {
+		question: "%s 等于多少？", explanation: "%s。", separator: "，",
+		trueFalse: "%s = %d。", trueLabel: "正确", falseLabel: "错误",
+		multiSelect: "请选出所有结果大于 %d 的算式。", ordering: "请将算式按结果从小到大排列。",
+	},
+	"ko": {
+		question: "%s 는 얼마일까요?", explanation: "%s 입니다.", separator: ", ",
+		trueFalse: "%s = %d 이다.", trueLabel: "맞다", falseLabel: "틀리다",
+		multiSelect: "값이 %d 보다 큰 식을 모두 고르세요.", ordering: "식을 값이 작은 순서대로 나열하세요.",
+	},
+}
+
+// localPhrasesFor 言語の定型文（用意のない言語は英語、未指定は日本語）
+func localPhrasesFor(language string) localPhrases {
+	language = domain.NormalizeLanguage(language)
+	if language == "" {
+		language = domain.DefaultLanguage
+	}
+	if phrases, ok := localPhrasesByLanguage[language]; ok {
+		return phrases
+	}
+	return localPhrasesByLanguage["en"]
+}
+
+// localQuestion 言語に依存しない生成結果（問題文・解説は言語ごとに render で作る）
+type localQuestion struct {
+	key        domain.AnswerKey
+	expression string   // 単一選択・○×・数値問題の式
+	shown      int      // ○×問題で提示する答え
+	threshold  int      // 複数選択問題の基準値
+	options    []string // 単一選択・複数選択・並べ替え問題の選択肢
+	steps      []string // 解説に使う計算過程
+}
+
+// render 問題文・選択肢・解説を作る
+func (q localQuestion) render(p localPhrases) (string, []string, string) {
+	explanation := fmt.Sprintf(p.explanation, strings.Join(q.steps, p.separator))
+	options := append([]string(nil), q.options...)
+
+	switch q.key.Type {
+	case domain.QuestionTypeTrueFalse:
+		return fmt.Sprintf(p.trueFalse, q.expression, q.shown), []string{p.trueLabel, p.falseLabel}, explanation
+	case domain.QuestionTypeMultiSelect:
+		return fmt.Sprintf(p.multiSelect, q.threshold), options, explanation
+	case domain.QuestionTypeOrdering:
+		return p.ordering, options, explanation
+	default:
+		return fmt.Sprintf(p.question, q.expression), options, explanation
+	}
+}
+
+// build 問題形式に応じた計算問題を作る
+func (l *LocalClient) build(kind domain.QuestionType, difficulty domain.Difficulty) localQuestion {
+	switch kind {
+	case domain.QuestionTypeTrueFalse:
+		expression, answer, steps := l.buildArithmetic(difficulty)
+		q := localQuestion{key: domain.AnswerKey{Type: kind}, expression: expression, shown: answer, steps: steps}
+		// 半分の確率で誤った答えを提示する
+		if l.rng.Intn(2) == 1 {
+			offset := l.between(1, 10)
+			if l.rng.Intn(2) == 1 {
+				offset = -offset
+			}
+			q.shown += offset
+			q.key.CorrectAnswer = 1
+		}
+		return q
+
+	case domain.QuestionTypeNumeric:
+		expression, answer, steps := l.buildArithmetic(difficulty)
+		return localQuestion{
+			key:        domain.AnswerKey{Type: kind, NumericAnswer: float64(answer)},
+			expression: expression,
+			steps:      steps,
+		}
+
+	case domain.QuestionTypeMultiSelect:
+		options, values, steps := l.buildExpressions(difficulty)
+		sorted := slices.Sorted(slices.Values(values))
+		// 最大値以外を基準値にすると、正解は1〜3個になる
+		q := localQuestion{key: domain.AnswerKey{Type: kind}, threshold: sorted[l.rng.Intn(len(sorted)-1)], options: options, steps: steps}
+		for i, value := range values {
+			if value > q.threshold {
+				q.key.CorrectAnswers = append(q.key.CorrectAnswers, i)
+			}
+		}
+		return q
+
+	case domain.QuestionTypeOrdering:
+		options, values, steps := l.buildExpressions(difficulty)
+		order := make([]int, len(values))
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortFunc(order, func(a, b int) int { return values[a] - values[b] })
+		return localQuestion{key: domain.AnswerKey{Type: kind, CorrectAnswers: order}, options: options, steps: steps}
+
+	default:
+		expression, answer, steps := l.buildArithmetic(difficulty)
+		options, correctAnswer := l.buildOptions(answer)
+		return localQuestion{
+			key:        domain.AnswerKey{Type: domain.QuestionTypeChoice, CorrectAnswer: correctAnswer},
+			expression: expression,
+			options:    options,
+			steps:      steps,
+		}
+	}
+}
+
+// buildExpressions 答えが重複しない式を選択肢の数だけ作る（答えの小さい順には並ばないようにする）
+func (l *LocalClient) buildExpressions(difficulty domain.Difficulty) ([]string, []int, []string) {
+	var expressions, steps []string
+	var values []int
+	for len(expressions) < localOptionCount {
+		expression, answer, _ := l.buildArithmetic(difficulty)
+		if slices.Contains(values, answer) {
+			continue
+		}
+		expressions = append(expressions, expression)
+		values = append(values, answer)
+	}
+	if slices.IsSorted(values) {
+		expressions[0], expressions[1] = expressions[1], expressions[0]
+		values[0], values[1] = values[1], values[0]
+	}
+
+	for i, expression := range expressions {
+		steps = append(steps, fmt.Sprintf("%s = %d", expression, values[i]))
+	}
+	return expressions, values, steps
+}
+
+// buildArithmetic 難易度に応じた計算問題の式・答え・計算過程を作る
+func (l *LocalClient) buildArithmetic(difficulty domain.Difficulty) (string, int, []string) {
+	switch difficulty {
+	case domain.DifficultyHard:
+		a, b, c := l.between(11, 30), l.between(3, 9), l.between(1, 50)
+		answer := a*b - c
+		return fmt.Sprintf("%d × %d − %d", a, b, c), answer, []string{
+			fmt.Sprintf("%d × %d = %d", a, b, a*b),
+			fmt.Sprintf("%d − %d = %d", a*b, c, answer),
+		}
+	case domain.DifficultyMedium:
+		a, b := l.between(3, 12), l.between(3, 12)
+		answer := a * b
+		return fmt.Sprintf("%d × %d", a, b), answer, []string{fmt.Sprintf("%d × %d = %d", a, b, answer)}
+	default:
+		a, b := l.between(1, 20), l.between(1, 20)
+		answer := a + b
+		return fmt.Sprintf("%d + %d", a, b), answer, []string{fmt.Sprintf("%d + %d = %d", a, b, answer)}
+	}
+}
+
+// buildOptions 正解の近くの値を誤答として混ぜ、並び順をシャッフルする
+func (l *LocalClient) buildOptions(answer int) ([]string, int) {
+	values := []int{answer}
+	seen := map[int]bool{answer: true}
+	for len(values) < localOptionCount {
+		candidate := answer + l.between(-10, 10)
+		if seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+		values = append(values, candidate)
+	}
+
+	l.rng.Shuffle(len(values), func(i, j int) {
+		values[i], values[j] = values[j], values[i]
+	})
+
+	options := make([]string, len(values))
+	correctAnswer := 0
+	for i, value := range values {
+		options[i] = fmt.Sprintf("%d", value)
+		if value == answer {
+			correctAnswer = i
+		}
+	}
+	return options, correctAnswer
+}
+
+// between min 以上 max 以下の整数を返す
+func (l *LocalClient) between(min, max int) int {
+	return min + l.rng.Intn(max-min+1)
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quiz-app/internal/domain"
+	"quiz-app/pkg/config"
+)
+
+func TestLocalClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("同じシードからは同じ順序で同じ問題が生成されること", func(t *testing.T) {
+		first := NewLocalClient(42)
+		second := NewLocalClient(42)
+
+		for _, difficulty := range []domain.Difficulty{domain.DifficultyEasy, domain.DifficultyMedium, domain.DifficultyHard} {
+			q1, err := first.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: difficulty})
+			require.NoError(t, err)
+			q2, err := second.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: difficulty})
+			require.NoError(t, err)
+
+			assert.Equal(t, q1.Text, q2.Text)
+			assert.Equal(t, q1.Options, q2.Options)
+			assert.Equal(t, q1.CorrectAnswer, q2.CorrectAnswer)
+			assert.Equal(t, q1.Explanation, q2.Explanation)
+		}
+	})
+
+	t.Run("選択肢が重複しない4択問題が生成されること", func(t *testing.T) {
+		client := NewLocalClient(1)
+		for i := 0; i < 50; i++ {
+			question, err := client.GenerateQuestion(ctx, QuestionGenerationRequest{Difficulty: domain.DifficultyHard, Category: "計算"})
+			require.NoError(t, err)
+
+			require.Len(t, question.Options, 4)
+			seen := map[string]bool{}
+			for _, option := range question.Options {
+				assert.False(t, seen[option], "duplicate option %s", option)
+				seen[option] = true
+			}
+			assert.GreaterOrEqual(t, question.CorrectAnswer, 0)
+			assert.Less(t, question.CorrectAnswer, 4)
+			assert.Equal(t, "計算", question.Category)
+			assert.Equal(t, domain.AIProviderLocal, question.AIProvider)
+			assert.NotEmpty(t, question.Explanation)
+		}
+	})
+
+	t.Run("指定した言語で生成し、同じ選択肢で翻訳を作成すること", func(t *testing.T) {
+		client := NewLocalClient(3)
+		question, err := client.GenerateQuestion(ctx, QuestionGenerationRequest{
+			Difficulty:           domain.DifficultyHard,
+			Language:             "en",
+			TranslationLanguages: []string{"ja", "fr"},
+		})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(question.Text, "What is "))
+
+		require.Len(t, question.Translations, 2)
+		ja := question.Translations["ja"]
+		assert.True(t, strings.HasSuffix(ja.Text, " はいくつ？"))
+		assert.True(t, strings.HasSuffix(ja.Explanation, " です。"))
+		assert.Equal(t, question.Options, ja.Options)
+		// 定型文のない言語は英語で作成する
+		assert.Equal(t, question.Text, question.Translations["fr"].Text)
+	})
+}
+
+func TestLocalClientQuestionTypes(t *testing.T) {
+	ctx := context.Background()
+	validator := NewQuestionValidator(nil)
+
+	for _, questionType := range domain.QuestionTypes {
+		t.Run(string(questionType)+"の問題が検証を通る形で生成されること", func(t *testing.T) {
+			client := NewLocalClient(5)
+			for i := 0; i < 30; i++ {
+				question, err := client.GenerateQuestion(ctx, QuestionGenerationRequest{
+					Difficulty:           domain.DifficultyMedium,
+					QuestionType:         questionType,
+					TranslationLanguages: []string{"en"},
+				})
+				require.NoError(t, err)
+				assert.Equal(t, questionType, question.Kind())
+				require.NoError(t, validator.Validate(question))
+				require.NoError(t, validator.ValidateTranslations(question, []string{"en"}))
+				assert.Len(t, question.Translations["en"].Options, len(question.Options))
+			}
+		})
+	}
+
+	t.Run("○×問題の選択肢は言語ごとの定型文になること", func(t *testing.T) {
+		question, err := NewLocalClient(1).GenerateQuestion(ctx, QuestionGenerationRequest{
+			Difficulty:           domain.DifficultyEasy,
+			QuestionType:         domain.QuestionTypeTrueFalse,
+			TranslationLanguages: []string{"en"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"正しい", "誤り"}, question.Options)
+		assert.Equal(t, []string{"True", "False"}, question.Translations["en"].Options)
+	})
+}
+
+func TestNewAIServiceProvider(t *testing.T) {
+	t.Run("localを指定するとAPIキーなしで問題を生成できること", func(t *testing.T) {
+		aiService, err := NewAIService(&config.Config{AI: config.AIConfig{Provider: AIProviderLocal, LocalSeed: 7}})
+		require.NoError(t, err)
+
+		question, err := aiService.GenerateQuestion(context.Background(), QuestionGenerationRequest{SessionID: "session1", Round: 3, Difficulty: domain.DifficultyEasy}, nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, "session1", question.SessionID)
+		assert.Equal(t, 3, question.Round)
+		assert.Equal(t, "Local", aiService.GetCurrentProvider())
+	})
+
+	t.Run("APIキーが未設定の場合はErrNoAIClientsが返ること", func(t *testing.T) {
+		_, err := NewAIService(&config.Config{AI: config.AIConfig{Provider: AIProviderAuto}})
+		assert.ErrorIs(t, err, ErrNoAIClients)
+	})
+
+	t.Run("ローカル生成でも禁止語・重複判定・ルーティングの設定を使うこと", func(t *testing.T) {
+		aiService, err := NewLocalAIService(&config.Config{AI: config.AIConfig{LocalSeed: 7, BannedWords: []string{"禁止"}, DuplicateRetries: 5, MaxRetries: -1}})
+		require.NoError(t, err)
+		require.NotNil(t, aiService.router.options.Validator)
+		assert.Error(t, aiService.router.options.Validator.Validate(&domain.Question{Text: "禁止された問題", Options: []string{"A", "B", "C", "D"}}))
+		assert.Equal(t, 5, aiService.duplicateRetries)
+
+		_, err = NewLocalAIService(&config.Config{AI: config.AIConfig{RoutingPolicy: "unknown"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("未知のプロバイダーはエラーとなること", func(t *testing.T) {
+		_, err := NewAIService(&config.Config{AI: config.AIConfig{Provider: "unknown"}})
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrNoAIClients)
+	})
+}
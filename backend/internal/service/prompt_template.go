@@ -0,0 +1,236 @@
+package service
+
+import (
+	"fmt"
+	"quiz-app/internal/domain"
+	"strings"
+	"text/template"
+)
+
+// 問題生成の指示の既定値
+const (
+	defaultAudience = "忘年会の参加者"
+	defaultCategory = "一般常識・雑学"
+)
+
+// DefaultPromptTemplate テンプレート未指定のセッションで使う問題生成の指示
+// 出力形式（JSON）の指定はテンプレートに関わらず問題形式ごとの outputFormats を付け加える
+const DefaultPromptTemplate = `{{.Audience}}向けのクイズ問題を1問作成してください。
+{{- if .Theme}}
+テーマは「{{.Theme}}」です。
+{{- end}}
+
+【条件】
+- 難易度: {{.DifficultyLabel}}
+- カテゴリ: {{.Category}}
+- 形式: {{.QuestionTypeLabel}}
+- {{.LanguageName}}で作成
+- 楽しく盛り上がる内容
+- 不適切な内容は避ける
+{{- if .ReferenceText}}
+
+【参考資料】
+以下の資料に書かれている内容から出題してください。
+{{.ReferenceText}}
+{{- end}}`
+
+// outputFormatHeader 出力形式の指定の共通部分
+const outputFormatHeader = `
+
+【出力形式】
+以下のJSON形式で回答してください。余計な説明は不要です。
+`
+
+// outputFormats クライアントが応答を解析するための問題形式ごとの出力形式の指定
+var outputFormats = map[domain.QuestionType]string{
+	domain.QuestionTypeChoice: outputFormatHeader + `
+{
+  "text": "問題文",
+  "options": ["選択肢1", "選択肢2", "選択肢3", "選択肢4"],
+  "correctAnswer": 0,
+  "explanation": "解説（省略可）"
+}
+
+※correctAnswerは正解の選択肢のインデックス（0-3）`,
+
+	domain.QuestionTypeTrueFalse: outputFormatHeader + `
+{
+  "text": "正誤を判定する文",
+  "options": ["正しい", "誤り"],
+  "correctAnswer": 0,
+  "explanation": "解説（省略可）"
+}
+
+※optionsは「正しい」「誤り」をこの順で問題の言語に合わせて記載し、correctAnswerは文が正しければ0、誤りなら1`,
+
+	domain.QuestionTypeMultiSelect: outputFormatHeader + `
+{
+  "text": "問題文（「すべて選んでください」など複数選ぶことがわかる文）",
+  "options": ["選択肢1", "選択肢2", "選択肢3", "選択肢4"],
+  "correctAnswers": [0, 2],
+  "explanation": "解説（省略可）"
+}
+
+※correctAnswersは正解の選択肢のインデックス（0-3）の配列で、正解は1〜3個`,
+
+	domain.QuestionTypeNumeric: outputFormatHeader + `
+{
+  "text": "答えが数値になる問題文",
+  "numericAnswer": 3776,
+  "unit": "m",
+  "explanation": "解説（省略可）"
+}
+
+※numericAnswerは正解の数値、unitは単位（なければ空文字）。最も近い数値を答えた参加者が正解となる`,
+
+	domain.QuestionTypeOrdering: outputFormatHeader + `
+{
+  "text": "並べ替えの基準がわかる問題文（例: 古い順に並べてください）",
+  "options": ["項目1", "項目2", "項目3", "項目4"],
+  "correctAnswers": [2, 0, 3, 1],
+  "explanation": "解説（省略可）"
+}
+
+※optionsは正しい順序とは異なる順で記載し、correctAnswersは正しい順に並べた選択肢のインデックス（0-3）`,
+}
+
+// questionTypeLabels プロンプトで使う問題形式の説明
+var questionTypeLabels = map[domain.QuestionType]string{
+	domain.QuestionTypeChoice:      "4択問題（正解は1つ）",
+	domain.QuestionTypeTrueFalse:   "○×問題（文の正誤を答える）",
+	domain.QuestionTypeMultiSelect: "複数選択問題（4つの選択肢から正解をすべて選ぶ）",
+	domain.QuestionTypeNumeric:     "数値で答える問題（最も近い数値を答えた人が正解）",
+	domain.QuestionTypeOrdering:    "並べ替え問題（4つの項目を正しい順に並べる）",
+}
+
+// PromptData プロンプトテンプレートに渡す変数
+type PromptData struct {
+	Difficulty        domain.Difficulty // easy / medium / hard
+	DifficultyLabel   string            // 「初級レベル（一般常識）」など
+	Category          string
+	Language          string // 言語コード（"ja" など）
+	LanguageName      string // 「日本語」など
+	Audience          string
+	Theme             string
+	ReferenceText     string
+	QuestionType      domain.QuestionType // choice / true_false など
+	QuestionTypeLabel string              // 「4択問題（正解は1つ）」など
+}
+
+// PromptVariables テンプレートで使用できる変数とその説明（管理画面での編集用）
+var PromptVariables = map[string]string{
+	"Difficulty":        "難易度（easy / medium / hard）",
+	"DifficultyLabel":   "難易度の説明（初級レベル（一般常識）など）",
+	"Category":          "カテゴリ",
+	"Language":          "言語コード（ja など）",
+	"LanguageName":      "言語名（日本語など）",
+	"Audience":          "想定する参加者",
+	"Theme":             "セッションのテーマ（未設定の場合は空）",
+	"ReferenceText":     "テーマの参考資料（未設定の場合は空）",
+	"QuestionType":      "問題形式（choice / true_false / multi_select / numeric / ordering）",
+	"QuestionTypeLabel": "問題形式の説明（4択問題（正解は1つ）など）",
+}
+
+var difficultyLabels = map[domain.Difficulty]string{
+	domain.DifficultyEasy:   "初級レベル（一般常識）",
+	domain.DifficultyMedium: "中級レベル（少し考える必要がある）",
+	domain.DifficultyHard:   "上級レベル（専門知識が必要）",
+}
+
+// promptDataFor 生成リクエストからテンプレートの変数を作る（未指定の項目は既定値）
+func promptDataFor(request QuestionGenerationRequest) PromptData {
+	data := PromptData{
+		Difficulty:      request.Difficulty,
+		DifficultyLabel: difficultyLabels[request.Difficulty],
+		Category:        request.Category,
+		Language:        domain.NormalizeLanguage(request.Language),
+		Audience:        request.Audience,
+		Theme:           request.Theme,
+		ReferenceText:   request.ReferenceText,
+		QuestionType:    questionTypeOf(request),
+	}
+	data.QuestionTypeLabel = questionTypeLabels[data.QuestionType]
+	if data.Category == "" {
+		data.Category = defaultCategory
+	}
+	if !domain.IsSupportedLanguage(data.Language) {
+		data.Language = domain.DefaultLanguage
+	}
+	data.LanguageName = domain.LanguageName(data.Language)
+	if data.Audience == "" {
+		data.Audience = defaultAudience
+	}
+	return data
+}
+
+// RenderPrompt テンプレートを展開して問題生成の指示を作る（body が空なら既定のテンプレート）
+func RenderPrompt(body string, request QuestionGenerationRequest) (string, error) {
+	if strings.TrimSpace(body) == "" {
+		body = DefaultPromptTemplate
+	}
+
+	tmpl, err := template.New("prompt").Option("missingkey=error").Parse(body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", domain.ErrInvalidPromptTemplate, err)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, promptDataFor(request)); err != nil {
+		return "", fmt.Errorf("%w: %v", domain.ErrInvalidPromptTemplate, err)
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// ValidatePromptTemplate テンプレートの構文と、存在しない変数を参照していないかを検証する
+func ValidatePromptTemplate(body string) error {
+	_, err := RenderPrompt(body, QuestionGenerationRequest{
+		Difficulty:    domain.DifficultyMedium,
+		Theme:         "テーマ",
+		ReferenceText: "参考資料",
+	})
+	return err
+}
+
+// PreviewPrompt クライアントに送られるプロンプト全体を返す（管理画面での確認用）
+func PreviewPrompt(body string, request QuestionGenerationRequest) (string, error) {
+	prompt, err := RenderPrompt(body, request)
+	if err != nil {
+		return "", err
+	}
+	request.Prompt = prompt
+	return questionPrompt(request), nil
+}
+
+// questionPrompt クライアントに送るプロンプト（指示・出力形式・翻訳・出題済みの問題）
+func questionPrompt(request QuestionGenerationRequest) string {
+	instructions := request.Prompt
+	if instructions == "" {
+		// 既定のテンプレートは展開に失敗しない
+		instructions, _ = RenderPrompt("", request)
+	}
+	return instructions + outputFormats[questionTypeOf(request)] + translationPrompt(request.TranslationLanguages) +
+		avoidQuestionsPrompt(request.AvoidQuestions)
+}
+
+// questionTypeOf 生成する問題形式（未指定・未定義の形式は単一選択）
+func questionTypeOf(request QuestionGenerationRequest) domain.QuestionType {
+	if !domain.IsValidQuestionType(request.QuestionType) {
+		return domain.QuestionTypeChoice
+	}
+	return request.QuestionType
+}
+
+// translationPrompt 問題と同時に翻訳を作成するよう出力形式に追記する指示
+func translationPrompt(languages []string) string {
+	if len(languages) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\n【翻訳】\n作成した問題を以下の言語にも翻訳し、JSONの \"translations\" に言語コードをキーとして含めてください（選択肢のない問題は options を空の配列にしてください）。\n")
+	for _, language := range languages {
+		fmt.Fprintf(&b, "- %s（%s）\n", language, domain.LanguageName(language))
+	}
+	fmt.Fprintf(&b, "選択肢は元の問題と同じ順序で翻訳してください。\n\n\"translations\": {\"%s\": {\"text\": \"問題文\", \"options\": [\"選択肢1\", \"選択肢2\", \"選択肢3\", \"選択肢4\"], \"explanation\": \"解説\"}}", languages[0])
+	return b.String()
+}
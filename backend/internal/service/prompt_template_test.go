@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quiz-app/internal/domain"
+)
+
+func TestRenderPrompt(t *testing.T) {
+	t.Run("既定のテンプレートは未指定の項目を既定値で展開すること", func(t *testing.T) {
+		prompt, err := RenderPrompt("", QuestionGenerationRequest{Difficulty: domain.DifficultyEasy})
+		require.NoError(t, err)
+		assert.Contains(t, prompt, "忘年会の参加者向け")
+		assert.Contains(t, prompt, "初級レベル（一般常識）")
+		assert.Contains(t, prompt, "カテゴリ: 一般常識・雑学")
+		assert.Contains(t, prompt, "日本語で作成")
+		assert.NotContains(t, prompt, "テーマ")
+		assert.NotContains(t, prompt, "【参考資料】")
+	})
+
+	t.Run("テーマと参考資料を指定するとプロンプトに含まれること", func(t *testing.T) {
+		prompt, err := RenderPrompt("", QuestionGenerationRequest{
+			Difficulty:    domain.DifficultyHard,
+			Category:      "社史",
+			Audience:      "新入社員",
+			Theme:         "会社の歴史",
+			ReferenceText: "1998年に創業。",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, prompt, "新入社員向け")
+		assert.Contains(t, prompt, "テーマは「会社の歴史」です。")
+		assert.Contains(t, prompt, "カテゴリ: 社史")
+		assert.Contains(t, prompt, "【参考資料】")
+		assert.Contains(t, prompt, "1998年に創業。")
+	})
+
+	t.Run("独自のテンプレートで変数を展開できること", func(t *testing.T) {
+		prompt, err := RenderPrompt("{{.Theme}}について{{.LanguageName}}で{{.Difficulty}}の問題を作成", QuestionGenerationRequest{
+			Difficulty: domain.DifficultyMedium,
+			Language:   "en",
+			Theme:      "宇宙",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "宇宙について英語でmediumの問題を作成", prompt)
+	})
+
+	t.Run("構文エラーや存在しない変数はErrInvalidPromptTemplateになること", func(t *testing.T) {
+		assert.ErrorIs(t, ValidatePromptTemplate("{{.Theme"), domain.ErrInvalidPromptTemplate)
+		assert.ErrorIs(t, ValidatePromptTemplate("{{.Unknown}}"), domain.ErrInvalidPromptTemplate)
+		assert.NoError(t, ValidatePromptTemplate(DefaultPromptTemplate))
+	})
+
+	t.Run("クライアントに送るプロンプトには出力形式と出題済みの問題が加わること", func(t *testing.T) {
+		prompt, err := PreviewPrompt("{{.Category}}の問題", QuestionGenerationRequest{
+			Category:       "歴史",
+			AvoidQuestions: []string{"日本で一番高い山は？"},
+		})
+		require.NoError(t, err)
+		assert.Contains(t, prompt, "歴史の問題\n\n【出力形式】")
+		assert.Contains(t, prompt, `"correctAnswer": 0`)
+		assert.Contains(t, prompt, "- 日本で一番高い山は？")
+	})
+}
+
+func TestQuestionTypePrompt(t *testing.T) {
+	t.Run("問題形式に応じた説明と出力形式が付くこと", func(t *testing.T) {
+		prompt := questionPrompt(QuestionGenerationRequest{Difficulty: domain.DifficultyEasy, QuestionType: domain.QuestionTypeNumeric})
+		assert.Contains(t, prompt, "- 形式: 数値で答える問題")
+		assert.Contains(t, prompt, `"numericAnswer": 3776`)
+		assert.NotContains(t, prompt, `"options"`)
+
+		prompt = questionPrompt(QuestionGenerationRequest{Difficulty: domain.DifficultyEasy, QuestionType: domain.QuestionTypeOrdering})
+		assert.Contains(t, prompt, `"correctAnswers": [2, 0, 3, 1]`)
+	})
+
+	t.Run("未指定・未定義の問題形式は4択問題になること", func(t *testing.T) {
+		for _, questionType := range []domain.QuestionType{"", "essay"} {
+			prompt := questionPrompt(QuestionGenerationRequest{Difficulty: domain.DifficultyEasy, QuestionType: questionType})
+			assert.Contains(t, prompt, "- 形式: 4択問題")
+			assert.Contains(t, prompt, `"correctAnswer": 0`)
+		}
+	})
+}
+
+func TestTranslationPrompt(t *testing.T) {
+	t.Run("翻訳する言語を指定した場合のみ翻訳の指示が付くこと", func(t *testing.T) {
+		prompt := questionPrompt(QuestionGenerationRequest{Language: "ja", TranslationLanguages: []string{"en", "ko"}})
+		assert.Contains(t, prompt, "- en（英語）\n- ko（韓国語）")
+		assert.Contains(t, prompt, `"translations": {"en": {`)
+
+		assert.NotContains(t, questionPrompt(QuestionGenerationRequest{Language: "ja"}), "translations")
+	})
+
+	t.Run("生成する言語の名前がテンプレートに渡り、未対応の言語は日本語になること", func(t *testing.T) {
+		prompt, err := RenderPrompt("{{.Language}}:{{.LanguageName}}", QuestionGenerationRequest{Language: "en-US"})
+		require.NoError(t, err)
+		assert.Equal(t, "en:英語", prompt)
+
+		prompt, err = RenderPrompt("{{.Language}}:{{.LanguageName}}", QuestionGenerationRequest{Language: "xx"})
+		require.NoError(t, err)
+		assert.Equal(t, "ja:日本語", prompt)
+	})
+}
+
+func TestAIServicePromptTemplate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("テンプレートを展開した指示が再生成時も同じまま渡ること", func(t *testing.T) {
+		founded := newGeneratedQuestion("創業した年は？", []string{"1998年", "2001年", "1990年", "2010年"}, 0)
+		president := newGeneratedQuestion("初代社長の出身地は？", []string{"大阪", "東京", "福岡", "札幌"}, 0)
+		client := &scriptedClient{questions: []*domain.Question{founded, president}}
+		aiService := newAIService([]AIClient{client}, RouterOptions{})
+
+		question, err := aiService.GenerateQuestion(ctx, QuestionGenerationRequest{
+			Difficulty:     domain.DifficultyEasy,
+			Theme:          "会社の歴史",
+			PromptTemplate: "{{.Theme}}のクイズ",
+		}, []*domain.Question{founded}, "")
+		require.NoError(t, err)
+		assert.Equal(t, president.Text, question.Text)
+
+		require.Len(t, client.requests, 2)
+		for _, request := range client.requests {
+			assert.Equal(t, "会社の歴史のクイズ", request.Prompt)
+		}
+	})
+
+	t.Run("展開できないテンプレートはプロバイダーを呼ばずにエラーを返すこと", func(t *testing.T) {
+		client := &scriptedClient{questions: []*domain.Question{
+			newGeneratedQuestion("創業した年は？", []string{"1998年", "2001年", "1990年", "2010年"}, 0),
+		}}
+		aiService := newAIService([]AIClient{client}, RouterOptions{})
+
+		_, err := aiService.GenerateQuestion(ctx, QuestionGenerationRequest{PromptTemplate: "{{.Missing}}"}, nil, "")
+		assert.ErrorIs(t, err, domain.ErrInvalidPromptTemplate)
+		assert.Empty(t, client.requests)
+	})
+}
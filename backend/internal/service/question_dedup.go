@@ -0,0 +1,204 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"quiz-app/internal/domain"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var numberPattern = regexp.MustCompile(`\d+(?:\.\d+)?`)
+
+const (
+	defaultDuplicateSimilarity = 0.8 // これ以上の類似度の問題は重複とみなす
+	questionHistorySize        = 200 // 履歴グループごとに保持する問題数
+	avoidPromptSize            = 10  // プロンプトで避けるよう指示する出題済み問題数
+)
+
+// QuestionFingerprint 問題文と選択肢から表記ゆれと選択肢の順序を除いた指紋を作る
+func QuestionFingerprint(text string, options []string) string {
+	normalized := make([]string, len(options))
+	for i, option := range options {
+		normalized[i] = normalizeForCompare(option)
+	}
+	sort.Strings(normalized)
+
+	sum := sha256.Sum256([]byte(normalizeForCompare(text) + "\x00" + strings.Join(normalized, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// QuestionSimilarity 2つの問題の類似度（0〜1）
+// 問題文の文字 bigram の一致度を基準とし、選択肢が共通しているほど残りの差を縮める
+func QuestionSimilarity(a, b *domain.Question) float64 {
+	if QuestionFingerprint(a.Text, a.Options) == QuestionFingerprint(b.Text, b.Options) {
+		return 1
+	}
+
+	textA, textB := comparableText(a), comparableText(b)
+	text := diceCoefficient(bigrams(normalizeForCompare(textA)), bigrams(normalizeForCompare(textB)))
+	similarity := text + (1-text)*0.5*optionOverlap(a.Options, b.Options)
+
+	// 「3 + 5 は？」と「4 + 5 は？」のように数値だけが異なる問題は文字の一致度が高くても別の問題とみなす
+	if !slices.Equal(numbersIn(textA), numbersIn(textB)) {
+		similarity *= 0.5
+	}
+	return similarity
+}
+
+// comparableText 類似度の比較に使う文
+// 並べ替え・複数選択の問題は「古い順に並べてください」のように問題文が定型になりやすいため、選択肢も含めて比較する
+func comparableText(q *domain.Question) string {
+	switch q.Kind() {
+	case domain.QuestionTypeOrdering, domain.QuestionTypeMultiSelect:
+		return q.Text + "\n" + strings.Join(q.Options, "\n")
+	}
+	return q.Text
+}
+
+// numbersIn 文中の数値を出現順に返す
+func numbersIn(s string) []string {
+	return numberPattern.FindAllString(norm.NFKC.String(s), -1)
+}
+
+// FindDuplicate history の中で question と最も似た問題を返す（threshold 未満なら nil）
+func FindDuplicate(question *domain.Question, history []*domain.Question, threshold float64) (*domain.Question, float64) {
+	var duplicate *domain.Question
+	best := 0.0
+	for _, q := range history {
+		if similarity := QuestionSimilarity(question, q); similarity >= threshold && similarity > best {
+			duplicate, best = q, similarity
+		}
+	}
+	return duplicate, best
+}
+
+// bigrams 連続する2文字の出現回数（1文字の場合はその文字）
+func bigrams(s string) map[string]int {
+	runes := []rune(s)
+	grams := make(map[string]int)
+	if len(runes) == 1 {
+		grams[s]++
+	}
+	for i := 0; i+1 < len(runes); i++ {
+		grams[string(runes[i:i+2])]++
+	}
+	return grams
+}
+
+// diceCoefficient 2つの bigram 集合の Dice 係数
+func diceCoefficient(a, b map[string]int) float64 {
+	total := 0
+	for _, n := range a {
+		total += n
+	}
+	for _, n := range b {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+
+	shared := 0
+	for gram, n := range a {
+		if m := b[gram]; m < n {
+			shared += m
+		} else {
+			shared += n
+		}
+	}
+	return 2 * float64(shared) / float64(total)
+}
+
+// optionOverlap 選択肢の集合の Jaccard 係数
+func optionOverlap(a, b []string) float64 {
+	set := make(map[string]bool, len(a))
+	for _, option := range a {
+		set[normalizeForCompare(option)] = true
+	}
+
+	union := len(set)
+	shared := 0
+	seen := make(map[string]bool, len(b))
+	for _, option := range b {
+		key := normalizeForCompare(option)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if set[key] {
+			shared++
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// recentQuestionTexts 末尾から最大 n 件の問題文を重複なく返す
+func recentQuestionTexts(questions []*domain.Question, n int) []string {
+	texts := make([]string, 0, n)
+	seen := make(map[string]bool, n)
+	for i := len(questions) - 1; i >= 0 && len(texts) < n; i-- {
+		key := normalizeForCompare(questions[i].Text)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		texts = append(texts, questions[i].Text)
+	}
+	return texts
+}
+
+// QuestionHistory 履歴グループ（組織など）ごとに最近生成した問題を保持する
+// セッションをまたいで同じ問題を出さないために使う（プロセス内のみで保持する）
+type QuestionHistory struct {
+	mu     sync.Mutex
+	size   int
+	groups map[string][]*domain.Question
+}
+
+func NewQuestionHistory(size int) *QuestionHistory {
+	return &QuestionHistory{
+		size:   size,
+		groups: make(map[string][]*domain.Question),
+	}
+}
+
+// Add 問題を履歴に加える（グループ未指定の場合は何もしない）
+func (h *QuestionHistory) Add(group string, question *domain.Question) {
+	if group == "" {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	questions := append(h.groups[group], question)
+	if len(questions) > h.size {
+		questions = questions[len(questions)-h.size:]
+	}
+	h.groups[group] = questions
+}
+
+// Recent グループの最近の問題を古い順に返す
+func (h *QuestionHistory) Recent(group string) []*domain.Question {
+	if group == "" {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	questions := make([]*domain.Question, len(h.groups[group]))
+	copy(questions, h.groups[group])
+	return questions
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quiz-app/internal/domain"
+)
+
+func TestQuestionSimilarity(t *testing.T) {
+	t.Run("表記ゆれと選択肢の順序を無視して同じ指紋になること", func(t *testing.T) {
+		assert.Equal(t,
+			QuestionFingerprint("日本で一番高い山は？", []string{"富士山", "北岳", "ABC", "間ノ岳"}),
+			QuestionFingerprint(" 日本で 一番高い山は? ", []string{"ＡＢＣ", "間ノ岳", "富士山", "北岳"}))
+	})
+
+	t.Run("言い回しが少し違うだけの問題は重複とみなすこと", func(t *testing.T) {
+		a := newGeneratedQuestion("日本で一番高い山はどこ？", []string{"富士山", "北岳", "奥穂高岳", "間ノ岳"}, 0)
+		b := newGeneratedQuestion("日本で一番高い山はどこでしょう？", []string{"北岳", "富士山", "槍ヶ岳", "間ノ岳"}, 1)
+		assert.GreaterOrEqual(t, QuestionSimilarity(a, b), defaultDuplicateSimilarity)
+
+		c := newGeneratedQuestion("世界で一番長い川は？", []string{"ナイル川", "アマゾン川", "長江", "ミシシッピ川"}, 0)
+		assert.Less(t, QuestionSimilarity(a, c), 0.5)
+
+		duplicate, _ := FindDuplicate(b, []*domain.Question{c, a}, defaultDuplicateSimilarity)
+		assert.Same(t, a, duplicate)
+	})
+
+	t.Run("数値だけが異なる問題は重複とみなさないこと", func(t *testing.T) {
+		a := newGeneratedQuestion("3 + 5 はいくつ？", []string{"8", "7", "9", "10"}, 0)
+		b := newGeneratedQuestion("4 + 5 はいくつ？", []string{"9", "8", "10", "11"}, 0)
+		assert.Less(t, QuestionSimilarity(a, b), defaultDuplicateSimilarity)
+	})
+}
+
+func TestAIServiceDuplicates(t *testing.T) {
+	ctx := context.Background()
+	mountain := newGeneratedQuestion("日本で一番高い山はどこ？", []string{"富士山", "北岳", "奥穂高岳", "間ノ岳"}, 0)
+	river := newGeneratedQuestion("世界で一番長い川は？", []string{"ナイル川", "アマゾン川", "長江", "ミシシッピ川"}, 0)
+
+	t.Run("出題済みと重複した問題は避ける指示を加えて再生成すること", func(t *testing.T) {
+		client := &scriptedClient{questions: []*domain.Question{mountain, river}}
+		aiService := newAIService([]AIClient{client}, RouterOptions{})
+
+		question, err := aiService.GenerateQuestion(ctx, QuestionGenerationRequest{SessionID: "s1", Round: 2}, []*domain.Question{mountain}, "")
+		require.NoError(t, err)
+		assert.Equal(t, river.Text, question.Text)
+		assert.Equal(t, "s1", question.SessionID)
+		assert.Equal(t, 2, question.Round)
+
+		require.Len(t, client.requests, 2)
+		assert.Equal(t, []string{mountain.Text}, client.requests[0].AvoidQuestions)
+		assert.Equal(t, []string{mountain.Text, mountain.Text}, client.requests[1].AvoidQuestions)
+	})
+
+	t.Run("再生成しても重複する場合はErrDuplicateQuestionを返すこと", func(t *testing.T) {
+		client := &scriptedClient{questions: []*domain.Question{mountain}}
+		aiService := newAIService([]AIClient{client}, RouterOptions{})
+
+		_, err := aiService.GenerateQuestion(ctx, QuestionGenerationRequest{}, []*domain.Question{mountain}, "")
+		assert.ErrorIs(t, err, domain.ErrDuplicateQuestion)
+		assert.Equal(t, aiService.duplicateRetries+1, client.calls)
+	})
+
+	t.Run("同じ履歴グループのセッション間で重複を避けること", func(t *testing.T) {
+		client := &scriptedClient{questions: []*domain.Question{mountain, mountain, river}}
+		aiService := newAIService([]AIClient{client}, RouterOptions{})
+
+		_, err := aiService.GenerateQuestion(ctx, QuestionGenerationRequest{SessionID: "s1"}, nil, "org")
+		require.NoError(t, err)
+
+		question, err := aiService.GenerateQuestion(ctx, QuestionGenerationRequest{SessionID: "s2"}, nil, "org")
+		require.NoError(t, err)
+		assert.Equal(t, river.Text, question.Text)
+
+		// グループが異なれば履歴は共有しない
+		assert.Empty(t, aiService.history.Recent("other"))
+	})
+}
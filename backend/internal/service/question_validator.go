@@ -0,0 +1,225 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"quiz-app/internal/domain"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// AI が生成した問題の上限
+const (
+	generatedOptionCount       = 4
+	maxGeneratedTextLength     = 200
+	maxGeneratedOptionLength   = 50
+	maxGeneratedExplanationLen = 400
+)
+
+// defaultBannedWords 設定に関わらず拒否する語
+var defaultBannedWords = []string{"死ね", "殺す", "fuck", "shit"}
+
+// ErrQuestionRejected 生成された問題が検証を通らなかった
+var ErrQuestionRejected = errors.New("generated question rejected")
+
+// QuestionValidator AI が生成した問題を整形・検証する
+// すべてのクライアントの結果はルーターでこの検証を通してから採用する
+type QuestionValidator struct {
+	bannedWords []string
+}
+
+// NewQuestionValidator 既定の禁止語に bannedWords を加えた検証器を作成する
+func NewQuestionValidator(bannedWords []string) *QuestionValidator {
+	words := make([]string, 0, len(defaultBannedWords)+len(bannedWords))
+	for _, word := range append(append([]string{}, defaultBannedWords...), bannedWords...) {
+		if word = normalizeForCompare(word); word != "" {
+			words = append(words, word)
+		}
+	}
+	return &QuestionValidator{bannedWords: words}
+}
+
+// Validate 問題文・選択肢・解説を整形したうえで検証する
+// 不合格の場合は理由を含む ErrQuestionRejected を返す
+func (v *QuestionValidator) Validate(q *domain.Question) error {
+	sanitizeQuestion(q)
+
+	if reason := v.rejectReason(q); reason != "" {
+		return fmt.Errorf("%w: %s", ErrQuestionRejected, reason)
+	}
+	return nil
+}
+
+// ValidateTranslations 要求した言語の翻訳がそろっているかを検証し、各翻訳を元の問題と同じ基準で整形・検証する
+// 要求していない言語の翻訳は取り除く
+func (v *QuestionValidator) ValidateTranslations(q *domain.Question, languages []string) error {
+	translations := make(map[string]domain.QuestionTranslation, len(languages))
+	for _, language := range languages {
+		translation, ok := q.Translations[language]
+		if !ok {
+			return fmt.Errorf("%w: missing %s translation", ErrQuestionRejected, language)
+		}
+
+		localized := *q
+		localized.Text = translation.Text
+		localized.Options = append([]string(nil), translation.Options...)
+		localized.Explanation = translation.Explanation
+		sanitizeQuestion(&localized)
+		if reason := v.rejectReason(&localized); reason != "" {
+			return fmt.Errorf("%w: %s translation: %s", ErrQuestionRejected, language, reason)
+		}
+		translations[language] = domain.QuestionTranslation{
+			Text:        localized.Text,
+			Options:     localized.Options,
+			Explanation: localized.Explanation,
+		}
+	}
+
+	if len(translations) == 0 {
+		translations = nil
+	}
+	q.Translations = translations
+	return nil
+}
+
+func (v *QuestionValidator) rejectReason(q *domain.Question) string {
+	if q.Text == "" {
+		return "question text is empty"
+	}
+	if utf8.RuneCountInString(q.Text) > maxGeneratedTextLength {
+		return fmt.Sprintf("question text exceeds %d characters", maxGeneratedTextLength)
+	}
+	if reason := answerKeyRejectReason(q); reason != "" {
+		return reason
+	}
+	if !domain.IsValidDifficulty(q.Difficulty) {
+		return fmt.Sprintf("invalid difficulty %q", q.Difficulty)
+	}
+	if utf8.RuneCountInString(q.Explanation) > maxGeneratedExplanationLen {
+		return fmt.Sprintf("explanation exceeds %d characters", maxGeneratedExplanationLen)
+	}
+
+	seen := make(map[string]int, len(q.Options))
+	for i, option := range q.Options {
+		if option == "" {
+			return fmt.Sprintf("option %d is empty", i)
+		}
+		if utf8.RuneCountInString(option) > maxGeneratedOptionLength {
+			return fmt.Sprintf("option %d exceeds %d characters", i, maxGeneratedOptionLength)
+		}
+		key := normalizeForCompare(option)
+		if j, ok := seen[key]; ok {
+			return fmt.Sprintf("options %d and %d are duplicates", j, i)
+		}
+		seen[key] = i
+	}
+	// 正解の番号の範囲・重複は問題形式ごとの定義（domain）で判定する
+	if !q.IsValid() {
+		return fmt.Sprintf("invalid answer key for %s question", q.Kind())
+	}
+
+	if q.Kind() == domain.QuestionTypeChoice && answerLeaked(q) {
+		return "question text contains the correct answer"
+	}
+
+	for _, field := range append([]string{q.Text, q.Explanation}, q.Options...) {
+		normalized := normalizeForCompare(field)
+		for _, word := range v.bannedWords {
+			if strings.Contains(normalized, word) {
+				return fmt.Sprintf("contains banned word %q", word)
+			}
+		}
+	}
+	return ""
+}
+
+// answerKeyRejectReason 選択肢の数と正解が問題形式に合っているか判定する
+func answerKeyRejectReason(q *domain.Question) string {
+	optionCount := generatedOptionCount
+	switch q.Kind() {
+	case domain.QuestionTypeTrueFalse:
+		optionCount = 2
+	case domain.QuestionTypeNumeric:
+		optionCount = 0
+	}
+	if len(q.Options) != optionCount {
+		return fmt.Sprintf("%s question must have exactly %d options (got %d)", q.Kind(), optionCount, len(q.Options))
+	}
+
+	switch q.Kind() {
+	case domain.QuestionTypeChoice, domain.QuestionTypeTrueFalse:
+		if q.CorrectAnswer < 0 || q.CorrectAnswer >= len(q.Options) {
+			return fmt.Sprintf("correctAnswer %d is out of range", q.CorrectAnswer)
+		}
+	case domain.QuestionTypeMultiSelect:
+		// 全部正解の問題は選択肢を読まなくても答えられる
+		if len(q.CorrectAnswers) == 0 || len(q.CorrectAnswers) == len(q.Options) {
+			return fmt.Sprintf("multi-select question must have 1 to %d correct answers (got %d)", len(q.Options)-1, len(q.CorrectAnswers))
+		}
+	case domain.QuestionTypeOrdering:
+		if slices.Equal(q.CorrectAnswers, []int{0, 1, 2, 3}) {
+			return "ordering question options are already in the correct order"
+		}
+	case domain.QuestionTypeNumeric:
+		if q.Tolerance < 0 {
+			return fmt.Sprintf("tolerance %v is negative", q.Tolerance)
+		}
+	default:
+		return fmt.Sprintf("invalid question type %q", q.Type)
+	}
+	return ""
+}
+
+// answerLeaked 正解の選択肢だけが問題文に含まれているか判定する
+// 「AとBのどちらが〜」のように他の選択肢も含まれる場合は漏洩とみなさない
+func answerLeaked(q *domain.Question) bool {
+	text := normalizeForCompare(q.Text)
+	correct := normalizeForCompare(q.Options[q.CorrectAnswer])
+	if utf8.RuneCountInString(correct) < 2 || !strings.Contains(text, correct) {
+		return false
+	}
+	for i, option := range q.Options {
+		if i != q.CorrectAnswer && strings.Contains(text, normalizeForCompare(option)) {
+			return false
+		}
+	}
+	return true
+}
+
+// sanitizeQuestion 制御文字と前後の空白を取り除き、選択肢の改行を空白にする
+func sanitizeQuestion(q *domain.Question) {
+	q.Text = sanitizeText(q.Text, true)
+	q.Explanation = sanitizeText(q.Explanation, true)
+	q.Category = sanitizeText(q.Category, false)
+	for i, option := range q.Options {
+		q.Options[i] = sanitizeText(option, false)
+	}
+}
+
+func sanitizeText(s string, keepNewlines bool) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' && keepNewlines:
+			return r
+		case r == '\n' || r == '\t' || r == '\r':
+			return ' '
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, s)
+	if !keepNewlines {
+		s = strings.Join(strings.Fields(s), " ")
+	}
+	return strings.TrimSpace(s)
+}
+
+// normalizeForCompare 全角半角・大文字小文字・空白の違いを無視して比較するための正規化
+func normalizeForCompare(s string) string {
+	s = strings.ToLower(norm.NFKC.String(s))
+	return strings.Join(strings.Fields(s), "")
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quiz-app/internal/domain"
+)
+
+func newGeneratedQuestion(text string, options []string, correctAnswer int) *domain.Question {
+	return domain.NewQuestion("", 0, text, options, correctAnswer, domain.DifficultyMedium, "一般", domain.AIProviderGemini)
+}
+
+func TestQuestionValidator(t *testing.T) {
+	validator := NewQuestionValidator([]string{"NGワード"})
+
+	t.Run("整形したうえで正常な問題を受け入れること", func(t *testing.T) {
+		q := newGeneratedQuestion("  日本で一番高い山は？\x00 ", []string{" 富士山", "北岳\n", "奥穂高岳", "間ノ岳"}, 0)
+		require.NoError(t, validator.Validate(q))
+		assert.Equal(t, "日本で一番高い山は？", q.Text)
+		assert.Equal(t, []string{"富士山", "北岳", "奥穂高岳", "間ノ岳"}, q.Options)
+	})
+
+	cases := []struct {
+		name     string
+		question *domain.Question
+		reason   string
+	}{
+		{"正解番号が範囲外", newGeneratedQuestion("問題", []string{"A", "B", "C", "D"}, 4), "out of range"},
+		{"選択肢が4つでない", newGeneratedQuestion("問題", []string{"A", "B", "C"}, 0), "exactly 4 options"},
+		{"空の選択肢", newGeneratedQuestion("問題", []string{"A", " ", "C", "D"}, 0), "option 1 is empty"},
+		{"全角半角のみ異なる重複選択肢", newGeneratedQuestion("問題", []string{"ABC", "ＡＢＣ", "C", "D"}, 0), "duplicates"},
+		{"問題文に正解が含まれる", newGeneratedQuestion("東京タワーがある都市はどこ？", []string{"大阪", "東京", "名古屋", "福岡"}, 1), "contains the correct answer"},
+		{"問題文が長すぎる", newGeneratedQuestion(strings.Repeat("あ", maxGeneratedTextLength+1), []string{"A", "B", "C", "D"}, 0), "exceeds"},
+		{"禁止語を含む", newGeneratedQuestion("ngワードを含む問題", []string{"A", "B", "C", "D"}, 0), "banned word"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name+"の問題を拒否すること", func(t *testing.T) {
+			err := validator.Validate(tc.question)
+			require.ErrorIs(t, err, ErrQuestionRejected)
+			assert.Contains(t, err.Error(), tc.reason)
+		})
+	}
+
+	t.Run("他の選択肢も問題文に含まれる場合は正解の漏洩とみなさないこと", func(t *testing.T) {
+		q := newGeneratedQuestion("東京と大阪、人口が多いのはどちら？", []string{"東京", "大阪", "同じ", "わからない"}, 0)
+		assert.NoError(t, validator.Validate(q))
+	})
+}
+
+func TestQuestionValidatorTranslations(t *testing.T) {
+	validator := NewQuestionValidator(nil)
+	newTranslated := func() *domain.Question {
+		q := newGeneratedQuestion("日本で一番高い山は？", []string{"富士山", "北岳", "奥穂高岳", "間ノ岳"}, 0)
+		q.Translations = map[string]domain.QuestionTranslation{
+			"en": {Text: " What is the highest mountain in Japan?\x00", Options: []string{"Mt. Fuji", "Mt. Kita", "Mt. Okuhotaka", "Mt. Aino"}},
+			"fr": {Text: "Quelle est la plus haute montagne du Japon ?", Options: []string{"Fuji", "Kita", "Okuhotaka", "Aino"}},
+		}
+		return q
+	}
+
+	t.Run("要求した言語の翻訳を整形し、要求していない翻訳は取り除くこと", func(t *testing.T) {
+		q := newTranslated()
+		require.NoError(t, validator.ValidateTranslations(q, []string{"en"}))
+		require.Len(t, q.Translations, 1)
+		assert.Equal(t, "What is the highest mountain in Japan?", q.Translations["en"].Text)
+	})
+
+	t.Run("翻訳が足りない・検証を通らない場合は拒否すること", func(t *testing.T) {
+		err := validator.ValidateTranslations(newTranslated(), []string{"en", "ko"})
+		require.ErrorIs(t, err, ErrQuestionRejected)
+		assert.Contains(t, err.Error(), "missing ko translation")
+
+		q := newTranslated()
+		q.Translations["en"] = domain.QuestionTranslation{Text: "Question", Options: []string{"A", "A", "C", "D"}}
+		err = validator.ValidateTranslations(q, []string{"en"})
+		require.ErrorIs(t, err, ErrQuestionRejected)
+		assert.Contains(t, err.Error(), "en translation: options 0 and 1 are duplicates")
+	})
+
+	t.Run("翻訳を要求しない場合は翻訳を持たないこと", func(t *testing.T) {
+		q := newTranslated()
+		require.NoError(t, validator.ValidateTranslations(q, nil))
+		assert.Nil(t, q.Translations)
+	})
+}
+
+func TestQuestionValidatorQuestionTypes(t *testing.T) {
+	validator := NewQuestionValidator(nil)
+	typed := func(questionType domain.QuestionType, options []string, key domain.AnswerKey) *domain.Question {
+		q := newGeneratedQuestion("問題", options, key.CorrectAnswer)
+		key.Type = questionType
+		q.SetAnswerKey(key)
+		return q
+	}
+	four := []string{"A", "B", "C", "D"}
+
+	accepted := []struct {
+		name     string
+		question *domain.Question
+	}{
+		{"○×問題", typed(domain.QuestionTypeTrueFalse, []string{"正しい", "誤り"}, domain.AnswerKey{CorrectAnswer: 1})},
+		{"複数選択問題", typed(domain.QuestionTypeMultiSelect, four, domain.AnswerKey{CorrectAnswers: []int{0, 2}})},
+		{"並べ替え問題", typed(domain.QuestionTypeOrdering, four, domain.AnswerKey{CorrectAnswers: []int{2, 0, 3, 1}})},
+		{"数値問題", typed(domain.QuestionTypeNumeric, nil, domain.AnswerKey{NumericAnswer: 3776})},
+	}
+	for _, tc := range accepted {
+		t.Run(tc.name+"を受け入れること", func(t *testing.T) {
+			assert.NoError(t, validator.Validate(tc.question))
+		})
+	}
+
+	rejected := []struct {
+		name     string
+		question *domain.Question
+		reason   string
+	}{
+		{"選択肢が4つの○×問題", typed(domain.QuestionTypeTrueFalse, four, domain.AnswerKey{}), "exactly 2 options"},
+		{"すべて正解の複数選択問題", typed(domain.QuestionTypeMultiSelect, four, domain.AnswerKey{CorrectAnswers: []int{0, 1, 2, 3}}), "1 to 3 correct answers"},
+		{"正解が範囲外の複数選択問題", typed(domain.QuestionTypeMultiSelect, four, domain.AnswerKey{CorrectAnswers: []int{4}}), "invalid answer key"},
+		{"最初から正しい順の並べ替え問題", typed(domain.QuestionTypeOrdering, four, domain.AnswerKey{CorrectAnswers: []int{0, 1, 2, 3}}), "already in the correct order"},
+		{"順序が足りない並べ替え問題", typed(domain.QuestionTypeOrdering, four, domain.AnswerKey{CorrectAnswers: []int{1, 0}}), "invalid answer key"},
+		{"選択肢のある数値問題", typed(domain.QuestionTypeNumeric, four, domain.AnswerKey{NumericAnswer: 1}), "exactly 0 options"},
+	}
+	for _, tc := range rejected {
+		t.Run(tc.name+"を拒否すること", func(t *testing.T) {
+			err := validator.Validate(tc.question)
+			require.ErrorIs(t, err, ErrQuestionRejected)
+			assert.Contains(t, err.Error(), tc.reason)
+		})
+	}
+
+	t.Run("ルーターは要求した問題形式を設定してから検証すること", func(t *testing.T) {
+		generated := newGeneratedQuestion("古い順に並べてください", []string{"江戸", "平安", "明治", "奈良"}, 0)
+		generated.CorrectAnswers = []int{3, 1, 0, 2}
+		generated.NumericAnswer = 1
+		router := newTestRouter([]AIClient{&scriptedClient{questions: []*domain.Question{generated}}}, RouterOptions{})
+
+		question, _, err := router.GenerateQuestion(context.Background(), QuestionGenerationRequest{
+			Difficulty:   domain.DifficultyMedium,
+			QuestionType: domain.QuestionTypeOrdering,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.QuestionTypeOrdering, question.Type)
+		assert.Equal(t, []int{3, 1, 0, 2}, question.CorrectAnswers)
+		assert.Zero(t, question.NumericAnswer)
+	})
+}
+
+func TestProviderRouterValidation(t *testing.T) {
+	t.Run("検証で不合格の問題は再生成し、サーキットには影響しないこと", func(t *testing.T) {
+		bad := newGeneratedQuestion("問題", []string{"A", "B", "C", "D"}, 4)
+		good := newGeneratedQuestion("問題", []string{"A", "B", "C", "D"}, 1)
+		client := &scriptedClient{questions: []*domain.Question{bad, bad, good}}
+		router := newTestRouter([]AIClient{client}, RouterOptions{MaxRetries: 2, FailureThreshold: 1})
+
+		question, _, err := router.GenerateQuestion(context.Background(), QuestionGenerationRequest{Difficulty: domain.DifficultyMedium})
+		require.NoError(t, err)
+		assert.Equal(t, 1, question.CorrectAnswer)
+
+		stats := router.Stats()[0]
+		assert.Equal(t, 2, stats.Rejections)
+		assert.Equal(t, 0, stats.Failures)
+		assert.Equal(t, string(CircuitClosed), stats.CircuitState)
+	})
+
+	t.Run("再生成しても不合格の場合は次のプロバイダーへ切り替わること", func(t *testing.T) {
+		bad := &scriptedClient{questions: []*domain.Question{newGeneratedQuestion("問題", []string{"A", "A", "C", "D"}, 0)}}
+		fallback := &fakeRouteClient{name: "fallback"}
+		router := newTestRouter([]AIClient{bad, fallback}, RouterOptions{MaxRetries: 1})
+
+		_, client, err := router.GenerateQuestion(context.Background(), QuestionGenerationRequest{Difficulty: domain.DifficultyMedium})
+		require.NoError(t, err)
+		assert.Equal(t, "fallback", client.GetName())
+		assert.Equal(t, 2, router.Stats()[0].Rejections)
+	})
+}
+
+// scriptedClient 用意した問題を順番に返す（使い切った後は最後の問題を繰り返す）
+type scriptedClient struct {
+	questions []*domain.Question
+	calls     int
+	requests  []QuestionGenerationRequest
+}
+
+func (s *scriptedClient) GenerateQuestion(ctx context.Context, request QuestionGenerationRequest) (*domain.Question, error) {
+	i := s.calls
+	if i >= len(s.questions) {
+		i = len(s.questions) - 1
+	}
+	s.calls++
+	s.requests = append(s.requests, request)
+	q := *s.questions[i]
+	q.Options = append([]string{}, q.Options...)
+	return &q, nil
+}
+
+func (s *scriptedClient) IsAvailable() bool { return true }
+
+func (s *scriptedClient) GetName() string { return "scripted" }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/csv"
 	"fmt"
+	"log"
 	"math/rand"
 	"quiz-app/internal/domain"
 	"quiz-app/internal/repository"
@@ -18,6 +19,7 @@ type adminUseCase struct {
 	questionRepo    repository.QuestionRepository
 	answerRepo      repository.AnswerRepository
 	wsManager       *websocket.Manager
+	scheduler       *RoundScheduler
 }
 
 func NewAdminUseCase(
@@ -26,6 +28,7 @@ func NewAdminUseCase(
 	questionRepo repository.QuestionRepository,
 	answerRepo repository.AnswerRepository,
 	wsManager *websocket.Manager,
+	scheduler *RoundScheduler,
 ) AdminUseCase {
 	return &adminUseCase{
 		sessionRepo:     sessionRepo,
@@ -33,6 +36,7 @@ func NewAdminUseCase(
 		questionRepo:    questionRepo,
 		answerRepo:      answerRepo,
 		wsManager:       wsManager,
+		scheduler:       scheduler,
 	}
 }
 
@@ -62,11 +66,12 @@ func (u *adminUseCase) GetSessionStats(ctx context.Context, sessionID string) (m
 		return nil, fmt.Errorf("failed to get eliminated participants: %w", err)
 	}
 
-	// 問題情報
+	// 問題情報（出題前の下書きは除く）
 	questions, err := u.questionRepo.GetBySession(ctx, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get questions: %w", err)
 	}
+	questions = domain.PublishedQuestions(questions)
 
 	// 回答情報
 	answers, err := u.answerRepo.GetBySession(ctx, sessionID)
@@ -77,10 +82,18 @@ func (u *adminUseCase) GetSessionStats(ctx context.Context, sessionID string) (m
 	// 統計計算
 	totalAnswers := len(answers)
 	correctAnswers := 0
+	totalResponseTime := 0
 	for _, answer := range answers {
 		if answer.IsCorrect {
 			correctAnswers++
 		}
+		totalResponseTime += answer.ResponseTime
+	}
+
+	// 平均回答時間（サーバー計測値、ミリ秒）
+	averageResponseTime := 0
+	if totalAnswers > 0 {
+		averageResponseTime = totalResponseTime / totalAnswers
 	}
 
 	correctRate := 0.0
@@ -109,9 +122,10 @@ func (u *adminUseCase) GetSessionStats(ctx context.Context, sessionID string) (m
 			"total": len(questions),
 		},
 		"answers": map[string]interface{}{
-			"total":       totalAnswers,
-			"correct":     correctAnswers,
-			"correctRate": correctRate,
+			"total":               totalAnswers,
+			"correct":             correctAnswers,
+			"correctRate":         correctRate,
+			"averageResponseTime": averageResponseTime,
 		},
 	}
 
@@ -214,11 +228,12 @@ func (u *adminUseCase) ExportResults(ctx context.Context, sessionID string) ([]b
 		return nil, fmt.Errorf("failed to get participants: %w", err)
 	}
 
-	// 問題データ取得
+	// 問題データ取得（出題前の下書きは除く）
 	questions, err := u.questionRepo.GetBySession(ctx, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get questions: %w", err)
 	}
+	questions = domain.PublishedQuestions(questions)
 
 	// 回答データ取得
 	answers, err := u.answerRepo.GetBySession(ctx, sessionID)
@@ -234,6 +249,7 @@ func (u *adminUseCase) ExportResults(ctx context.Context, sessionID string) ([]b
 	header := []string{
 		"セッションID", "セッション名", "参加者ID", "表示名", "ステータス",
 		"スコア", "正解数", "参加時刻", "脱落時刻", "復活時刻",
+		"残りライフ", "ライフ喪失時刻",
 	}
 	
 	// 各問題の列を追加
@@ -241,6 +257,8 @@ func (u *adminUseCase) ExportResults(ctx context.Context, sessionID string) ([]b
 		header = append(header, fmt.Sprintf("Q%d_回答", question.Round))
 		header = append(header, fmt.Sprintf("Q%d_正解", question.Round))
 		header = append(header, fmt.Sprintf("Q%d_回答時間", question.Round))
+		header = append(header, fmt.Sprintf("Q%d_得点", question.Round))
+		header = append(header, fmt.Sprintf("Q%d_解説", question.Round))
 	}
 
 	writer.Write(header)
@@ -272,20 +290,29 @@ func (u *adminUseCase) ExportResults(ctx context.Context, sessionID string) ([]b
 			row = append(row, "")
 		}
 
+		// 残りライフとライフを失った時刻（複数回は " / " 区切り）
+		lifeLostAt := make([]string, len(participant.LifeLostAt))
+		for i, lostAt := range participant.LifeLostAt {
+			lifeLostAt[i] = lostAt.Format("2006-01-02 15:04:05")
+		}
+		row = append(row, fmt.Sprintf("%d", participant.Lives), strings.Join(lifeLostAt, " / "))
+
 		// 各問題の回答データ
 		for _, question := range questions {
 			answer := u.findAnswerByUserAndQuestion(answers, participant.UserID, question.ID)
 			if answer != nil {
-				row = append(row, fmt.Sprintf("%d", answer.SelectedOption))
+				row = append(row, answer.Summary())
 				if answer.IsCorrect {
 					row = append(row, "○")
 				} else {
 					row = append(row, "×")
 				}
 				row = append(row, fmt.Sprintf("%d", answer.ResponseTime))
+				row = append(row, fmt.Sprintf("%d", answer.Points))
 			} else {
-				row = append(row, "", "", "")
+				row = append(row, "", "", "", "")
 			}
+			row = append(row, question.Explanation)
 		}
 
 		writer.Write(row)
@@ -324,8 +351,13 @@ func (u *adminUseCase) SkipQuestion(ctx context.Context, sessionID string) error
 		return domain.ErrQuestionNotFound
 	}
 
-	// 問題終了通知（正解は表示しない）
-	u.wsManager.NotifyQuestionEnd(sessionID, currentQuestion.ID, -1)
+	// 出題タイマーを破棄（スキップした問題の結果処理は行わない）
+	if err := u.scheduler.Cancel(ctx, sessionID); err != nil {
+		log.Printf("Failed to cancel round for session %s: %v", sessionID, err)
+	}
+
+	// 問題終了通知（正解・解説は表示しない）
+	u.wsManager.NotifyQuestionEnd(sessionID, currentQuestion, false)
 
 	// 次のラウンドに進む
 	if err := session.NextRound(); err != nil {
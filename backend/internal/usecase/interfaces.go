@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"io"
 	"quiz-app/internal/domain"
+	"quiz-app/internal/service"
 )
 
 type SessionUseCase interface {
@@ -12,18 +14,32 @@ type SessionUseCase interface {
 	StartSession(ctx context.Context, sessionID string) error
 	FinishSession(ctx context.Context, sessionID string) error
 	DeleteSession(ctx context.Context, sessionID string) error
-	JoinSession(ctx context.Context, sessionID, userID, displayName string) (*domain.Participant, error)
+	JoinSession(ctx context.Context, sessionID, userID, displayName, language string) (*domain.Participant, error)
 	GetParticipants(ctx context.Context, sessionID string) ([]*domain.Participant, error)
 	GetActiveParticipants(ctx context.Context, sessionID string) ([]*domain.Participant, error)
+	AuthorizeSessionAccess(ctx context.Context, sessionID, userID string, isAdmin bool) error
+	UpdateGenerationSettings(ctx context.Context, sessionID string, generation domain.GenerationSettings) (*domain.Session, error)
 }
 
 type QuizUseCase interface {
 	GenerateQuestion(ctx context.Context, sessionID string, round int, difficulty domain.Difficulty, category string) (*domain.Question, error)
 	GetCurrentQuestion(ctx context.Context, sessionID string) (*domain.Question, error)
+	GetSessionSnapshot(ctx context.Context, sessionID, userID string) (*domain.SessionSnapshot, error)
 	GetAllQuestions(ctx context.Context, sessionID string) ([]*domain.Question, error)
-	SubmitAnswer(ctx context.Context, sessionID, userID, questionID string, selectedOption, responseTime int) (*domain.Answer, error)
-	ProcessRoundResults(ctx context.Context, sessionID string, questionID string) ([]*domain.Participant, []*domain.Participant, error)
+	SubmitAnswer(ctx context.Context, sessionID, userID, questionID string, input domain.AnswerInput, clientResponseTime int) (*domain.Answer, error)
+	ProcessRoundResults(ctx context.Context, sessionID string, questionID string) (*domain.RoundResult, error)
 	NextRound(ctx context.Context, sessionID string) error
+	GetLeaderboard(ctx context.Context, sessionID string) ([]*domain.LeaderboardEntry, error)
+	GenerateDraftQuestions(ctx context.Context, sessionID string, count int, difficulties []domain.Difficulty, categories []string) ([]*domain.Question, error)
+	GetDraftQuestions(ctx context.Context, sessionID string) ([]*domain.Question, error)
+	UpdateDraftQuestion(ctx context.Context, sessionID, questionID, text string, options []string, key domain.AnswerKey, difficulty domain.Difficulty, category, explanation string) (*domain.Question, error)
+	ReorderDraftQuestions(ctx context.Context, sessionID string, questionIDs []string) ([]*domain.Question, error)
+	ReviewDraftQuestion(ctx context.Context, sessionID, questionID string, approve bool) (*domain.Question, error)
+	PublishNextQuestion(ctx context.Context, sessionID string) (*domain.Question, error)
+	UpdateQuestionExplanation(ctx context.Context, sessionID, questionID, explanation string) (*domain.Question, error)
+	UpdateQuestionTranslation(ctx context.Context, sessionID, questionID, language string, translation domain.QuestionTranslation) (*domain.Question, error)
+	UpdateQuestionMedia(ctx context.Context, sessionID, questionID string, media *domain.Media, optionMedia []*domain.Media) (*domain.Question, error)
+	GetAIProviderHealth(ctx context.Context) (*domain.AIProviderHealth, error)
 }
 
 type UserUseCase interface {
@@ -38,4 +54,29 @@ type AdminUseCase interface {
 	StartRevival(ctx context.Context, sessionID string, count int) ([]*domain.Participant, error)
 	ExportResults(ctx context.Context, sessionID string) ([]byte, error)
 	SkipQuestion(ctx context.Context, sessionID string) error
+}
+
+type QuestionBankUseCase interface {
+	CreateBank(ctx context.Context, name, description string, questions []domain.BankQuestion) (*domain.QuestionBank, error)
+	GetBank(ctx context.Context, bankID string) (*domain.QuestionBank, error)
+	ListBanks(ctx context.Context) ([]*domain.QuestionBank, error)
+	UpdateBank(ctx context.Context, bankID, name, description string, questions []domain.BankQuestion) (*domain.QuestionBank, error)
+	DeleteBank(ctx context.Context, bankID string) error
+	ImportBank(ctx context.Context, format domain.QuestionBankFormat, data []byte, name string) (*domain.QuestionBank, error)
+	ExportBank(ctx context.Context, bankID string, format domain.QuestionBankFormat) ([]byte, error)
+}
+
+type MediaUseCase interface {
+	Upload(ctx context.Context, contentType string, size int64, r io.Reader, altText string) (*domain.Media, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type PromptTemplateUseCase interface {
+	CreateTemplate(ctx context.Context, name, description, body string) (*domain.PromptTemplate, error)
+	GetTemplate(ctx context.Context, templateID string) (*domain.PromptTemplate, error)
+	ListTemplates(ctx context.Context) ([]*domain.PromptTemplate, error)
+	UpdateTemplate(ctx context.Context, templateID, name, description, body string) (*domain.PromptTemplate, error)
+	DeleteTemplate(ctx context.Context, templateID string) error
+	PreviewTemplate(ctx context.Context, body string, request service.QuestionGenerationRequest) (string, error)
 }
\ No newline at end of file
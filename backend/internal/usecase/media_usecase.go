@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"quiz-app/internal/domain"
+	"quiz-app/internal/repository"
+)
+
+type mediaUseCase struct {
+	store         repository.BlobStore
+	maxUploadSize int64
+}
+
+// NewMediaUseCase maxUploadSize はアップロードできるファイルの上限（バイト）
+func NewMediaUseCase(store repository.BlobStore, maxUploadSize int64) MediaUseCase {
+	return &mediaUseCase{
+		store:         store,
+		maxUploadSize: maxUploadSize,
+	}
+}
+
+// Upload 画像・音声を保存し、問題に添付できるメディアを返す
+// size が不明（-1）でも上限を超えた時点で ErrMediaTooLarge にする
+func (u *mediaUseCase) Upload(ctx context.Context, contentType string, size int64, r io.Reader, altText string) (*domain.Media, error) {
+	if size > u.maxUploadSize {
+		return nil, domain.ErrMediaTooLarge
+	}
+
+	key, err := domain.NewMediaKey(contentType)
+	if err != nil {
+		return nil, err
+	}
+	media, err := domain.MediaForKey(key, altText)
+	if err != nil {
+		return nil, err
+	}
+
+	limited := &io.LimitedReader{R: r, N: u.maxUploadSize + 1}
+	if err := u.store.Put(ctx, key, media.ContentType, limited); err != nil {
+		return nil, fmt.Errorf("failed to store media: %w", err)
+	}
+	if limited.N == 0 {
+		u.store.Delete(ctx, key)
+		return nil, domain.ErrMediaTooLarge
+	}
+
+	media.URL = u.store.URL(key)
+	return media, nil
+}
+
+// Open 保存したメディアと Content-Type を返す（呼び出し側で Close する）
+func (u *mediaUseCase) Open(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	if _, err := domain.MediaForKey(key, ""); err != nil {
+		return nil, "", domain.ErrMediaNotFound
+	}
+	return u.store.Open(ctx, key)
+}
+
+// Delete 保存したメディアを削除する（添付している問題からは外れないため、URL は配信されなくなる）
+func (u *mediaUseCase) Delete(ctx context.Context, key string) error {
+	if _, err := domain.MediaForKey(key, ""); err != nil {
+		return domain.ErrMediaNotFound
+	}
+	return u.store.Delete(ctx, key)
+}
+
+// resolveMedia 添付するメディアをキーから作り直し、保存済みであることを確認して URL を設定する
+// 種類・Content-Type・URL はクライアントの指定を使わない
+func resolveMedia(ctx context.Context, store repository.BlobStore, media *domain.Media) (*domain.Media, error) {
+	if media == nil {
+		return nil, nil
+	}
+
+	resolved, err := domain.MediaForKey(media.Key, media.AltText)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := store.Exists(ctx, resolved.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check media: %w", err)
+	}
+	if !exists {
+		return nil, domain.ErrMediaNotFound
+	}
+	resolved.URL = store.URL(resolved.Key)
+	return resolved, nil
+}
+
+// resolveQuestionMedia 問題文と選択肢のメディアを resolveMedia で確認する
+func resolveQuestionMedia(ctx context.Context, store repository.BlobStore, media *domain.Media, optionMedia []*domain.Media) (*domain.Media, []*domain.Media, error) {
+	resolved, err := resolveMedia(ctx, store, media)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var resolvedOptions []*domain.Media
+	if optionMedia != nil {
+		resolvedOptions = make([]*domain.Media, len(optionMedia))
+		for i, m := range optionMedia {
+			if resolvedOptions[i], err = resolveMedia(ctx, store, m); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return resolved, resolvedOptions, nil
+}
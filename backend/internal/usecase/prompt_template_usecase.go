@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"quiz-app/internal/domain"
+	"quiz-app/internal/repository"
+	"quiz-app/internal/service"
+	"time"
+)
+
+type promptTemplateUseCase struct {
+	templateRepo repository.PromptTemplateRepository
+}
+
+func NewPromptTemplateUseCase(templateRepo repository.PromptTemplateRepository) PromptTemplateUseCase {
+	return &promptTemplateUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+// validatePromptTemplate 名前・本文に加え、テンプレートの構文と変数を検証する
+func validatePromptTemplate(template *domain.PromptTemplate) error {
+	if err := template.Validate(); err != nil {
+		return err
+	}
+	return service.ValidatePromptTemplate(template.Body)
+}
+
+func (u *promptTemplateUseCase) CreateTemplate(ctx context.Context, name, description, body string) (*domain.PromptTemplate, error) {
+	template := domain.NewPromptTemplate(name, description, body)
+	if err := validatePromptTemplate(template); err != nil {
+		return nil, err
+	}
+
+	if err := u.templateRepo.Create(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to create prompt template: %w", err)
+	}
+
+	return template, nil
+}
+
+func (u *promptTemplateUseCase) GetTemplate(ctx context.Context, templateID string) (*domain.PromptTemplate, error) {
+	if templateID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	return u.templateRepo.GetByID(ctx, templateID)
+}
+
+func (u *promptTemplateUseCase) ListTemplates(ctx context.Context) ([]*domain.PromptTemplate, error) {
+	templates, err := u.templateRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+func (u *promptTemplateUseCase) UpdateTemplate(ctx context.Context, templateID, name, description, body string) (*domain.PromptTemplate, error) {
+	template, err := u.GetTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	template.Name = name
+	template.Description = description
+	template.Body = body
+	template.UpdatedAt = time.Now()
+	template.Normalize()
+	if err := validatePromptTemplate(template); err != nil {
+		return nil, err
+	}
+
+	if err := u.templateRepo.Update(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to update prompt template: %w", err)
+	}
+
+	return template, nil
+}
+
+// DeleteTemplate テンプレートを削除する
+// 削除したテンプレートを指定しているセッションでは、問題生成時に ErrPromptTemplateNotFound となる
+func (u *promptTemplateUseCase) DeleteTemplate(ctx context.Context, templateID string) error {
+	if _, err := u.GetTemplate(ctx, templateID); err != nil {
+		return err
+	}
+
+	if err := u.templateRepo.Delete(ctx, templateID); err != nil {
+		return fmt.Errorf("failed to delete prompt template: %w", err)
+	}
+
+	return nil
+}
+
+// PreviewTemplate テンプレートを展開し、AI に送られるプロンプト全体を返す（body が空なら既定のテンプレート）
+func (u *promptTemplateUseCase) PreviewTemplate(ctx context.Context, body string, request service.QuestionGenerationRequest) (string, error) {
+	if request.Difficulty == "" {
+		request.Difficulty = domain.DifficultyMedium
+	}
+	if !domain.IsValidDifficulty(request.Difficulty) {
+		return "", domain.ErrInvalidInput
+	}
+
+	return service.PreviewPrompt(body, request)
+}
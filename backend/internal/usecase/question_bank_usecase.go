@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"quiz-app/internal/domain"
+	"quiz-app/internal/repository"
+	"time"
+)
+
+type questionBankUseCase struct {
+	bankRepo   repository.QuestionBankRepository
+	mediaStore repository.BlobStore
+}
+
+func NewQuestionBankUseCase(bankRepo repository.QuestionBankRepository, mediaStore repository.BlobStore) QuestionBankUseCase {
+	return &questionBankUseCase{
+		bankRepo:   bankRepo,
+		mediaStore: mediaStore,
+	}
+}
+
+// validateBank 問題一覧を検証し、添付されたメディアが保存済みであることを確認する
+func (u *questionBankUseCase) validateBank(ctx context.Context, bank *domain.QuestionBank) error {
+	if err := bank.Validate(); err != nil {
+		return err
+	}
+
+	for i := range bank.Questions {
+		q := &bank.Questions[i]
+		media, optionMedia, err := resolveQuestionMedia(ctx, u.mediaStore, q.Media, q.OptionMedia)
+		if err != nil {
+			return err
+		}
+		q.Media, q.OptionMedia = media, optionMedia
+	}
+	return nil
+}
+
+func (u *questionBankUseCase) CreateBank(ctx context.Context, name, description string, questions []domain.BankQuestion) (*domain.QuestionBank, error) {
+	bank := domain.NewQuestionBank(name, description, questions)
+	if err := u.validateBank(ctx, bank); err != nil {
+		return nil, err
+	}
+
+	if err := u.bankRepo.Create(ctx, bank); err != nil {
+		return nil, fmt.Errorf("failed to create question bank: %w", err)
+	}
+
+	return bank, nil
+}
+
+func (u *questionBankUseCase) GetBank(ctx context.Context, bankID string) (*domain.QuestionBank, error) {
+	if bankID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	return u.bankRepo.GetByID(ctx, bankID)
+}
+
+func (u *questionBankUseCase) ListBanks(ctx context.Context) ([]*domain.QuestionBank, error) {
+	banks, err := u.bankRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list question banks: %w", err)
+	}
+
+	return banks, nil
+}
+
+// UpdateBank バンク名・説明・問題一覧を置き換える（既存の問題IDは維持される）
+func (u *questionBankUseCase) UpdateBank(ctx context.Context, bankID, name, description string, questions []domain.BankQuestion) (*domain.QuestionBank, error) {
+	bank, err := u.GetBank(ctx, bankID)
+	if err != nil {
+		return nil, err
+	}
+
+	bank.Name = name
+	bank.Description = description
+	bank.Questions = questions
+	bank.UpdatedAt = time.Now()
+	bank.Normalize()
+	if err := u.validateBank(ctx, bank); err != nil {
+		return nil, err
+	}
+
+	if err := u.bankRepo.Update(ctx, bank); err != nil {
+		return nil, fmt.Errorf("failed to update question bank: %w", err)
+	}
+
+	return bank, nil
+}
+
+func (u *questionBankUseCase) DeleteBank(ctx context.Context, bankID string) error {
+	if _, err := u.GetBank(ctx, bankID); err != nil {
+		return err
+	}
+
+	if err := u.bankRepo.Delete(ctx, bankID); err != nil {
+		return fmt.Errorf("failed to delete question bank: %w", err)
+	}
+
+	return nil
+}
+
+// ImportBank JSON / CSV から新しい問題バンクを作成する
+func (u *questionBankUseCase) ImportBank(ctx context.Context, format domain.QuestionBankFormat, data []byte, name string) (*domain.QuestionBank, error) {
+	bank, err := domain.ParseQuestionBank(format, data, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.validateBank(ctx, bank); err != nil {
+		return nil, err
+	}
+
+	if err := u.bankRepo.Create(ctx, bank); err != nil {
+		return nil, fmt.Errorf("failed to create question bank: %w", err)
+	}
+
+	return bank, nil
+}
+
+func (u *questionBankUseCase) ExportBank(ctx context.Context, bankID string, format domain.QuestionBankFormat) ([]byte, error) {
+	bank, err := u.GetBank(ctx, bankID)
+	if err != nil {
+		return nil, err
+	}
+
+	return bank.Export(format)
+}
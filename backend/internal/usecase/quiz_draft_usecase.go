@@ -0,0 +1,281 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"quiz-app/internal/domain"
+	"time"
+)
+
+// 開始前に問題を一括生成し、レビュー（編集・並べ替え・承認）してから1問ずつ出題する
+
+// GenerateDraftQuestions 開始前のセッションに下書きの問題を count 問生成する
+// difficulties / categories を指定した場合は順番に割り当て、未指定の場合は出題順に応じて自動で選ぶ
+func (u *quizUseCase) GenerateDraftQuestions(ctx context.Context, sessionID string, count int, difficulties []domain.Difficulty, categories []string) ([]*domain.Question, error) {
+	if sessionID == "" || count <= 0 {
+		return nil, domain.ErrInvalidInput
+	}
+
+	session, err := u.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, domain.ErrSessionNotFound
+	}
+
+	if !session.IsWaiting() {
+		return nil, domain.ErrInvalidSessionStatus
+	}
+
+	existing, err := u.questionRepo.GetBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	position := domain.NextDraftPosition(existing)
+
+	drafts := make([]*domain.Question, 0, count)
+	for i := 0; i < count; i++ {
+		var difficulty domain.Difficulty
+		if len(difficulties) > 0 {
+			difficulty = difficulties[i%len(difficulties)]
+		}
+		category := ""
+		if len(categories) > 0 {
+			category = categories[i%len(categories)]
+		}
+
+		// 出題順をラウンドとみなして難易度・カテゴリを決める
+		question, err := u.produceQuestion(ctx, session, position, difficulty, category)
+		if err != nil {
+			return drafts, err
+		}
+
+		question.MarkDraft(position)
+		if err := u.questionRepo.Create(ctx, question); err != nil {
+			return drafts, fmt.Errorf("failed to save question: %w", err)
+		}
+
+		drafts = append(drafts, question)
+		position++
+	}
+
+	return drafts, nil
+}
+
+// GetDraftQuestions 出題前の問題を出題順に取得する
+func (u *quizUseCase) GetDraftQuestions(ctx context.Context, sessionID string) ([]*domain.Question, error) {
+	if sessionID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	if _, err := u.sessionRepo.GetByID(ctx, sessionID); err != nil {
+		return nil, domain.ErrSessionNotFound
+	}
+
+	questions, err := u.questionRepo.GetBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	return domain.DraftQuestions(questions), nil
+}
+
+// UpdateDraftQuestion 出題前の問題を編集する
+func (u *quizUseCase) UpdateDraftQuestion(ctx context.Context, sessionID, questionID, text string, options []string, key domain.AnswerKey, difficulty domain.Difficulty, category, explanation string) (*domain.Question, error) {
+	question, err := u.getDraftQuestion(ctx, sessionID, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := question.EditWithAnswerKey(text, options, key, difficulty, category, explanation); err != nil {
+		return nil, err
+	}
+
+	if err := u.questionRepo.Update(ctx, question); err != nil {
+		return nil, fmt.Errorf("failed to update question: %w", err)
+	}
+
+	return question, nil
+}
+
+// ReorderDraftQuestions 出題前の問題を指定したID順に並べ替える
+func (u *quizUseCase) ReorderDraftQuestions(ctx context.Context, sessionID string, questionIDs []string) ([]*domain.Question, error) {
+	drafts, err := u.GetDraftQuestions(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := domain.ReorderDrafts(drafts, questionIDs); err != nil {
+		return nil, err
+	}
+
+	for _, question := range drafts {
+		if err := u.questionRepo.Update(ctx, question); err != nil {
+			return nil, fmt.Errorf("failed to update question: %w", err)
+		}
+	}
+
+	return domain.DraftQuestions(drafts), nil
+}
+
+// ReviewDraftQuestion 出題前の問題を承認または却下する
+func (u *quizUseCase) ReviewDraftQuestion(ctx context.Context, sessionID, questionID string, approve bool) (*domain.Question, error) {
+	question, err := u.getDraftQuestion(ctx, sessionID, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if approve {
+		err = question.Approve()
+	} else {
+		err = question.Reject()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.questionRepo.Update(ctx, question); err != nil {
+		return nil, fmt.Errorf("failed to update question: %w", err)
+	}
+
+	return question, nil
+}
+
+// PublishNextQuestion 承認済みの問題のうち出題順が最も早いものを現在のラウンドで出題する
+func (u *quizUseCase) PublishNextQuestion(ctx context.Context, sessionID string) (*domain.Question, error) {
+	if sessionID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	session, err := u.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, domain.ErrSessionNotFound
+	}
+
+	if !session.IsActive() {
+		return nil, domain.ErrSessionNotActive
+	}
+
+	questions, err := u.questionRepo.GetBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	question, err := domain.NextApprovedQuestion(questions)
+	if err != nil {
+		return nil, err
+	}
+
+	// 回答受付開始時刻を記録（回答時間はこの時刻を基準にサーバー側で計測する）
+	if err := question.Publish(session.CurrentRound, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := u.questionRepo.Update(ctx, question); err != nil {
+		return nil, fmt.Errorf("failed to update question: %w", err)
+	}
+
+	if err := u.startQuestion(ctx, session, question); err != nil {
+		return nil, err
+	}
+
+	return question, nil
+}
+
+// UpdateQuestionExplanation 問題の解説を編集する（出題済みの問題も対象）
+func (u *quizUseCase) UpdateQuestionExplanation(ctx context.Context, sessionID, questionID, explanation string) (*domain.Question, error) {
+	if sessionID == "" || questionID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	if _, err := u.sessionRepo.GetByID(ctx, sessionID); err != nil {
+		return nil, domain.ErrSessionNotFound
+	}
+
+	// Firestore 実装は問題IDのみでの取得に対応していないため、セッションの問題一覧から探す
+	questions, err := u.questionRepo.GetBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	for _, question := range questions {
+		if question.ID != questionID {
+			continue
+		}
+
+		question.SetExplanation(explanation)
+		if err := u.questionRepo.Update(ctx, question); err != nil {
+			return nil, fmt.Errorf("failed to update question: %w", err)
+		}
+		return question, nil
+	}
+	return nil, domain.ErrQuestionNotFound
+}
+
+// UpdateQuestionTranslation 問題の他言語版を設定する（AI の翻訳の修正や手動での追加に使う）
+func (u *quizUseCase) UpdateQuestionTranslation(ctx context.Context, sessionID, questionID, language string, translation domain.QuestionTranslation) (*domain.Question, error) {
+	if sessionID == "" || questionID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	if _, err := u.sessionRepo.GetByID(ctx, sessionID); err != nil {
+		return nil, domain.ErrSessionNotFound
+	}
+
+	questions, err := u.questionRepo.GetBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	for _, question := range questions {
+		if question.ID != questionID {
+			continue
+		}
+
+		if err := question.SetTranslation(language, translation); err != nil {
+			return nil, err
+		}
+		if err := u.questionRepo.Update(ctx, question); err != nil {
+			return nil, fmt.Errorf("failed to update question: %w", err)
+		}
+		return question, nil
+	}
+	return nil, domain.ErrQuestionNotFound
+}
+
+// UpdateQuestionMedia 出題前の問題に画像・音声を添付する（nil で外す）
+// optionMedia は選択肢と同じ順序・同じ数で、メディアのない選択肢は nil
+func (u *quizUseCase) UpdateQuestionMedia(ctx context.Context, sessionID, questionID string, media *domain.Media, optionMedia []*domain.Media) (*domain.Question, error) {
+	question, err := u.getDraftQuestion(ctx, sessionID, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	media, optionMedia, err = resolveQuestionMedia(ctx, u.mediaStore, media, optionMedia)
+	if err != nil {
+		return nil, err
+	}
+	if err := question.SetMedia(media, optionMedia); err != nil {
+		return nil, err
+	}
+
+	if err := u.questionRepo.Update(ctx, question); err != nil {
+		return nil, fmt.Errorf("failed to update question: %w", err)
+	}
+
+	return question, nil
+}
+
+// getDraftQuestion セッション内の出題前の問題を取得する
+// Firestore 実装は問題IDのみでの取得に対応していないため、セッションの問題一覧から探す
+func (u *quizUseCase) getDraftQuestion(ctx context.Context, sessionID, questionID string) (*domain.Question, error) {
+	drafts, err := u.GetDraftQuestions(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, question := range drafts {
+		if question.ID == questionID {
+			return question, nil
+		}
+	}
+	return nil, domain.ErrQuestionNotFound
+}
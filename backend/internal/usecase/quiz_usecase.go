@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"quiz-app/internal/domain"
 	"quiz-app/internal/repository"
 	"quiz-app/internal/service"
@@ -15,8 +17,12 @@ type quizUseCase struct {
 	participantRepo repository.ParticipantRepository
 	questionRepo    repository.QuestionRepository
 	answerRepo      repository.AnswerRepository
+	bankRepo        repository.QuestionBankRepository
+	templateRepo    repository.PromptTemplateRepository
+	mediaStore      repository.BlobStore
 	aiService       *service.AIService
 	wsManager       *websocket.Manager
+	scheduler       *RoundScheduler
 }
 
 func NewQuizUseCase(
@@ -24,16 +30,24 @@ func NewQuizUseCase(
 	participantRepo repository.ParticipantRepository,
 	questionRepo repository.QuestionRepository,
 	answerRepo repository.AnswerRepository,
+	bankRepo repository.QuestionBankRepository,
+	templateRepo repository.PromptTemplateRepository,
+	mediaStore repository.BlobStore,
 	aiService *service.AIService,
 	wsManager *websocket.Manager,
+	scheduler *RoundScheduler,
 ) QuizUseCase {
 	return &quizUseCase{
 		sessionRepo:     sessionRepo,
 		participantRepo: participantRepo,
 		questionRepo:    questionRepo,
 		answerRepo:      answerRepo,
+		bankRepo:        bankRepo,
+		templateRepo:    templateRepo,
+		mediaStore:      mediaStore,
 		aiService:       aiService,
 		wsManager:       wsManager,
+		scheduler:       scheduler,
 	}
 }
 
@@ -54,36 +68,186 @@ func (u *quizUseCase) GenerateQuestion(ctx context.Context, sessionID string, ro
 
 	// 問題を順次蓄積していくため、指定されたラウンドの問題をそのまま生成
 	// 重複チェックは行わず、管理者が明示的に問題を生成できるようにする
+	question, err := u.produceQuestion(ctx, session, round, difficulty, category)
+	if err != nil {
+		return nil, err
+	}
+
+	// 回答受付開始時刻を記録（回答時間はこの時刻を基準にサーバー側で計測する）
+	question.Open(time.Now())
+
+	// 問題を保存
+	if err := u.questionRepo.Create(ctx, question); err != nil {
+		return nil, fmt.Errorf("failed to save question: %w", err)
+	}
+
+	if err := u.startQuestion(ctx, session, question); err != nil {
+		return nil, err
+	}
 
-	// 難易度をラウンドに応じて自動調整
+	return question, nil
+}
+
+// produceQuestion 問題バンクまたは AI から問題を1問用意する（保存はしない）
+func (u *quizUseCase) produceQuestion(ctx context.Context, session *domain.Session, round int, difficulty domain.Difficulty, category string) (*domain.Question, error) {
+	if session.Settings.QuestionBankID != "" {
+		// 問題バンクから未出題の問題を選ぶ
+		return u.drawFromBank(ctx, session, round, difficulty, category)
+	}
+
+	if u.aiService == nil {
+		return nil, domain.ErrAIServiceUnavailable
+	}
+
+	// 出題済みの問題（下書きを含む）と重複しないよう、履歴を渡して AI で問題生成
+	history, err := u.questionRepo.GetBySession(ctx, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	// 難易度の指定がなければセッションの設定（ラウンド数または正解率）に応じて自動調整
+	var decision *domain.DifficultyDecision
 	if difficulty == "" {
-		difficulty = u.aiService.GetDifficultyForRound(round)
+		decision, err = u.chooseDifficulty(ctx, session, round, history)
+		if err != nil {
+			return nil, err
+		}
+		difficulty = decision.Difficulty
 	}
 
-	// カテゴリをランダムに選択
+	generation := session.Settings.Generation
+
+	// カテゴリをランダムに選択（セッションでカテゴリを指定している場合はその中から選ぶ）
 	if category == "" {
-		categories := u.aiService.GetCategories()
+		categories := generation.Categories
+		if len(categories) == 0 {
+			categories = u.aiService.GetCategories()
+		}
 		if len(categories) > 0 {
 			// 簡単なランダム選択（実際にはより良い方法を使用）
 			category = categories[round%len(categories)]
 		}
 	}
 
-	// AI で問題生成
-	question, err := u.aiService.GenerateQuestion(ctx, sessionID, round, difficulty, category)
+	request := service.QuestionGenerationRequest{
+		Difficulty: difficulty,
+		Category:   category,
+		Round:      round,
+		SessionID:  session.ID,
+		Language:   generation.PrimaryLanguage(),
+
+		QuestionType:         generation.QuestionTypeForRound(round),
+		TranslationLanguages: generation.TranslationLanguages(),
+		Audience:             generation.Audience,
+		Theme:                generation.Theme,
+		ReferenceText:        generation.ReferenceText,
+	}
+	if generation.PromptTemplateID != "" {
+		template, err := u.templateRepo.GetByID(ctx, generation.PromptTemplateID)
+		if err != nil {
+			return nil, err
+		}
+		request.PromptTemplate = template.Body
+	}
+	question, err := u.aiService.GenerateQuestion(ctx, request, history, session.Settings.HistoryGroup)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate question: %w", err)
+		return nil, err
 	}
+	question.DifficultyDecision = decision
+	return question, nil
+}
 
-	// 問題を保存
-	if err := u.questionRepo.Create(ctx, question); err != nil {
-		return nil, fmt.Errorf("failed to save question: %w", err)
+// chooseDifficulty 次のラウンドの難易度を決める
+// adaptive の場合は出題済みの問題の正解率と、回答を続けられる参加者数を使う
+func (u *quizUseCase) chooseDifficulty(ctx context.Context, session *domain.Session, round int, history []*domain.Question) (*domain.DifficultyDecision, error) {
+	settings := session.Settings.Difficulty
+	if settings.Mode() != domain.DifficultyPolicyAdaptive {
+		return domain.ChooseDifficulty(settings, round, 0, nil), nil
+	}
+
+	answers, err := u.answerRepo.GetBySession(ctx, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answers: %w", err)
+	}
+	survivors, err := u.participantRepo.GetActiveBySession(ctx, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	return domain.ChooseDifficulty(settings, round, len(survivors), domain.RoundStats(history, answers)), nil
+}
+
+// startQuestion 回答受付を開始し、制限時間経過後に自動で結果処理を行う
+func (u *quizUseCase) startQuestion(ctx context.Context, session *domain.Session, question *domain.Question) error {
+	sessionID := session.ID
+	questionID := question.ID
+	timeLimit := time.Duration(session.Settings.TimeLimit) * time.Second
+	if err := u.scheduler.Start(ctx, sessionID, questionID, question.OpenedAt, timeLimit, func() {
+		u.handleRoundExpired(sessionID, questionID)
+	}); err != nil {
+		return fmt.Errorf("failed to start round: %w", err)
 	}
 
 	// WebSocketで問題開始通知
 	u.wsManager.NotifyQuestionStart(sessionID, question, session.Settings.TimeLimit)
+	return nil
+}
 
-	return question, nil
+// drawFromBank セッションに設定された問題バンクから、まだ出題していない問題を選ぶ
+func (u *quizUseCase) drawFromBank(ctx context.Context, session *domain.Session, round int, difficulty domain.Difficulty, category string) (*domain.Question, error) {
+	bank, err := u.bankRepo.GetByID(ctx, session.Settings.QuestionBankID)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := u.questionRepo.GetBySession(ctx, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	picked, err := bank.Pick(domain.UsedBankQuestionIDs(questions), difficulty, category)
+	if err != nil {
+		return nil, err
+	}
+
+	return picked.ToQuestion(session.ID, round), nil
+}
+
+// getSessionQuestion セッションの問題を取得する
+// Firestore 実装は問題IDのみでの取得に対応していないため、セッションの問題一覧から探す
+func (u *quizUseCase) getSessionQuestion(ctx context.Context, sessionID, questionID string) (*domain.Question, error) {
+	questions, err := u.questionRepo.GetBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	for _, question := range questions {
+		if question.ID == questionID {
+			return question, nil
+		}
+	}
+	return nil, domain.ErrQuestionNotFound
+}
+
+// handleRoundExpired 制限時間経過時にラウンド結果を処理する
+func (u *quizUseCase) handleRoundExpired(sessionID, questionID string) {
+	ctx := context.Background()
+
+	session, err := u.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		log.Printf("Round timer: session %s not found: %v", sessionID, err)
+		return
+	}
+
+	// 開始前・終了後のセッションは結果処理しない
+	if !session.IsActive() {
+		return
+	}
+
+	// 手動で結果処理済みの場合は何もしない
+	if _, err := u.ProcessRoundResults(ctx, sessionID, questionID); err != nil && err != domain.ErrRoundAlreadyProcessed {
+		log.Printf("Round timer: failed to process results for session %s question %s: %v", sessionID, questionID, err)
+	}
 }
 
 func (u *quizUseCase) GetCurrentQuestion(ctx context.Context, sessionID string) (*domain.Question, error) {
@@ -102,9 +266,9 @@ func (u *quizUseCase) GetCurrentQuestion(ctx context.Context, sessionID string)
 		return nil, domain.ErrQuestionNotFound
 	}
 
-	// 現在のラウンド以上の問題から最も早いものを選択
+	// 現在のラウンド以上の問題から最も早いものを選択（出題前の下書きは除く）
 	var currentQuestion *domain.Question
-	for _, q := range questions {
+	for _, q := range domain.PublishedQuestions(questions) {
 		if q.Round >= session.CurrentRound {
 			if currentQuestion == nil || q.Round < currentQuestion.Round {
 				currentQuestion = q
@@ -119,6 +283,44 @@ func (u *quizUseCase) GetCurrentQuestion(ctx context.Context, sessionID string)
 	return currentQuestion, nil
 }
 
+// GetSessionSnapshot 再接続したクライアント向けにセッション・出題中の問題・参加状況をまとめて返す
+func (u *quizUseCase) GetSessionSnapshot(ctx context.Context, sessionID, userID string) (*domain.SessionSnapshot, error) {
+	if sessionID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	session, err := u.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, domain.ErrSessionNotFound
+	}
+	snapshot := &domain.SessionSnapshot{Session: session}
+
+	if participant, err := u.participantRepo.GetByUserAndSession(ctx, userID, sessionID); err == nil {
+		snapshot.Participant = participant
+	}
+
+	if !session.IsActive() {
+		return snapshot, nil
+	}
+	question, err := u.GetCurrentQuestion(ctx, sessionID)
+	if err != nil {
+		if err == domain.ErrQuestionNotFound {
+			return snapshot, nil
+		}
+		return nil, err
+	}
+	snapshot.Question = question
+
+	// スケジューラが把握していない問題は回答を受け付けないため締め切り済みとして返す
+	remaining, open, err := u.scheduler.Remaining(ctx, sessionID, question.ID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get round: %w", err)
+	}
+	snapshot.QuestionOpen = open
+	snapshot.RemainingTime = remaining
+	return snapshot, nil
+}
+
 func (u *quizUseCase) GetAllQuestions(ctx context.Context, sessionID string) ([]*domain.Question, error) {
 	if sessionID == "" {
 		return nil, domain.ErrInvalidInput
@@ -134,10 +336,10 @@ func (u *quizUseCase) GetAllQuestions(ctx context.Context, sessionID string) ([]
 		return nil, fmt.Errorf("failed to get questions: %w", err)
 	}
 
-	return questions, nil
+	return domain.PublishedQuestions(questions), nil
 }
 
-func (u *quizUseCase) SubmitAnswer(ctx context.Context, sessionID, userID, questionID string, selectedOption, responseTime int) (*domain.Answer, error) {
+func (u *quizUseCase) SubmitAnswer(ctx context.Context, sessionID, userID, questionID string, input domain.AnswerInput, clientResponseTime int) (*domain.Answer, error) {
 	if sessionID == "" || userID == "" || questionID == "" {
 		return nil, domain.ErrInvalidInput
 	}
@@ -163,101 +365,245 @@ func (u *quizUseCase) SubmitAnswer(ctx context.Context, sessionID, userID, quest
 	}
 
 	// 問題確認
-	question, err := u.questionRepo.GetByID(ctx, questionID)
+	question, err := u.getSessionQuestion(ctx, sessionID, questionID)
 	if err != nil {
+		return nil, err
+	}
+	if !question.IsPublished() {
 		return nil, domain.ErrQuestionNotFound
 	}
 
 	// 既に回答済みかチェック
 	existingAnswer, err := u.answerRepo.GetByUserAndQuestion(ctx, userID, questionID)
 	if err == nil && existingAnswer != nil {
+		existingAnswer.Duplicate = true
 		return existingAnswer, nil
 	}
 
-	// 回答作成
-	answer := domain.NewAnswer(userID, sessionID, questionID, selectedOption, responseTime)
-	
-	// 正解判定
-	isCorrect := question.ValidateAnswer(selectedOption)
+	// 回答作成（クライアント申告の回答時間は診断用に保持のみ）
+	answer := domain.NewAnswer(userID, sessionID, questionID, -1, clientResponseTime)
+	answer.SetInput(input)
+
+	// 制限時間チェック
+	if err := u.scheduler.CheckAnswerable(ctx, sessionID, questionID, answer.AnsweredAt); err != nil {
+		return nil, err
+	}
+
+	// 回答時間はサーバー側で計測
+	answer.MeasureResponseTime(question)
+
+	// 問題形式に応じて正解判定（最も近い回答が正解となる数値問題は締め切り時に判定する）
+	isCorrect, err := question.Grade(input)
+	if err != nil {
+		return nil, err
+	}
 	answer.SetCorrect(isCorrect)
+	answer.Pending = question.ClosestWins()
+
+	// セッションの採点方式で獲得点数を算出
+	if isCorrect {
+		previousAnswers, err := u.answerRepo.GetByUserAndSession(ctx, userID, sessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get previous answers: %w", err)
+		}
+		strategy := domain.NewScoringStrategy(session.Settings.Scoring)
+		timeLimit := time.Duration(session.Settings.TimeLimit) * time.Second
+		answer.Score(strategy, question, timeLimit, domain.CurrentStreak(previousAnswers))
+	}
 
-	// 回答保存
+	// 回答保存（同時に送られた回答が先に保存された場合は、その回答を返す）
 	if err := u.answerRepo.Create(ctx, answer); err != nil {
+		if err == domain.ErrAnswerExists {
+			if existingAnswer, err := u.answerRepo.GetByUserAndQuestion(ctx, userID, questionID); err == nil {
+				existingAnswer.Duplicate = true
+				return existingAnswer, nil
+			}
+		}
 		return nil, fmt.Errorf("failed to save answer: %w", err)
 	}
 
 	// 正解の場合、参加者のスコア更新
 	if isCorrect {
-		points := question.GetPoints()
-		participant.AddCorrectAnswer(points)
+		participant.AddCorrectAnswer(answer.Points)
 		if err := u.participantRepo.Update(ctx, participant); err != nil {
 			return nil, fmt.Errorf("failed to update participant score: %w", err)
 		}
 	}
 
+	// 回答者数のみ通知（誰が何を選んだかは送らない）
+	// 回答者数は通知のみに使うため、数えられなくても回答は受け付ける
+	if answered, err := u.scheduler.RecordAnswer(ctx, sessionID, questionID); err == nil {
+		u.wsManager.NotifyAnswerCount(sessionID, questionID, answered)
+	} else if !errors.Is(err, domain.ErrRoundNotFound) {
+		log.Printf("Failed to record answer count for session %s question %s: %v", sessionID, questionID, err)
+	}
+
 	return answer, nil
 }
 
-func (u *quizUseCase) ProcessRoundResults(ctx context.Context, sessionID string, questionID string) ([]*domain.Participant, []*domain.Participant, error) {
+// questionAnswers 問題への回答を取得する
+// 問題IDだけで回答を引けないストレージ（Firestore はセッションごとに回答を保存する）もあるため、セッションの回答から絞り込む
+func (u *quizUseCase) questionAnswers(ctx context.Context, sessionID, questionID string) ([]*domain.Answer, error) {
+	sessionAnswers, err := u.answerRepo.GetBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answers: %w", err)
+	}
+
+	answers := make([]*domain.Answer, 0, len(sessionAnswers))
+	for _, answer := range sessionAnswers {
+		if answer.QuestionID == questionID {
+			answers = append(answers, answer)
+		}
+	}
+	return answers, nil
+}
+
+// gradeClosestAnswers 数値問題の回答のうち正解に最も近いものを正解とし、得点を反映する
+func (u *quizUseCase) gradeClosestAnswers(ctx context.Context, session *domain.Session, question *domain.Question) error {
+	answers, err := u.questionAnswers(ctx, session.ID, question.ID)
+	if err != nil {
+		return err
+	}
+
+	strategy := domain.NewScoringStrategy(session.Settings.Scoring)
+	timeLimit := time.Duration(session.Settings.TimeLimit) * time.Second
+	for _, answer := range domain.ClosestAnswers(question, answers) {
+		// 結果処理をやり直した場合に二重に加点しない
+		if answer.IsCorrect {
+			continue
+		}
+
+		sessionAnswers, err := u.answerRepo.GetByUserAndSession(ctx, answer.UserID, session.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get previous answers: %w", err)
+		}
+		previousAnswers := make([]*domain.Answer, 0, len(sessionAnswers))
+		for _, a := range sessionAnswers {
+			if a.ID != answer.ID {
+				previousAnswers = append(previousAnswers, a)
+			}
+		}
+
+		answer.SetCorrect(true)
+		answer.Score(strategy, question, timeLimit, domain.CurrentStreak(previousAnswers))
+		if err := u.answerRepo.Update(ctx, answer); err != nil {
+			return fmt.Errorf("failed to update answer: %w", err)
+		}
+
+		participant, err := u.participantRepo.GetByUserAndSession(ctx, answer.UserID, session.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get participant: %w", err)
+		}
+		participant.AddCorrectAnswer(answer.Points)
+		if err := u.participantRepo.Update(ctx, participant); err != nil {
+			return fmt.Errorf("failed to update participant score: %w", err)
+		}
+	}
+	return nil
+}
+
+func (u *quizUseCase) ProcessRoundResults(ctx context.Context, sessionID string, questionID string) (*domain.RoundResult, error) {
 	if sessionID == "" || questionID == "" {
-		return nil, nil, domain.ErrInvalidInput
+		return nil, domain.ErrInvalidInput
 	}
 
 	// セッション確認
 	session, err := u.sessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
-		return nil, nil, domain.ErrSessionNotFound
+		return nil, domain.ErrSessionNotFound
 	}
 
 	// 問題確認
-	question, err := u.questionRepo.GetByID(ctx, questionID)
+	question, err := u.getSessionQuestion(ctx, sessionID, questionID)
 	if err != nil {
-		return nil, nil, domain.ErrQuestionNotFound
+		return nil, err
+	}
+
+	// 結果処理済みの問題は再処理しない（出題状態を破棄した後や再起動後も問題に記録した時刻で判定する）
+	if question.IsProcessed() {
+		return nil, domain.ErrRoundAlreadyProcessed
+	}
+
+	// 回答受付を締め切る（手動処理の場合はタイマーも停止）
+	// タイマーと手動の結果処理が重なった場合は、先に締め切った方だけが採点・通知する
+	// 出題状態が残っていない問題（前の問題・保持期間切れ）は、未処理であれば結果処理する
+	if err := u.scheduler.Close(ctx, sessionID, questionID); err != nil && !errors.Is(err, domain.ErrRoundNotFound) {
+		return nil, err
+	}
+	question.MarkProcessed(time.Now())
+	if err := u.questionRepo.Update(ctx, question); err != nil {
+		return nil, fmt.Errorf("failed to mark question processed: %w", err)
+	}
+
+	// 最も近い回答が正解となる数値問題は、締め切った時点の回答で採点する
+	if question.ClosestWins() {
+		if err := u.gradeClosestAnswers(ctx, session, question); err != nil {
+			return nil, err
+		}
 	}
 
 	// アクティブな参加者取得
 	activeParticipants, err := u.participantRepo.GetActiveBySession(ctx, sessionID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get active participants: %w", err)
+		return nil, fmt.Errorf("failed to get active participants: %w", err)
+	}
+
+	answers, err := u.questionAnswers(ctx, sessionID, questionID)
+	if err != nil {
+		return nil, err
+	}
+	answerByUser := make(map[string]*domain.Answer, len(answers))
+	for _, answer := range answers {
+		answerByUser[answer.UserID] = answer
 	}
 
-	var survivors []*domain.Participant
-	var eliminated []*domain.Participant
+	result := domain.NewRoundResult(session)
+	result.QuestionID = question.ID
 
-	// 各参加者の回答をチェック
+	// 各参加者の回答をチェックし、進行方式に従って脱落・ライフ減少を反映
 	for _, participant := range activeParticipants {
-		answer, err := u.answerRepo.GetByUserAndQuestion(ctx, participant.UserID, questionID)
-		
-		if err != nil || !answer.IsCorrect {
-			// 不正解または無回答の場合は脱落
-			participant.Eliminate()
+		answer, ok := answerByUser[participant.UserID]
+		correct := ok && answer.IsCorrect
+
+		result.Apply(participant, correct)
+		if !correct && result.Mode != domain.GameModeClassic {
 			if err := u.participantRepo.Update(ctx, participant); err != nil {
-				return nil, nil, fmt.Errorf("failed to eliminate participant: %w", err)
+				return nil, fmt.Errorf("failed to update participant: %w", err)
 			}
-			eliminated = append(eliminated, participant)
-		} else {
-			// 正解の場合は生き残り
-			survivors = append(survivors, participant)
 		}
 	}
 
+	result.Finalize(session)
+
 	// WebSocketで問題終了通知
-	u.wsManager.NotifyQuestionEnd(sessionID, questionID, question.CorrectAnswer)
+	u.wsManager.NotifyQuestionEnd(sessionID, question, true)
 
 	// 少し待ってからラウンド結果通知
 	time.Sleep(2 * time.Second)
-	u.wsManager.NotifyRoundResult(sessionID, survivors, eliminated, session.CurrentRound)
+	u.wsManager.NotifyRoundResult(sessionID, result)
+
+	// 最新の順位を通知（失敗してもラウンド処理は継続する）
+	if leaderboard, err := u.buildLeaderboard(ctx, sessionID); err != nil {
+		log.Printf("Failed to build leaderboard for session %s: %v", sessionID, err)
+	} else {
+		u.wsManager.NotifyLeaderboardUpdate(sessionID, session.CurrentRound, leaderboard)
+	}
 
-	// 生き残りが1人以下の場合はゲーム終了
-	if len(survivors) <= 1 {
-		session.Finish()
+	// 進行方式ごとの終了条件を満たした場合はゲーム終了
+	if result.GameOver {
+		if err := session.Finish(); err != nil {
+			return nil, err
+		}
+		if err := u.scheduler.Cancel(ctx, sessionID); err != nil {
+			log.Printf("Failed to cancel round for session %s: %v", sessionID, err)
+		}
 		if err := u.sessionRepo.Update(ctx, session); err != nil {
-			return nil, nil, fmt.Errorf("failed to finish session: %w", err)
+			return nil, fmt.Errorf("failed to finish session: %w", err)
 		}
 		u.wsManager.NotifySessionUpdate(sessionID, session)
 	}
 
-	return survivors, eliminated, nil
+	return result, nil
 }
 
 func (u *quizUseCase) NextRound(ctx context.Context, sessionID string) error {
@@ -280,8 +626,8 @@ func (u *quizUseCase) NextRound(ctx context.Context, sessionID string) error {
 		return fmt.Errorf("failed to get active participants: %w", err)
 	}
 
-	if len(activeParticipants) <= 1 {
-		// 1人以下の場合はゲーム終了
+	if session.IsGameOver(len(activeParticipants)) {
+		// 進行方式ごとの終了条件を満たした場合はゲーム終了
 		return u.finishGame(ctx, sessionID, session)
 	}
 
@@ -300,11 +646,50 @@ func (u *quizUseCase) NextRound(ctx context.Context, sessionID string) error {
 	return nil
 }
 
+// GetAIProviderHealth 問題生成プロバイダーごとの成功率・応答時間を取得する
+func (u *quizUseCase) GetAIProviderHealth(ctx context.Context) (*domain.AIProviderHealth, error) {
+	if u.aiService == nil {
+		return nil, domain.ErrAIServiceUnavailable
+	}
+	return u.aiService.GetProviderHealth(), nil
+}
+
+func (u *quizUseCase) GetLeaderboard(ctx context.Context, sessionID string) ([]*domain.LeaderboardEntry, error) {
+	if sessionID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	if _, err := u.sessionRepo.GetByID(ctx, sessionID); err != nil {
+		return nil, domain.ErrSessionNotFound
+	}
+
+	return u.buildLeaderboard(ctx, sessionID)
+}
+
+// buildLeaderboard セッションの全参加者と回答からランキングを作成
+func (u *quizUseCase) buildLeaderboard(ctx context.Context, sessionID string) ([]*domain.LeaderboardEntry, error) {
+	participants, err := u.participantRepo.GetBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	answers, err := u.answerRepo.GetBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answers: %w", err)
+	}
+
+	return domain.BuildLeaderboard(participants, answers), nil
+}
+
 func (u *quizUseCase) finishGame(ctx context.Context, sessionID string, session *domain.Session) error {
 	if err := session.Finish(); err != nil {
 		return err
 	}
 
+	if err := u.scheduler.Cancel(ctx, sessionID); err != nil {
+		log.Printf("Failed to cancel round for session %s: %v", sessionID, err)
+	}
+
 	if err := u.sessionRepo.Update(ctx, session); err != nil {
 		return fmt.Errorf("failed to finish session: %w", err)
 	}
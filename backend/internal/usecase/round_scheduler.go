@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"quiz-app/internal/domain"
+	"quiz-app/internal/repository"
+	"sync"
+	"time"
+)
+
+const (
+	// 通信遅延を考慮して締め切り後も受け付ける猶予時間
+	answerGracePeriod = 1 * time.Second
+	// finishedRoundRetention 締め切った問題を保持する期間（遅れて届いた回答の拒否と結果処理の重複の検出に使う）
+	finishedRoundRetention = 5 * time.Minute
+	// roundTimerTimeout タイマー発火時に出題状態を確認する上限時間
+	roundTimerTimeout = 5 * time.Second
+)
+
+// RoundScheduler セッションごとの出題タイマーを管理する
+// 回答受付の状態は RoundStore に保持し（複数のサーバーで共有できる）、タイマーは出題を開始したサーバーで動かす
+type RoundScheduler struct {
+	store  repository.RoundStore
+	mu     sync.Mutex
+	timers map[string]*time.Timer // sessionID -> このサーバーで開始した問題のタイマー
+}
+
+func NewRoundScheduler(store repository.RoundStore) *RoundScheduler {
+	return &RoundScheduler{
+		store:  store,
+		timers: make(map[string]*time.Timer),
+	}
+}
+
+// Start openedAt から問題の回答受付を開始し、制限時間経過後に onExpire を呼び出す
+// 同じセッションで出題中の問題があれば差し替え、このサーバーのタイマーは破棄する
+func (s *RoundScheduler) Start(ctx context.Context, sessionID, questionID string, openedAt time.Time, timeLimit time.Duration, onExpire func()) error {
+	round := &domain.Round{
+		SessionID:  sessionID,
+		QuestionID: questionID,
+		OpenedAt:   openedAt,
+	}
+	var retainUntil time.Time
+	if timeLimit > 0 {
+		round.Deadline = openedAt.Add(timeLimit)
+		retainUntil = round.Deadline.Add(answerGracePeriod + finishedRoundRetention)
+	}
+
+	s.stopTimer(sessionID)
+	if err := s.store.Start(ctx, round, retainUntil); err != nil {
+		return err
+	}
+
+	if !round.Deadline.IsZero() {
+		s.mu.Lock()
+		var timer *time.Timer
+		timer = time.AfterFunc(time.Until(round.Deadline)+answerGracePeriod, func() {
+			// 発火したタイマーを一覧から外す（timer は s.mu を取得してから読む）
+			s.mu.Lock()
+			if s.timers[sessionID] == timer {
+				delete(s.timers, sessionID)
+			}
+			s.mu.Unlock()
+
+			if s.expire(sessionID, questionID, openedAt) && onExpire != nil {
+				onExpire()
+			}
+		})
+		s.timers[sessionID] = timer
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// expire タイマー発火時に結果処理が必要か判定する（既に締め切り済み・差し替え済みなら false）
+// 締め切りは結果処理の Close で行い、手動の結果処理と同時に発火しても一方だけが処理する
+func (s *RoundScheduler) expire(sessionID, questionID string, openedAt time.Time) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), roundTimerTimeout)
+	defer cancel()
+
+	round, err := s.store.Get(ctx, sessionID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrRoundNotFound) {
+			log.Printf("Round timer: failed to get round for session %s: %v", sessionID, err)
+		}
+		return false
+	}
+	return round.QuestionID == questionID && round.OpenedAt.Equal(openedAt) && !round.Closed
+}
+
+// CheckAnswerable 指定時刻に問題への回答を受け付けられるか判定する
+// 把握していない問題（破棄済みなど）は締め切りを判定できないため受け付けない
+func (s *RoundScheduler) CheckAnswerable(ctx context.Context, sessionID, questionID string, at time.Time) error {
+	round, err := s.store.Get(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrRoundNotFound) {
+			return domain.ErrTimeExpired
+		}
+		return err
+	}
+	if round.QuestionID != questionID || round.Closed {
+		return domain.ErrTimeExpired
+	}
+
+	if !round.Deadline.IsZero() && at.After(round.Deadline.Add(answerGracePeriod)) {
+		return domain.ErrTimeExpired
+	}
+
+	return nil
+}
+
+// Remaining 指定時刻での問題の締め切りまでの残り時間と、回答を受け付けているかを返す
+// 把握していない問題の場合は受け付けていない扱い
+func (s *RoundScheduler) Remaining(ctx context.Context, sessionID, questionID string, at time.Time) (remaining time.Duration, open bool, err error) {
+	round, err := s.store.Get(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrRoundNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	if round.QuestionID != questionID || round.Closed {
+		return 0, false, nil
+	}
+	if round.Deadline.IsZero() {
+		return 0, true, nil
+	}
+	if remaining = round.Deadline.Sub(at); remaining < 0 {
+		remaining = 0
+	}
+	return remaining, true, nil
+}
+
+// RecordAnswer 出題中の問題の回答者数を1増やし、増やした後の数を返す
+// 出題中の問題でない場合は domain.ErrRoundNotFound を返す
+func (s *RoundScheduler) RecordAnswer(ctx context.Context, sessionID, questionID string) (int, error) {
+	return s.store.RecordAnswer(ctx, sessionID, questionID)
+}
+
+// Close 結果処理のために問題の回答受付を締め切り、タイマーを停止する
+// 既に結果処理を始めた問題の場合は（他のサーバーで始めた場合も）domain.ErrRoundAlreadyProcessed を返す
+// 把握していない問題（前の問題・破棄済みなど）は domain.ErrRoundNotFound を返し、出題中の別の問題のタイマーは止めない
+func (s *RoundScheduler) Close(ctx context.Context, sessionID, questionID string) error {
+	now := time.Now()
+	if err := s.store.Process(ctx, sessionID, questionID, now, now.Add(finishedRoundRetention)); err != nil {
+		return err
+	}
+
+	s.stopTimer(sessionID)
+	return nil
+}
+
+// Cancel 結果処理を行わずにセッションの出題タイマーを破棄する（スキップ・ゲーム終了時）
+// 以降その問題への回答は締め切り扱いになる
+func (s *RoundScheduler) Cancel(ctx context.Context, sessionID string) error {
+	s.stopTimer(sessionID)
+
+	now := time.Now()
+	return s.store.Cancel(ctx, sessionID, now, now.Add(finishedRoundRetention))
+}
+
+// stopTimer このサーバーで開始したセッションのタイマーを停止する
+// 他のサーバーで締め切った問題のタイマーは、発火時に expire が締め切り済みと判定する
+func (s *RoundScheduler) stopTimer(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if timer, ok := s.timers[sessionID]; ok {
+		timer.Stop()
+		delete(s.timers, sessionID)
+	}
+}
@@ -2,10 +2,12 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"quiz-app/internal/domain"
 	"quiz-app/internal/repository"
 	"quiz-app/internal/websocket"
+	"time"
 )
 
 type sessionUseCase struct {
@@ -116,10 +118,14 @@ func (u *sessionUseCase) FinishSession(ctx context.Context, sessionID string) er
 	return nil
 }
 
-func (u *sessionUseCase) JoinSession(ctx context.Context, sessionID, userID, displayName string) (*domain.Participant, error) {
+func (u *sessionUseCase) JoinSession(ctx context.Context, sessionID, userID, displayName, language string) (*domain.Participant, error) {
 	if sessionID == "" || userID == "" || displayName == "" {
 		return nil, domain.ErrInvalidInput
 	}
+	language = domain.NormalizeLanguage(language)
+	if language != "" && !domain.IsSupportedLanguage(language) {
+		return nil, domain.ErrInvalidInput
+	}
 
 	// セッション存在確認
 	session, err := u.sessionRepo.GetByID(ctx, sessionID)
@@ -135,6 +141,14 @@ func (u *sessionUseCase) JoinSession(ctx context.Context, sessionID, userID, dis
 	// 既に参加しているかチェック
 	existingParticipant, err := u.participantRepo.GetByUserAndSession(ctx, userID, sessionID)
 	if err == nil && existingParticipant != nil {
+		// 再参加時に言語が指定された場合は表示言語を切り替える
+		if language != "" && language != existingParticipant.Language {
+			existingParticipant.Language = language
+			if err := u.participantRepo.Update(ctx, existingParticipant); err != nil {
+				return nil, fmt.Errorf("failed to update participant: %w", err)
+			}
+			u.wsManager.SetUserLanguage(sessionID, userID, language)
+		}
 		return existingParticipant, nil
 	}
 
@@ -161,12 +175,18 @@ func (u *sessionUseCase) JoinSession(ctx context.Context, sessionID, userID, dis
 
 	// 参加者作成
 	participant := domain.NewParticipant(userID, sessionID, displayName)
+	participant.Lives = session.Settings.InitialLives()
+	participant.Language = language
 	
 	if err := u.participantRepo.Create(ctx, participant); err != nil {
 		return nil, fmt.Errorf("failed to create participant: %w", err)
 	}
 
 	// WebSocketで参加通知は Client 側で自動送信される
+	// 接続済みのクライアントにも問題の表示言語を反映する
+	if language != "" {
+		u.wsManager.SetUserLanguage(sessionID, userID, language)
+	}
 
 	return participant, nil
 }
@@ -233,5 +253,52 @@ func (u *sessionUseCase) DeleteSession(ctx context.Context, sessionID string) er
 	// WebSocketで削除通知
 	u.wsManager.NotifySessionDeleted(sessionID)
 
+	return nil
+}
+
+// UpdateGenerationSettings AI 問題生成のテーマ・カテゴリ・テンプレートを変更する
+// 変更は次に生成する問題から反映される
+func (u *sessionUseCase) UpdateGenerationSettings(ctx context.Context, sessionID string, generation domain.GenerationSettings) (*domain.Session, error) {
+	generation.Normalize()
+	if err := generation.Validate(); err != nil {
+		return nil, err
+	}
+
+	session, err := u.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, domain.ErrSessionNotFound
+	}
+	if session.IsFinished() {
+		return nil, domain.ErrSessionNotActive
+	}
+
+	session.Settings.Generation = generation
+	session.UpdatedAt = time.Now()
+	if err := u.sessionRepo.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+
+	return session, nil
+}
+
+// AuthorizeSessionAccess WebSocket でセッションに参加・購読できるか確認する
+// 管理者はセッションが存在すれば可、それ以外は参加者である必要がある
+func (u *sessionUseCase) AuthorizeSessionAccess(ctx context.Context, sessionID, userID string, isAdmin bool) error {
+	if _, err := u.sessionRepo.GetByID(ctx, sessionID); err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return domain.ErrSessionNotFound
+		}
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if isAdmin {
+		return nil
+	}
+
+	if _, err := u.participantRepo.GetByUserAndSession(ctx, userID, sessionID); err != nil {
+		if errors.Is(err, domain.ErrParticipantNotFound) {
+			return domain.ErrParticipantNotFound
+		}
+		return fmt.Errorf("failed to get participant: %w", err)
+	}
 	return nil
 }
\ No newline at end of file
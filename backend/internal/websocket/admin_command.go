@@ -0,0 +1,173 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+)
+
+const (
+	// adminCommandTimeout admin_control のコマンドの実行の上限時間
+	adminCommandTimeout = 30 * time.Second
+	// adminCommandQueueSize 接続ごとに実行待ちにできる admin_control の上限（溢れた場合は BUSY を返す）
+	adminCommandQueueSize = 16
+)
+
+// AdminCommand admin_control で実行できるコマンド（管理画面の REST API と同じ操作）
+type AdminCommand string
+
+const (
+	AdminCommandStart          AdminCommand = "start"           // セッション開始
+	AdminCommandFinish         AdminCommand = "finish"          // セッション終了
+	AdminCommandNextRound      AdminCommand = "next_round"      // 次のラウンドへ
+	AdminCommandSkip           AdminCommand = "skip"            // 出題中の問題をスキップ
+	AdminCommandProcessResults AdminCommand = "process_results" // ラウンドの結果処理（questionId が必要）
+	AdminCommandRevival        AdminCommand = "revival"         // 敗者復活（count が必要）
+)
+
+// AdminCommandRequest admin_control の data
+type AdminCommandRequest struct {
+	RequestID  string       `json:"requestId"` // 応答の admin_control_result にそのまま返す
+	Command    AdminCommand `json:"command"`
+	QuestionID string       `json:"questionId,omitempty"`
+	Count      int          `json:"count,omitempty"`
+}
+
+// AdminCommandExecutor admin_control のコマンドを UseCase に振り分けて実行する（handler.AdminCommandHandler が実装する）
+// 失敗した理由をクライアントに返す場合は *AdminCommandError を返す
+type AdminCommandExecutor interface {
+	ExecuteAdminCommand(ctx context.Context, sessionID string, req AdminCommandRequest) (interface{}, error)
+}
+
+// AdminCommandError admin_control_result で返すエラー（code は REST API のエラーコードと同じ）
+type AdminCommandError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *AdminCommandError) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// SetAdminCommandExecutor admin_control の実行に使うハンドラーを設定する（未設定の間はコマンドを受け付けない）
+func (m *Manager) SetAdminCommandExecutor(executor AdminCommandExecutor) {
+	m.hub.SetAdminCommandExecutor(executor)
+}
+
+// handleAdminControl 管理者のコマンドを実行し、結果を requestId を付けて送信者に返す
+// 対象のセッションはメッセージの sessionId（未指定なら接続中のセッション）
+func (c *Client) handleAdminControl(msg ClientMessage) {
+	// c.SessionID は受信処理の goroutine でのみ変更されるため、ここで読んだ値をコマンドの実行に渡す
+	// （実行用の goroutine では c.SessionID を読まない）
+	primarySessionID := c.SessionID
+
+	var req AdminCommandRequest
+	raw, err := json.Marshal(msg.Data)
+	if err == nil {
+		err = json.Unmarshal(raw, &req)
+	}
+	if err != nil {
+		c.sendAdminCommandResult(primarySessionID, req, nil, &AdminCommandError{Code: "BAD_REQUEST", Message: "Invalid command"})
+		return
+	}
+
+	if !c.IsAdmin {
+		c.sendAdminCommandResult(primarySessionID, req, nil, &AdminCommandError{Code: "FORBIDDEN", Message: "Admin access required"})
+		return
+	}
+
+	sessionID := msg.SessionID
+	if sessionID == "" {
+		sessionID = primarySessionID
+	}
+	if sessionID == "" {
+		c.sendAdminCommandResult(primarySessionID, req, nil, &AdminCommandError{Code: "BAD_REQUEST", Message: "Session ID is required"})
+		return
+	}
+
+	executor := c.hub.getAdminCommandExecutor()
+	if executor == nil {
+		c.sendAdminCommandResult(primarySessionID, req, nil, &AdminCommandError{Code: "UNAVAILABLE", Message: "Admin commands are not available"})
+		return
+	}
+
+	// 結果処理など時間のかかるコマンドの間も ping や回答を受け付けられるよう、受信処理とは別の goroutine で実行する
+	if !c.enqueueAdminCommand(func() { c.executeAdminCommand(executor, primarySessionID, sessionID, req) }) {
+		c.sendAdminCommandResult(primarySessionID, req, nil, &AdminCommandError{Code: "BUSY", Message: "Too many pending commands"})
+	}
+}
+
+// enqueueAdminCommand コマンドを接続ごとの goroutine で受信順に実行する（実行待ちが上限に達している場合は false）
+// ReadPump の goroutine からのみ呼ぶ
+func (c *Client) enqueueAdminCommand(run func()) bool {
+	return enqueueWork(&c.adminCommands, adminCommandQueueSize, run)
+}
+
+// stopAdminCommands 実行待ちのコマンドを実行し終えたら goroutine を終了する（切断時に ReadPump から呼ぶ）
+func (c *Client) stopAdminCommands() {
+	stopWork(&c.adminCommands)
+}
+
+// executeAdminCommand sessionID に対してコマンドを実行する（primarySessionID は受け付けた時点の接続中のセッション）
+func (c *Client) executeAdminCommand(executor AdminCommandExecutor, primarySessionID, sessionID string, req AdminCommandRequest) {
+	ctx, cancel := context.WithTimeout(context.Background(), adminCommandTimeout)
+	defer cancel()
+
+	result, err := executor.ExecuteAdminCommand(ctx, sessionID, req)
+	if err != nil {
+		var cmdErr *AdminCommandError
+		if !errors.As(err, &cmdErr) {
+			log.Printf("Failed to execute admin command: Command=%s, SessionID=%s: %v", req.Command, sessionID, err)
+			cmdErr = &AdminCommandError{Code: "INTERNAL_ERROR", Message: "Failed to execute command"}
+		}
+		c.sendAdminCommandResult(primarySessionID, req, nil, cmdErr)
+		return
+	}
+	c.sendAdminCommandResult(primarySessionID, req, result, nil)
+}
+
+// sendAdminCommandResult コマンドの結果を REST API の応答と同じ形（success / data / error）で返す
+// primarySessionID はコマンドを受け付けた時点の接続中のセッション
+func (c *Client) sendAdminCommandResult(primarySessionID string, req AdminCommandRequest, result interface{}, cmdErr *AdminCommandError) {
+	data := map[string]interface{}{
+		"requestId": req.RequestID,
+		"command":   string(req.Command),
+		"success":   cmdErr == nil,
+	}
+	if cmdErr != nil {
+		data["error"] = cmdErr
+	} else if result != nil {
+		data["data"] = result
+	}
+
+	// 実行中に切断されている場合があるため、接続中か確認してから送る
+	c.hub.sendToClient(c, Message{
+		Type:      string(MessageTypeAdminControlResult),
+		SessionID: primarySessionID,
+		Data:      data,
+		Timestamp: getCurrentTimestamp(),
+	})
+}
+
+// sendToClient 接続中のクライアントにのみ送る（送信が詰まっている場合は切り離す）
+func (h *Hub) sendToClient(client *Client, msg Message) {
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("Failed to marshal message: %v", err)
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if !h.clients[client] {
+		return
+	}
+	select {
+	case client.send <- msgBytes:
+	default:
+		h.dropClient(client)
+	}
+}
@@ -0,0 +1,210 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAdminCommandExecutor struct {
+	err       error
+	sessionID string
+	requests  []AdminCommandRequest
+	release   chan struct{} // 設定した場合は閉じられるまで実行を終えない
+	executed  chan string   // 設定した場合は実行したコマンドの requestId を送る
+}
+
+func (f *fakeAdminCommandExecutor) ExecuteAdminCommand(ctx context.Context, sessionID string, req AdminCommandRequest) (interface{}, error) {
+	if f.release != nil {
+		<-f.release
+	}
+	f.sessionID = sessionID
+	f.requests = append(f.requests, req)
+	if f.executed != nil {
+		defer func() { f.executed <- req.RequestID }()
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return map[string]string{"message": "ok"}, nil
+}
+
+func TestHandleAdminControl(t *testing.T) {
+	newTestClient := func(hub *Hub, userID string, isAdmin bool) *Client {
+		client := &Client{hub: hub, send: make(chan []byte, 8), UserID: userID, SessionID: "s1", IsAdmin: isAdmin}
+		hub.registerClient(client)
+		for len(client.send) > 0 {
+			<-client.send
+		}
+		return client
+	}
+	receive := func(t *testing.T, client *Client) map[string]interface{} {
+		var msg Message
+		select {
+		case data := <-client.send:
+			require.NoError(t, json.Unmarshal(data, &msg))
+		case <-time.After(time.Second):
+			t.Fatalf("no message for %s", client.UserID)
+		}
+		require.Equal(t, string(MessageTypeAdminControlResult), msg.Type)
+		return msg.Data.(map[string]interface{})
+	}
+	control := func(client *Client, sessionID string, data interface{}) {
+		client.handleMessage(ClientMessage{Type: "admin_control", SessionID: sessionID, Data: data})
+	}
+
+	t.Run("コマンドを実行し、requestId を付けた結果を送信者にのみ返すこと", func(t *testing.T) {
+		hub := NewHub()
+		executor := &fakeAdminCommandExecutor{}
+		hub.SetAdminCommandExecutor(executor)
+		admin := newTestClient(hub, "admin", true)
+		player := newTestClient(hub, "player", false)
+		<-admin.send // player の参加通知
+
+		control(admin, "", map[string]interface{}{"requestId": "r1", "command": "process_results", "questionId": "q1"})
+
+		result := receive(t, admin)
+		assert.Equal(t, "r1", result["requestId"])
+		assert.Equal(t, "process_results", result["command"])
+		assert.Equal(t, true, result["success"])
+		assert.Equal(t, map[string]interface{}{"message": "ok"}, result["data"])
+		assert.Empty(t, player.send)
+
+		require.Len(t, executor.requests, 1)
+		assert.Equal(t, "s1", executor.sessionID)
+		assert.Equal(t, AdminCommandProcessResults, executor.requests[0].Command)
+		assert.Equal(t, "q1", executor.requests[0].QuestionID)
+	})
+
+	t.Run("メッセージの sessionId を指定したセッションに対して実行すること", func(t *testing.T) {
+		hub := NewHub()
+		executor := &fakeAdminCommandExecutor{}
+		hub.SetAdminCommandExecutor(executor)
+		admin := newTestClient(hub, "admin", true)
+
+		control(admin, "s2", map[string]interface{}{"requestId": "r2", "command": "revival", "count": 3})
+
+		assert.Equal(t, true, receive(t, admin)["success"])
+		assert.Equal(t, "s2", executor.sessionID)
+		assert.Equal(t, 3, executor.requests[0].Count)
+	})
+
+	t.Run("管理者以外のコマンドは実行せず FORBIDDEN を返すこと", func(t *testing.T) {
+		hub := NewHub()
+		executor := &fakeAdminCommandExecutor{}
+		hub.SetAdminCommandExecutor(executor)
+		player := newTestClient(hub, "player", false)
+
+		control(player, "", map[string]interface{}{"requestId": "r3", "command": "finish"})
+
+		result := receive(t, player)
+		assert.Equal(t, "r3", result["requestId"])
+		assert.Equal(t, false, result["success"])
+		assert.Equal(t, "FORBIDDEN", result["error"].(map[string]interface{})["code"])
+		assert.Empty(t, executor.requests)
+	})
+
+	t.Run("実行時のエラーを返し、想定外のエラーは内容を伏せること", func(t *testing.T) {
+		hub := NewHub()
+		executor := &fakeAdminCommandExecutor{err: &AdminCommandError{Code: "CONFLICT", Message: "Session is not active"}}
+		hub.SetAdminCommandExecutor(executor)
+		admin := newTestClient(hub, "admin", true)
+
+		control(admin, "", map[string]interface{}{"requestId": "r4", "command": "skip"})
+		result := receive(t, admin)
+		assert.Equal(t, false, result["success"])
+		assert.Equal(t, map[string]interface{}{"code": "CONFLICT", "message": "Session is not active"}, result["error"])
+
+		executor.err = errors.New("database is down")
+		control(admin, "", map[string]interface{}{"requestId": "r5", "command": "skip"})
+		result = receive(t, admin)
+		assert.Equal(t, "r5", result["requestId"])
+		assert.Equal(t, "INTERNAL_ERROR", result["error"].(map[string]interface{})["code"])
+		assert.NotContains(t, result["error"].(map[string]interface{})["message"], "database")
+	})
+
+	t.Run("実行中のコマンドがあっても他のメッセージを処理し、コマンドは受信順に実行すること", func(t *testing.T) {
+		hub := NewHub()
+		executor := &fakeAdminCommandExecutor{release: make(chan struct{})}
+		hub.SetAdminCommandExecutor(executor)
+		admin := newTestClient(hub, "admin", true)
+
+		control(admin, "", map[string]interface{}{"requestId": "r6", "command": "process_results", "questionId": "q1"})
+		control(admin, "", map[string]interface{}{"requestId": "r7", "command": "next_round"})
+		admin.handleMessage(ClientMessage{Type: string(MessageTypePing)})
+
+		var pong Message
+		require.NoError(t, json.Unmarshal(<-admin.send, &pong))
+		assert.Equal(t, string(MessageTypePong), pong.Type)
+
+		close(executor.release)
+		assert.Equal(t, "r6", receive(t, admin)["requestId"])
+		assert.Equal(t, "r7", receive(t, admin)["requestId"])
+		admin.stopAdminCommands()
+	})
+
+	t.Run("実行中に切断された場合は結果を送らないこと", func(t *testing.T) {
+		hub := NewHub()
+		executor := &fakeAdminCommandExecutor{release: make(chan struct{})}
+		hub.SetAdminCommandExecutor(executor)
+		admin := newTestClient(hub, "admin", true)
+		hub.leaveGrace = 0
+
+		executor.executed = make(chan string, 2)
+
+		control(admin, "", map[string]interface{}{"requestId": "r8", "command": "finish"})
+		hub.unregisterClient(admin)
+		close(executor.release)
+		// 2つ目のコマンドが実行された時点で1つ目の結果の送信は終わっている
+		control(admin, "", map[string]interface{}{"requestId": "r9", "command": "finish"})
+		assert.Equal(t, "r8", <-executor.executed)
+		assert.Equal(t, "r9", <-executor.executed)
+		admin.stopAdminCommands()
+
+		_, open := <-admin.send
+		assert.False(t, open)
+	})
+
+	t.Run("実行中に主セッションを切り替えても、受け付けた時点のセッションで結果を返すこと", func(t *testing.T) {
+		hub := NewHub()
+		executor := &fakeAdminCommandExecutor{release: make(chan struct{})}
+		hub.SetAdminCommandExecutor(executor)
+		admin := newTestClient(hub, "admin", true)
+
+		control(admin, "", map[string]interface{}{"requestId": "r10", "command": "skip"})
+		// 受信処理の goroutine でのセッション切り替えと実行中のコマンドが競合しないこと（-race で確認）
+		close(executor.release)
+		require.True(t, hub.MoveClient(admin, "s2"))
+
+		// 切り替え先のセッションへの参加通知の後に届く
+		var msg Message
+		for msg.Type != string(MessageTypeAdminControlResult) {
+			select {
+			case data := <-admin.send:
+				require.NoError(t, json.Unmarshal(data, &msg))
+			case <-time.After(time.Second):
+				t.Fatal("no admin_control_result")
+			}
+		}
+		assert.Equal(t, "s1", msg.SessionID)
+		assert.Equal(t, "s1", executor.sessionID)
+		admin.stopAdminCommands()
+	})
+
+	t.Run("data の形式が違う場合は BAD_REQUEST を返すこと", func(t *testing.T) {
+		hub := NewHub()
+		executor := &fakeAdminCommandExecutor{}
+		hub.SetAdminCommandExecutor(executor)
+		admin := newTestClient(hub, "admin", true)
+
+		control(admin, "", "start")
+
+		assert.Equal(t, "BAD_REQUEST", receive(t, admin)["error"].(map[string]interface{})["code"])
+		assert.Empty(t, executor.requests)
+	})
+}
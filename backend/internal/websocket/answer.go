@@ -0,0 +1,233 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"quiz-app/internal/domain"
+)
+
+const (
+	// answerSubmitTimeout WebSocket で受け付けた回答の採点・保存の上限時間
+	answerSubmitTimeout = 10 * time.Second
+	// answerQueueSize 接続ごとに採点待ちにできる回答の上限（溢れた場合は busy で受け付けない）
+	answerQueueSize = 8
+	// answerCountInterval 回答者数を通知する最短の間隔（間に届いた回答は最後の数にまとめて通知する）
+	answerCountInterval = 500 * time.Millisecond
+)
+
+// AnswerSubmitter WebSocket で受け付けた回答を REST と同じ経路で採点・保存する（usecase.QuizUseCase が実装する）
+// 既に回答済みの場合は既存の回答を Duplicate を立てて返す
+type AnswerSubmitter interface {
+	SubmitAnswer(ctx context.Context, sessionID, userID, questionID string, input domain.AnswerInput, clientResponseTime int) (*domain.Answer, error)
+}
+
+// AnswerAckStatus 回答の受付結果（answer_ack で回答した本人にのみ送る）
+type AnswerAckStatus string
+
+const (
+	AnswerAckAccepted   AnswerAckStatus = "accepted"   // 採点・保存した
+	AnswerAckDuplicate  AnswerAckStatus = "duplicate"  // 既に回答済み（最初の回答が有効）
+	AnswerAckTooLate    AnswerAckStatus = "too_late"   // 締め切り後
+	AnswerAckEliminated AnswerAckStatus = "eliminated" // 脱落済み
+	AnswerAckRejected   AnswerAckStatus = "rejected"   // 参加者でない・回答の形式が違うなど（reason を参照）
+)
+
+// answerSubmitData answer_submit の data（REST の SubmitAnswerRequest と同じ項目）
+type answerSubmitData struct {
+	QuestionID      string   `json:"questionId"`
+	SelectedOption  *int     `json:"selectedOption"`
+	SelectedOptions []int    `json:"selectedOptions"`
+	NumericValue    *float64 `json:"numericValue"`
+	ResponseTime    int      `json:"responseTime"`
+}
+
+// SetAnswerSubmitter WebSocket の回答の採点に使う UseCase を設定する（未設定の間は回答を受け付けない）
+func (m *Manager) SetAnswerSubmitter(submitter AnswerSubmitter) {
+	m.hub.SetAnswerSubmitter(submitter)
+}
+
+// NotifyAnswerCount 問題の回答者数を通知する（誰が何を選んだかは送らない）
+// 回答のたびには送らず、answerCountInterval ごとに最新の数を送る
+func (m *Manager) NotifyAnswerCount(sessionID, questionID string, answered int) {
+	m.hub.NotifyAnswerCount(sessionID, questionID, answered)
+}
+
+// answerCountWindow 回答者数の通知を間引いている間の状態
+type answerCountWindow struct {
+	questionID string
+	sent       int // 最後に通知した数
+	pending    int // 次に通知する数（0 は通知待ちなし）
+	timer      *time.Timer
+}
+
+// NotifyAnswerCount 最初の回答者数はすぐに通知し、間隔内に届いた数は間隔の終わりに最大のものだけ通知する
+func (h *Hub) NotifyAnswerCount(sessionID, questionID string, answered int) {
+	h.answerCountMu.Lock()
+	if window, ok := h.answerCounts[sessionID]; ok {
+		if window.questionID == questionID {
+			if answered > window.sent && answered > window.pending {
+				window.pending = answered
+			}
+			h.answerCountMu.Unlock()
+			return
+		}
+		// 次の問題に移った場合は前の問題の通知待ちを破棄する
+		window.timer.Stop()
+	}
+	h.startAnswerCountWindow(sessionID, &answerCountWindow{questionID: questionID, sent: answered})
+	h.answerCountMu.Unlock()
+
+	h.broadcastAnswerCount(sessionID, questionID, answered)
+}
+
+// startAnswerCountWindow 間隔の終わりに通知待ちの数を送るタイマーを開始する（h.answerCountMu を取得済みで呼ぶ）
+func (h *Hub) startAnswerCountWindow(sessionID string, window *answerCountWindow) {
+	h.answerCounts[sessionID] = window
+	window.timer = time.AfterFunc(h.answerCountInterval, func() {
+		h.flushAnswerCount(sessionID, window)
+	})
+}
+
+// flushAnswerCount 通知待ちの数があれば送って次の間隔を始め、なければ間引きを終える
+func (h *Hub) flushAnswerCount(sessionID string, window *answerCountWindow) {
+	h.answerCountMu.Lock()
+	if h.answerCounts[sessionID] != window {
+		h.answerCountMu.Unlock()
+		return
+	}
+	if window.pending == 0 {
+		delete(h.answerCounts, sessionID)
+		h.answerCountMu.Unlock()
+		return
+	}
+	answered := window.pending
+	window.sent, window.pending = answered, 0
+	h.startAnswerCountWindow(sessionID, window)
+	h.answerCountMu.Unlock()
+
+	h.broadcastAnswerCount(sessionID, window.questionID, answered)
+}
+
+// broadcastAnswerCount 回答者数は次の通知で古くなるため、再送用には保持しない
+func (h *Hub) broadcastAnswerCount(sessionID, questionID string, answered int) {
+	h.BroadcastTransientToSession(sessionID, Message{
+		Type:      string(MessageTypeAnswerSubmitted),
+		SessionID: sessionID,
+		Data: map[string]interface{}{
+			"questionId":    questionID,
+			"answeredCount": answered,
+		},
+		Timestamp: getCurrentTimestamp(),
+	})
+}
+
+// handleAnswerSubmit 回答を採点し、結果を送信者にのみ返す
+// 回答者数の通知は採点した UseCase が NotifyAnswerCount で行う
+func (c *Client) handleAnswerSubmit(msg ClientMessage) {
+	// c.SessionID は受信処理の goroutine でのみ変更されるため、ここで読んだ値を採点に渡す
+	sessionID := c.SessionID
+
+	var data answerSubmitData
+	raw, err := json.Marshal(msg.Data)
+	if err == nil {
+		err = json.Unmarshal(raw, &data)
+	}
+	if err != nil || data.QuestionID == "" || sessionID == "" {
+		c.sendAnswerAck(sessionID, data.QuestionID, AnswerAckRejected, "invalid_request", nil)
+		return
+	}
+
+	submitter := c.hub.getAnswerSubmitter()
+	if submitter == nil {
+		c.sendAnswerAck(sessionID, data.QuestionID, AnswerAckRejected, "unavailable", nil)
+		return
+	}
+
+	// 採点・保存の間も ping や他のメッセージを受け付けられるよう、受信処理とは別の goroutine で受信順に採点する
+	if !enqueueWork(&c.answers, answerQueueSize, func() { c.submitAnswer(submitter, sessionID, data) }) {
+		c.sendAnswerAck(sessionID, data.QuestionID, AnswerAckRejected, "busy", nil)
+	}
+}
+
+// stopAnswerSubmits 採点待ちの回答を採点し終えたら goroutine を終了する（切断時に ReadPump から呼ぶ）
+func (c *Client) stopAnswerSubmits() {
+	stopWork(&c.answers)
+}
+
+// submitAnswer 回答を採点・保存し、受付結果を返す（採点用の goroutine で実行する）
+func (c *Client) submitAnswer(submitter AnswerSubmitter, sessionID string, data answerSubmitData) {
+	ctx, cancel := context.WithTimeout(context.Background(), answerSubmitTimeout)
+	defer cancel()
+
+	answer, err := submitter.SubmitAnswer(ctx, sessionID, c.UserID, data.QuestionID, domain.AnswerInput{
+		SelectedOption:  data.SelectedOption,
+		SelectedOptions: data.SelectedOptions,
+		NumericValue:    data.NumericValue,
+	}, data.ResponseTime)
+	if err != nil {
+		status, reason := answerAckForError(err)
+		if status == AnswerAckRejected && reason == "internal_error" {
+			log.Printf("Failed to submit answer: UserID=%s, SessionID=%s: %v", c.UserID, sessionID, err)
+		}
+		c.sendAnswerAck(sessionID, data.QuestionID, status, reason, nil)
+		return
+	}
+
+	status := AnswerAckAccepted
+	if answer.Duplicate {
+		status = AnswerAckDuplicate
+	}
+	c.sendAnswerAck(sessionID, data.QuestionID, status, "", answer)
+}
+
+// answerAckForError 採点時のエラーを受付結果と理由に変換する
+func answerAckForError(err error) (AnswerAckStatus, string) {
+	switch {
+	case errors.Is(err, domain.ErrTimeExpired):
+		return AnswerAckTooLate, ""
+	case errors.Is(err, domain.ErrParticipantEliminated):
+		return AnswerAckEliminated, ""
+	case errors.Is(err, domain.ErrAnswerExists):
+		return AnswerAckDuplicate, ""
+	case errors.Is(err, domain.ErrParticipantNotFound):
+		return AnswerAckRejected, "not_participant"
+	case errors.Is(err, domain.ErrInvalidAnswer):
+		return AnswerAckRejected, "invalid_answer"
+	case errors.Is(err, domain.ErrQuestionNotFound):
+		return AnswerAckRejected, "question_not_found"
+	case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrSessionNotActive):
+		return AnswerAckRejected, "session_not_active"
+	default:
+		return AnswerAckRejected, "internal_error"
+	}
+}
+
+// sendAnswerAck 回答の受付結果を送る（受け付けた場合は REST の応答と同じく正誤・得点を含める）
+// 採点中に切断されている場合があるため、接続中か確認してから送る
+func (c *Client) sendAnswerAck(sessionID, questionID string, status AnswerAckStatus, reason string, answer *domain.Answer) {
+	data := map[string]interface{}{
+		"questionId": questionID,
+		"status":     string(status),
+	}
+	if reason != "" {
+		data["reason"] = reason
+	}
+	if answer != nil {
+		data["answerId"] = answer.ID
+		data["isCorrect"] = answer.IsCorrect
+		data["pending"] = answer.Pending
+		data["points"] = answer.Points
+		data["responseTime"] = answer.ResponseTime
+	}
+
+	c.hub.sendToClient(c, Message{
+		Type:      string(MessageTypeAnswerAck),
+		SessionID: sessionID,
+		Data:      data,
+		Timestamp: getCurrentTimestamp(),
+	})
+}
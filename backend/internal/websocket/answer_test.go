@@ -0,0 +1,258 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"quiz-app/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAnswerSubmitter 1人1回まで受け付け、2回目以降は最初の回答を返す
+type fakeAnswerSubmitter struct {
+	err       error
+	answers   map[string]*domain.Answer
+	inputs    []domain.AnswerInput
+	release   chan struct{} // 設定した場合は閉じられるまで採点を終えない
+	submitted chan string   // 設定した場合は採点した回答の問題IDを送る
+}
+
+func (f *fakeAnswerSubmitter) SubmitAnswer(ctx context.Context, sessionID, userID, questionID string, input domain.AnswerInput, clientResponseTime int) (*domain.Answer, error) {
+	if f.release != nil {
+		<-f.release
+	}
+	if f.submitted != nil {
+		defer func() { f.submitted <- questionID }()
+	}
+	f.inputs = append(f.inputs, input)
+	if f.err != nil {
+		return nil, f.err
+	}
+	if existing, ok := f.answers[userID]; ok {
+		existing.Duplicate = true
+		return existing, nil
+	}
+	answer := domain.NewAnswer(userID, sessionID, questionID, -1, clientResponseTime)
+	answer.SetInput(input)
+	answer.SetCorrect(input.SelectedOption != nil && *input.SelectedOption == 1)
+	answer.ID = "a-" + userID
+	f.answers[userID] = answer
+	return answer, nil
+}
+
+func TestHandleAnswerSubmit(t *testing.T) {
+	newTestClient := func(hub *Hub, userID string) *Client {
+		client := &Client{hub: hub, send: make(chan []byte, 16), UserID: userID, SessionID: "s1"}
+		hub.registerClient(client)
+		return client
+	}
+	drain := func(clients ...*Client) {
+		for _, client := range clients {
+			for len(client.send) > 0 {
+				<-client.send
+			}
+		}
+	}
+	receive := func(t *testing.T, client *Client) Message {
+		var msg Message
+		select {
+		case data := <-client.send:
+			require.NoError(t, json.Unmarshal(data, &msg))
+		case <-time.After(time.Second):
+			t.Fatalf("no message for %s", client.UserID)
+		}
+		return msg
+	}
+	submit := func(client *Client, data map[string]interface{}) {
+		client.handleMessage(ClientMessage{Type: "answer_submit", SessionID: "s1", Data: data})
+	}
+
+	t.Run("採点結果は回答した本人にのみ送られ、回答内容は他の参加者に送られないこと", func(t *testing.T) {
+		hub := NewHub()
+		submitter := &fakeAnswerSubmitter{answers: make(map[string]*domain.Answer)}
+		hub.SetAnswerSubmitter(submitter)
+		alice := newTestClient(hub, "alice")
+		bob := newTestClient(hub, "bob")
+		drain(alice, bob)
+
+		submit(alice, map[string]interface{}{"questionId": "q1", "selectedOption": 1, "responseTime": 1200})
+
+		msg := receive(t, alice)
+		assert.Equal(t, string(MessageTypeAnswerAck), msg.Type)
+		data := msg.Data.(map[string]interface{})
+		assert.Equal(t, "accepted", data["status"])
+		assert.Equal(t, "q1", data["questionId"])
+		assert.Equal(t, true, data["isCorrect"])
+		assert.Empty(t, bob.send)
+		require.Len(t, submitter.inputs, 1)
+		require.NotNil(t, submitter.inputs[0].SelectedOption)
+		assert.Equal(t, 1, *submitter.inputs[0].SelectedOption)
+	})
+
+	t.Run("2回目の回答は duplicate として最初の回答が返ること", func(t *testing.T) {
+		hub := NewHub()
+		hub.SetAnswerSubmitter(&fakeAnswerSubmitter{answers: make(map[string]*domain.Answer)})
+		client := newTestClient(hub, "alice")
+		drain(client)
+
+		submit(client, map[string]interface{}{"questionId": "q1", "selectedOption": 0})
+		assert.Equal(t, "accepted", receive(t, client).Data.(map[string]interface{})["status"])
+
+		submit(client, map[string]interface{}{"questionId": "q1", "selectedOption": 1})
+		data := receive(t, client).Data.(map[string]interface{})
+		assert.Equal(t, "duplicate", data["status"])
+		assert.Equal(t, false, data["isCorrect"])
+	})
+
+	t.Run("採点時のエラーが受付結果に変換されること", func(t *testing.T) {
+		testCases := []struct {
+			err    error
+			status string
+			reason interface{}
+		}{
+			{domain.ErrTimeExpired, "too_late", nil},
+			{domain.ErrParticipantEliminated, "eliminated", nil},
+			{domain.ErrParticipantNotFound, "rejected", "not_participant"},
+			{domain.ErrInvalidAnswer, "rejected", "invalid_answer"},
+			{domain.ErrSessionNotActive, "rejected", "session_not_active"},
+		}
+		for _, tc := range testCases {
+			hub := NewHub()
+			hub.SetAnswerSubmitter(&fakeAnswerSubmitter{err: tc.err})
+			client := newTestClient(hub, "alice")
+			drain(client)
+
+			submit(client, map[string]interface{}{"questionId": "q1", "selectedOption": 0})
+			data := receive(t, client).Data.(map[string]interface{})
+			assert.Equal(t, tc.status, data["status"], tc.err.Error())
+			assert.Equal(t, tc.reason, data["reason"], tc.err.Error())
+			assert.NotContains(t, data, "isCorrect")
+		}
+	})
+
+	t.Run("問題IDがない・採点できない場合は受け付けないこと", func(t *testing.T) {
+		hub := NewHub()
+		client := newTestClient(hub, "alice")
+		drain(client)
+
+		submit(client, map[string]interface{}{"questionId": "q1", "selectedOption": 0})
+		assert.Equal(t, "unavailable", receive(t, client).Data.(map[string]interface{})["reason"])
+
+		submitter := &fakeAnswerSubmitter{answers: make(map[string]*domain.Answer)}
+		hub.SetAnswerSubmitter(submitter)
+		submit(client, map[string]interface{}{"selectedOption": 0})
+		assert.Equal(t, "invalid_request", receive(t, client).Data.(map[string]interface{})["reason"])
+		assert.Empty(t, submitter.inputs)
+	})
+
+	t.Run("採点中も他のメッセージを処理し、回答は受信順に採点すること", func(t *testing.T) {
+		hub := NewHub()
+		submitter := &fakeAnswerSubmitter{answers: make(map[string]*domain.Answer), release: make(chan struct{})}
+		hub.SetAnswerSubmitter(submitter)
+		client := newTestClient(hub, "alice")
+		drain(client)
+
+		submit(client, map[string]interface{}{"questionId": "q1", "selectedOption": 1})
+		submit(client, map[string]interface{}{"questionId": "q1", "selectedOption": 0})
+		client.handleMessage(ClientMessage{Type: string(MessageTypePing)})
+		assert.Equal(t, string(MessageTypePong), receive(t, client).Type)
+
+		close(submitter.release)
+		assert.Equal(t, "accepted", receive(t, client).Data.(map[string]interface{})["status"])
+		assert.Equal(t, "duplicate", receive(t, client).Data.(map[string]interface{})["status"])
+		client.stopAnswerSubmits()
+	})
+
+	t.Run("採点待ちが上限に達した回答は busy で受け付けないこと", func(t *testing.T) {
+		hub := NewHub()
+		submitter := &fakeAnswerSubmitter{answers: make(map[string]*domain.Answer), release: make(chan struct{})}
+		hub.SetAnswerSubmitter(submitter)
+		client := newTestClient(hub, "alice")
+		drain(client)
+
+		// 1件目は採点中、answerQueueSize 件が採点待ちになる
+		for i := 0; i < answerQueueSize+2; i++ {
+			submit(client, map[string]interface{}{"questionId": "q1", "selectedOption": 0})
+		}
+		data := receive(t, client).Data.(map[string]interface{})
+		assert.Equal(t, "rejected", data["status"])
+		assert.Equal(t, "busy", data["reason"])
+
+		close(submitter.release)
+		client.stopAnswerSubmits()
+	})
+
+	t.Run("採点中に切断された場合は受付結果を送らないこと", func(t *testing.T) {
+		hub := NewHub()
+		hub.leaveGrace = 0
+		submitter := &fakeAnswerSubmitter{answers: make(map[string]*domain.Answer), release: make(chan struct{}), submitted: make(chan string, 2)}
+		hub.SetAnswerSubmitter(submitter)
+		client := newTestClient(hub, "alice")
+		drain(client)
+
+		submit(client, map[string]interface{}{"questionId": "q1", "selectedOption": 1})
+		hub.unregisterClient(client)
+		close(submitter.release)
+		// 2つ目の回答を採点した時点で1つ目の受付結果の送信は終わっている
+		submit(client, map[string]interface{}{"questionId": "q2", "selectedOption": 1})
+		assert.Equal(t, "q1", <-submitter.submitted)
+		assert.Equal(t, "q2", <-submitter.submitted)
+		client.stopAnswerSubmits()
+
+		_, open := <-client.send
+		assert.False(t, open)
+	})
+
+	t.Run("回答者数の通知には回答内容が含まれないこと", func(t *testing.T) {
+		hub := NewHub()
+		alice := newTestClient(hub, "alice")
+		bob := newTestClient(hub, "bob")
+		drain(alice, bob)
+
+		manager := &Manager{hub: hub}
+		manager.NotifyAnswerCount("s1", "q1", 3)
+
+		for _, client := range []*Client{alice, bob} {
+			msg := receive(t, client)
+			assert.Equal(t, string(MessageTypeAnswerSubmitted), msg.Type)
+			assert.Equal(t, map[string]interface{}{"questionId": "q1", "answeredCount": float64(3)}, msg.Data)
+		}
+	})
+
+	t.Run("間隔内の回答者数は最大の数にまとめて通知されること", func(t *testing.T) {
+		hub := NewHub()
+		hub.answerCountInterval = 20 * time.Millisecond
+		alice := newTestClient(hub, "alice")
+		drain(alice)
+		count := func(msg Message) interface{} {
+			return msg.Data.(map[string]interface{})["answeredCount"]
+		}
+
+		for _, answered := range []int{1, 3, 2, 4} {
+			hub.NotifyAnswerCount("s1", "q1", answered)
+		}
+		assert.Equal(t, float64(1), count(receive(t, alice)))
+		assert.Empty(t, alice.send)
+
+		assert.Eventually(t, func() bool { return len(alice.send) > 0 }, time.Second, time.Millisecond)
+		assert.Equal(t, float64(4), count(receive(t, alice)))
+
+		// 次の問題の数はすぐに通知し、前の問題の通知待ちは送らない
+		hub.NotifyAnswerCount("s1", "q1", 5)
+		hub.NotifyAnswerCount("s1", "q2", 1)
+		msg := receive(t, alice)
+		assert.Equal(t, "q2", msg.Data.(map[string]interface{})["questionId"])
+		assert.Equal(t, float64(1), count(msg))
+
+		assert.Eventually(t, func() bool {
+			hub.answerCountMu.Lock()
+			defer hub.answerCountMu.Unlock()
+			return len(hub.answerCounts) == 0
+		}, time.Second, time.Millisecond)
+		assert.Empty(t, alice.send)
+	})
+}
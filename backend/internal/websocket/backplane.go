@@ -0,0 +1,358 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"quiz-app/internal/domain"
+	"quiz-app/pkg/config"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// backplaneTimeout Backplane への1回の配信・問い合わせの上限時間
+	backplaneTimeout = 5 * time.Second
+	// backplaneQueueSize Backplane への配信待ちの通知の上限（溢れた通知は捨てる）
+	backplaneQueueSize = 1024
+	// countReportInterval 接続数を Backplane に報告する間隔
+	countReportInterval = 5 * time.Second
+	// countReportTTL 報告が途絶えたノードの接続数を集計から外すまでの時間
+	countReportTTL = 3 * countReportInterval
+)
+
+// EnvelopeKind ノード間で配信する通知の種類
+type EnvelopeKind string
+
+const (
+	EnvelopeSession  EnvelopeKind = "session"  // セッション全体への通知（Backplane が全ノードで共通の通番を振る）
+	EnvelopeUser     EnvelopeKind = "user"     // ユーザーの全接続への通知
+	EnvelopeLanguage EnvelopeKind = "language" // 参加者の問題の表示言語の変更
+)
+
+// Envelope ノード間で配信する通知
+type Envelope struct {
+	Kind      EnvelopeKind       `json:"kind"`
+	SessionID string             `json:"sessionId,omitempty"`
+	UserID    string             `json:"userId,omitempty"`
+	Language  string             `json:"language,omitempty"`  // EnvelopeLanguage のみ
+	Message   Message            `json:"message"`             // 表示言語ごとに作る通知の場合は元の言語の版
+	Localized map[string]Message `json:"localized,omitempty"` // 言語コード -> 表示言語の版（受け取る側の接続の言語は分からないため全対応言語の版を送る）
+	Transient bool               `json:"transient,omitempty"` // 通番を振らず再送用にも保持しない通知（EnvelopeSession のみ）
+}
+
+// sequenced 通番を振る通知か
+func (e *Envelope) sequenced() bool {
+	return e.Kind == EnvelopeSession && !e.Transient
+}
+
+// withSeq 通番を振った Envelope を返す（元の Envelope は変更しない）
+func (e *Envelope) withSeq(seq int64) *Envelope {
+	env := *e
+	env.Message.Seq = seq
+	if len(e.Localized) > 0 {
+		env.Localized = make(map[string]Message, len(e.Localized))
+		for language, msg := range e.Localized {
+			msg.Seq = seq
+			env.Localized[language] = msg
+		}
+	}
+	return &env
+}
+
+// event 受け取ったノードで送信・再送に使う通知
+func (e *Envelope) event() bufferedEvent {
+	event := bufferedEvent{msg: e.Message}
+	if len(e.Localized) > 0 {
+		event.localize = func(language string) Message {
+			if msg, ok := e.Localized[language]; ok {
+				return msg
+			}
+			return e.Message
+		}
+	}
+	return event
+}
+
+// Backplane 複数のサーバー（ノード）で WebSocket の通知と接続数を共有する
+// Hub は自ノードの通知も Backplane 経由で受け取るため、全ノードに同じ順序で届く
+type Backplane interface {
+	// Publish 通知を全ノード（自ノードを含む）に配信する。Transient でない EnvelopeSession には通番を振る
+	Publish(ctx context.Context, env *Envelope) error
+	// Subscribe 配信された通知を受け取る handler を登録する（handler は配信順に1つずつ呼ばれる）
+	Subscribe(handler func(env *Envelope)) error
+	// ReportClientCounts ノードのセッションごとの接続数を報告する（ttl の間に再報告しなければ集計から外れる）
+	ReportClientCounts(ctx context.Context, nodeID string, counts map[string]int, ttl time.Duration) error
+	// RemoteClientCount excludeNodeID 以外のノードのセッションの接続数の合計
+	RemoteClientCount(ctx context.Context, sessionID, excludeNodeID string) (int, error)
+	Close() error
+}
+
+// NewBackplane 設定に応じた Backplane を作成する（単一サーバーの場合は nil）
+func NewBackplane(ctx context.Context, cfg *config.Config) (Backplane, error) {
+	switch cfg.WebSocket.Backplane {
+	case "redis":
+		return NewRedisBackplane(ctx, cfg.WebSocket.RedisURL)
+	case "memory":
+		return NewMemoryBackplane(), nil
+	case "none", "":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown websocket backplane: %s", cfg.WebSocket.Backplane)
+	}
+}
+
+// newNodeID ホスト名とランダムな接尾辞からノードの識別子を作る（同じホストで複数起動しても重ならない）
+func newNodeID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "node"
+	}
+	return hostname + "-" + uuid.NewString()[:8]
+}
+
+// SetBackplane 他のノードと通知と接続数を共有する（nodeID が空の場合は生成する）
+// 以降のセッション・ユーザーへの通知は Backplane 経由で送る
+func (m *Manager) SetBackplane(backplane Backplane, nodeID string) error {
+	return m.hub.SetBackplane(backplane, nodeID)
+}
+
+// Close Backplane との接続を閉じる
+func (m *Manager) Close() error {
+	return m.hub.Close()
+}
+
+// SetBackplane 他のノードと通知と接続数を共有する
+func (h *Hub) SetBackplane(backplane Backplane, nodeID string) error {
+	if nodeID == "" {
+		nodeID = newNodeID()
+	}
+
+	if err := backplane.Subscribe(h.deliver); err != nil {
+		return fmt.Errorf("failed to subscribe backplane: %w", err)
+	}
+
+	h.mutex.Lock()
+	h.backplane = backplane
+	h.nodeID = nodeID
+	h.mutex.Unlock()
+
+	go h.publishLoop(backplane)
+	go h.reportLoop(backplane)
+
+	log.Printf("WebSocket backplane enabled: NodeID=%s", nodeID)
+	return nil
+}
+
+// Close Backplane への配信と接続数の報告を止めて接続を閉じる
+func (h *Hub) Close() error {
+	h.mutex.Lock()
+	backplane := h.backplane
+	h.backplane = nil
+	h.mutex.Unlock()
+
+	if backplane == nil {
+		return nil
+	}
+	close(h.done)
+	return backplane.Close()
+}
+
+// publish 通知を Backplane への配信待ちに入れる（h.mutex を取得済みで呼ぶ。配信は publishLoop が行う）
+func (h *Hub) publish(env *Envelope) {
+	select {
+	case h.outbound <- env:
+	default:
+		log.Printf("Backplane queue is full, dropping message: Kind=%s, SessionID=%s, Type=%s", env.Kind, env.SessionID, env.Message.Type)
+	}
+}
+
+// publishLoop 配信待ちの通知を順に Backplane に配信する
+// 配信に失敗した通知は自ノードの接続にのみ送る（通番がないため再接続時には再送されない）
+func (h *Hub) publishLoop(backplane Backplane) {
+	for {
+		select {
+		case <-h.done:
+			return
+		case env := <-h.outbound:
+			ctx, cancel := context.WithTimeout(context.Background(), backplaneTimeout)
+			err := backplane.Publish(ctx, env)
+			cancel()
+			if err != nil {
+				log.Printf("Failed to publish to backplane: Kind=%s, SessionID=%s, Type=%s: %v", env.Kind, env.SessionID, env.Message.Type, err)
+				h.deliver(env)
+			}
+		}
+	}
+}
+
+// reportLoop 自ノードのセッションごとの接続数を定期的に Backplane に報告する
+func (h *Hub) reportLoop(backplane Backplane) {
+	ticker := time.NewTicker(countReportInterval)
+	defer ticker.Stop()
+
+	for {
+		h.reportClientCounts(backplane)
+		select {
+		case <-h.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *Hub) reportClientCounts(backplane Backplane) {
+	h.mutex.RLock()
+	counts := make(map[string]int, len(h.sessions))
+	for sessionID, clients := range h.sessions {
+		counts[sessionID] = len(clients)
+	}
+	nodeID := h.nodeID
+	h.mutex.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), backplaneTimeout)
+	defer cancel()
+
+	if err := backplane.ReportClientCounts(ctx, nodeID, counts, countReportTTL); err != nil {
+		log.Printf("Failed to report client counts to backplane: %v", err)
+	}
+}
+
+// deliver Backplane から受け取った通知を自ノードの接続に送る
+func (h *Hub) deliver(env *Envelope) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	switch env.Kind {
+	case EnvelopeSession:
+		h.deliverToSession(env)
+	case EnvelopeUser:
+		h.broadcastToUser(env.UserID, env.Message)
+	case EnvelopeLanguage:
+		h.applyUserLanguage(env.SessionID, env.UserID, env.Language)
+	default:
+		log.Printf("Unknown backplane envelope kind: %s", env.Kind)
+	}
+}
+
+// deliverToSession セッションの通知を表示言語ごとに送り、再接続時の再送用に保持する（h.mutex を取得済みで呼ぶ）
+func (h *Hub) deliverToSession(env *Envelope) {
+	event := env.event()
+	if event.msg.Seq > 0 {
+		h.events.store(env.SessionID, event)
+	}
+
+	messages := make(map[string][]byte)
+	for client := range h.sessions[env.SessionID] {
+		msgBytes, ok := messages[client.Language]
+		if !ok {
+			var err error
+			if msgBytes, err = json.Marshal(event.message(client.Language)); err != nil {
+				log.Printf("Failed to marshal message: %v", err)
+				return
+			}
+			messages[client.Language] = msgBytes
+		}
+
+		select {
+		case client.send <- msgBytes:
+		default:
+			h.dropClient(client)
+		}
+	}
+
+	// 削除されたセッションの表示言語と再送用の通知は、全ノードで削除の通知を送った後に破棄する
+	if env.Message.Type == string(MessageTypeSessionDeleted) {
+		delete(h.languages, env.SessionID)
+		h.events.clear(env.SessionID)
+	}
+}
+
+// localizedEnvelope 全対応言語と元の言語の版を作り、他のノードの接続の表示言語でも送れるようにする
+func localizedEnvelope(sessionID string, build func(language string) Message) *Envelope {
+	msg := build("")
+	localized := make(map[string]Message)
+	for _, language := range domain.SupportedLanguages() {
+		localizedMsg := build(language)
+		localizedMsg.Timestamp = msg.Timestamp
+		localized[language] = localizedMsg
+	}
+	return &Envelope{Kind: EnvelopeSession, SessionID: sessionID, Message: msg, Localized: localized}
+}
+
+// nodeClientCounts ノードが報告したセッションごとの接続数
+type nodeClientCounts struct {
+	counts    map[string]int
+	expiresAt time.Time
+}
+
+// MemoryBackplane 同一プロセス内の Hub 間で通知と接続数を共有する Backplane（テスト・単一サーバー向け）
+type MemoryBackplane struct {
+	mu       sync.Mutex
+	seqs     map[string]int64 // sessionID -> 最後に振った通番
+	handlers []func(env *Envelope)
+	nodes    map[string]nodeClientCounts
+}
+
+func NewMemoryBackplane() *MemoryBackplane {
+	return &MemoryBackplane{
+		seqs:  make(map[string]int64),
+		nodes: make(map[string]nodeClientCounts),
+	}
+}
+
+// Publish 登録された全ての handler を呼ぶ（配信順を保つため、呼び終わるまで次の配信を待たせる）
+func (b *MemoryBackplane) Publish(ctx context.Context, env *Envelope) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if env.sequenced() {
+		b.seqs[env.SessionID]++
+		env = env.withSeq(b.seqs[env.SessionID])
+	}
+	for _, handler := range b.handlers {
+		handler(env)
+	}
+	return nil
+}
+
+func (b *MemoryBackplane) Subscribe(handler func(env *Envelope)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers = append(b.handlers, handler)
+	return nil
+}
+
+func (b *MemoryBackplane) ReportClientCounts(ctx context.Context, nodeID string, counts map[string]int, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nodes[nodeID] = nodeClientCounts{counts: counts, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+func (b *MemoryBackplane) RemoteClientCount(ctx context.Context, sessionID, excludeNodeID string) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	total := 0
+	for nodeID, node := range b.nodes {
+		if nodeID == excludeNodeID || now.After(node.expiresAt) {
+			continue
+		}
+		total += node.counts[sessionID]
+	}
+	return total, nil
+}
+
+// Close 複数の Hub で共有するため何もしない
+func (b *MemoryBackplane) Close() error {
+	return nil
+}
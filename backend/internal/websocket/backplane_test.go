@@ -0,0 +1,169 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"quiz-app/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackplane(t *testing.T) {
+	// newNodes backplane を共有する Hub（ノード）を n 個作る
+	newNodes := func(t *testing.T, n int) ([]*Hub, *MemoryBackplane) {
+		backplane := NewMemoryBackplane()
+		hubs := make([]*Hub, n)
+		for i := range hubs {
+			hubs[i] = NewHub()
+			require.NoError(t, hubs[i].SetBackplane(backplane, ""))
+			t.Cleanup(func() { hubs[i].Close() })
+		}
+		return hubs, backplane
+	}
+	newTestClient := func(hub *Hub, userID, language string) *Client {
+		client := &Client{hub: hub, send: make(chan []byte, 16), UserID: userID, SessionID: "s1", Language: language}
+		hub.registerClient(client)
+		return client
+	}
+	// receive 通知が届くのを待つ（Backplane への配信は非同期）
+	receive := func(t *testing.T, client *Client) Message {
+		var msg Message
+		select {
+		case data := <-client.send:
+			require.NoError(t, json.Unmarshal(data, &msg))
+		case <-time.After(time.Second):
+			t.Fatalf("no message for %s", client.UserID)
+		}
+		return msg
+	}
+	drain := func(t *testing.T, client *Client, n int) {
+		for i := 0; i < n; i++ {
+			receive(t, client)
+		}
+	}
+
+	t.Run("他のノードに接続したクライアントにも、全ノードで共通の通番で届くこと", func(t *testing.T) {
+		hubs, _ := newNodes(t, 2)
+		player1 := newTestClient(hubs[0], "player1", "")
+		player2 := newTestClient(hubs[1], "player2", "")
+		drain(t, player1, 2) // 2人の参加通知
+		drain(t, player2, 2)
+
+		hubs[0].BroadcastToSession("s1", Message{Type: "session_update", SessionID: "s1"})
+
+		msg1, msg2 := receive(t, player1), receive(t, player2)
+		assert.Equal(t, "session_update", msg2.Type)
+		assert.Equal(t, int64(3), msg1.Seq)
+		assert.Equal(t, msg1.Seq, msg2.Seq)
+
+		// 再接続先のノードでも見逃した通知を再送できる
+		events, latest, complete := hubs[1].events.since("s1", 2)
+		assert.True(t, complete)
+		assert.Equal(t, int64(3), latest)
+		require.Len(t, events, 1)
+		assert.Equal(t, "session_update", events[0].msg.Type)
+	})
+
+	t.Run("回答者数は通番を振らずに他のノードにも届き、再送用に保持しないこと", func(t *testing.T) {
+		hubs, _ := newNodes(t, 2)
+		player1 := newTestClient(hubs[0], "player1", "")
+		player2 := newTestClient(hubs[1], "player2", "")
+		drain(t, player1, 2)
+		drain(t, player2, 2)
+
+		hubs[0].broadcastAnswerCount("s1", "q1", 1)
+
+		msg1, msg2 := receive(t, player1), receive(t, player2)
+		assert.Equal(t, string(MessageTypeAnswerSubmitted), msg2.Type)
+		assert.Zero(t, msg1.Seq)
+		assert.Zero(t, msg2.Seq)
+
+		// 次のセッション全体への通知の通番は飛ばない
+		hubs[1].BroadcastToSession("s1", Message{Type: "session_update", SessionID: "s1"})
+		assert.Equal(t, int64(3), receive(t, player1).Seq)
+		events, _, _ := hubs[0].events.since("s1", 2)
+		require.Len(t, events, 1)
+		assert.Equal(t, "session_update", events[0].msg.Type)
+	})
+
+	t.Run("他のノードの接続にも表示言語の版を送ること", func(t *testing.T) {
+		hubs, _ := newNodes(t, 2)
+		ja := newTestClient(hubs[0], "player1", "")
+		en := newTestClient(hubs[1], "player2", "en")
+		drain(t, ja, 2)
+		drain(t, en, 2)
+		question := domain.NewQuestion("s1", 1, "問題", []string{"赤", "青"}, 0, domain.DifficultyEasy, "", domain.AIProviderLocal)
+		require.NoError(t, question.SetTranslation("en", domain.QuestionTranslation{Text: "Question", Options: []string{"Red", "Blue"}}))
+
+		manager := &Manager{hub: hubs[0]}
+		manager.NotifyQuestionStart("s1", question, 30)
+
+		text := func(msg Message) interface{} {
+			return msg.Data.(map[string]interface{})["question"].(map[string]interface{})["text"]
+		}
+		assert.Equal(t, "問題", text(receive(t, ja)))
+		assert.Equal(t, "Question", text(receive(t, en)))
+	})
+
+	t.Run("ユーザーへの通知と表示言語の変更が他のノードの接続に反映されること", func(t *testing.T) {
+		hubs, _ := newNodes(t, 2)
+		player := newTestClient(hubs[1], "player1", "")
+		drain(t, player, 1)
+
+		hubs[0].BroadcastToUser("player1", Message{Type: "error"})
+		assert.Equal(t, "error", receive(t, player).Type)
+
+		hubs[0].SetUserLanguage("s1", "player1", "en")
+		assert.Eventually(t, func() bool {
+			hubs[1].mutex.RLock()
+			defer hubs[1].mutex.RUnlock()
+			return player.Language == "en"
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("接続数は報告が有効な全ノードの合計になること", func(t *testing.T) {
+		hubs, backplane := newNodes(t, 3)
+		newTestClient(hubs[0], "player1", "")
+		newTestClient(hubs[1], "player2", "")
+		newTestClient(hubs[1], "player3", "")
+		for _, hub := range hubs {
+			hub.reportClientCounts(backplane)
+		}
+
+		assert.Equal(t, 3, hubs[0].GetSessionClientCount("s1"))
+		assert.Equal(t, 3, hubs[2].GetSessionClientCount("s1"))
+
+		// 報告が途絶えたノードは集計から外す
+		require.NoError(t, backplane.ReportClientCounts(context.Background(), hubs[1].nodeID, map[string]int{"s1": 2}, -time.Second))
+		assert.Equal(t, 1, hubs[0].GetSessionClientCount("s1"))
+	})
+}
+
+func TestDecodeRedisFrame(t *testing.T) {
+	env := &Envelope{
+		Kind:      EnvelopeSession,
+		SessionID: "s1",
+		Message:   Message{Type: "question_start", SessionID: "s1"},
+		Localized: map[string]Message{"en": {Type: "question_start", SessionID: "s1"}},
+	}
+	payload, err := json.Marshal(env)
+	require.NoError(t, err)
+
+	decoded, err := decodeRedisFrame("42 " + string(payload))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), decoded.Message.Seq)
+	assert.Equal(t, int64(42), decoded.Localized["en"].Seq)
+	assert.Zero(t, env.Localized["en"].Seq)
+
+	decoded, err = decodeRedisFrame(`0 {"kind":"user","userId":"u1","message":{"type":"error","timestamp":1}}`)
+	require.NoError(t, err)
+	assert.Equal(t, EnvelopeUser, decoded.Kind)
+	assert.Zero(t, decoded.Message.Seq)
+
+	_, err = decodeRedisFrame("not-a-frame")
+	assert.Error(t, err)
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -16,13 +18,31 @@ const (
 	maxMessageSize = 512
 )
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		// 本番環境では適切なオリジンチェックを実装
+// newUpgrader allowedOrigins（CORS で許可したオリジン）とサーバーと同じホストのページからの接続のみ受け付ける
+func newUpgrader(allowedOrigins []string) *websocket.Upgrader {
+	return &websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin: func(r *http.Request) bool {
+			return checkOrigin(r, allowedOrigins)
+		},
+	}
+}
+
+// checkOrigin 他のサイトのページから管理者の Cookie を使って接続されるのを防ぐ
+// Origin のない接続はブラウザ以外からの接続のため受け付ける
+func checkOrigin(r *http.Request, allowedOrigins []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
 		return true
-	},
+	}
+	for _, allowed := range allowedOrigins {
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
 }
 
 type Client struct {
@@ -33,6 +53,12 @@ type Client struct {
 	SessionID   string
 	DisplayName string
 	IsAdmin     bool
+	Language    string // 問題を表示する言語（空なら問題の元の言語）
+
+	rooms map[string]bool // 通知を受け取るセッション（主セッション SessionID と購読中のセッション。Hub の mutex で保護）
+
+	adminCommands chan func() // 実行待ちの admin_control（最初のコマンドで作成する）
+	answers       chan func() // 採点待ちの answer_submit（最初の回答で作成する）
 }
 
 type ClientMessage struct {
@@ -55,6 +81,8 @@ func NewClient(hub *Hub, conn *websocket.Conn, userID, sessionID, displayName st
 
 func (c *Client) ReadPump() {
 	defer func() {
+		c.stopAdminCommands()
+		c.stopAnswerSubmits()
 		c.hub.unregister <- c
 		c.conn.Close()
 	}()
@@ -136,13 +164,20 @@ func (c *Client) handleMessage(msg ClientMessage) {
 		c.handleAnswerSubmit(msg)
 
 	case "admin_control":
-		if c.IsAdmin {
-			c.handleAdminControl(msg)
-		}
+		c.handleAdminControl(msg)
 
 	case "join_session":
 		c.handleJoinSession(msg)
 
+	case "subscribe_session":
+		c.handleSubscribeSession(msg)
+
+	case "unsubscribe_session":
+		c.handleUnsubscribeSession(msg)
+
+	case "resume":
+		c.handleResume(msg)
+
 	default:
 		log.Printf("Unknown message type: %s", msg.Type)
 	}
@@ -167,66 +202,6 @@ func (c *Client) sendPong() {
 	}
 }
 
-func (c *Client) handleAnswerSubmit(msg ClientMessage) {
-	// 回答データの処理は UseCase レイヤーに委譲
-	// ここではメッセージの転送のみ行う
-	responseMsg := Message{
-		Type:      string(MessageTypeAnswerSubmitted),
-		SessionID: c.SessionID,
-		Data: map[string]interface{}{
-			"userId": c.UserID,
-			"answer": msg.Data,
-		},
-		Timestamp: getCurrentTimestamp(),
-	}
-
-	// 同じセッションの管理者に通知
-	c.hub.BroadcastToSession(c.SessionID, responseMsg)
-}
-
-func (c *Client) handleAdminControl(msg ClientMessage) {
-	// 管理者コントロールメッセージの処理
-	controlMsg := Message{
-		Type:      "admin_control",
-		SessionID: c.SessionID,
-		Data:      msg.Data,
-		Timestamp: getCurrentTimestamp(),
-	}
-
-	// セッション内の全クライアントに管理者コマンドを送信
-	c.hub.BroadcastToSession(c.SessionID, controlMsg)
-}
-
-func (c *Client) handleJoinSession(msg ClientMessage) {
-	// セッション参加処理
-	if sessionID, ok := msg.Data.(map[string]interface{})["sessionId"].(string); ok {
-		c.SessionID = sessionID
-		
-		// 参加成功メッセージを送信
-		joinMsg := Message{
-			Type:      "join_success",
-			SessionID: sessionID,
-			Data: map[string]interface{}{
-				"userId":      c.UserID,
-				"displayName": c.DisplayName,
-			},
-			Timestamp: getCurrentTimestamp(),
-		}
-
-		msgBytes, err := json.Marshal(joinMsg)
-		if err != nil {
-			log.Printf("Failed to marshal join message: %v", err)
-			return
-		}
-
-		select {
-		case c.send <- msgBytes:
-		default:
-			close(c.send)
-		}
-	}
-}
-
 func (c *Client) SendMessage(msg Message) {
 	msgBytes, err := json.Marshal(msg)
 	if err != nil {
@@ -251,4 +226,32 @@ func (c *Client) SendError(errorMsg string) {
 	}
 
 	c.SendMessage(errorMessage)
+}
+
+// enqueueWork run を queue の goroutine で受信順に実行する（queue は最初に呼んだときに作成する）
+// 実行待ちが size に達している場合は false。ReadPump の goroutine からのみ呼ぶ
+func enqueueWork(queue *chan func(), size int, run func()) bool {
+	if *queue == nil {
+		*queue = make(chan func(), size)
+		go func(works <-chan func()) {
+			for run := range works {
+				run()
+			}
+		}(*queue)
+	}
+
+	select {
+	case *queue <- run:
+		return true
+	default:
+		return false
+	}
+}
+
+// stopWork 実行待ちを実行し終えたら queue の goroutine を終了する
+func stopWork(queue *chan func()) {
+	if *queue != nil {
+		close(*queue)
+		*queue = nil
+	}
 }
\ No newline at end of file
@@ -0,0 +1,28 @@
+package websocket
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckOrigin(t *testing.T) {
+	allowed := []string{"http://localhost:3000", "https://quiz.example.com/"}
+	request := func(origin string) bool {
+		r := httptest.NewRequest("GET", "http://api.example.com/ws", nil)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		return checkOrigin(r, allowed)
+	}
+
+	assert.True(t, request("http://localhost:3000"))
+	assert.True(t, request("https://quiz.example.com"))
+	assert.True(t, request("http://api.example.com"), "サーバーと同じホストのページ")
+	assert.True(t, request(""), "ブラウザ以外からの接続")
+
+	assert.False(t, request("https://evil.example.net"))
+	assert.False(t, request("http://localhost:3001"))
+	assert.False(t, request("null"))
+}
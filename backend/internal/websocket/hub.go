@@ -1,6 +1,7 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"sync"
@@ -9,16 +10,36 @@ import (
 
 type Hub struct {
 	clients    map[*Client]bool
-	sessions   map[string]map[*Client]bool // sessionID -> clients
+	sessions   map[string]map[*Client]bool  // sessionID -> clients
+	languages  map[string]map[string]string // sessionID -> userID -> 問題の表示言語（再接続時に引き継ぐ）
 	broadcast  chan []byte
 	register   chan *Client
 	unregister chan *Client
 	mutex      sync.RWMutex
+
+	answerSubmitter   AnswerSubmitter      // answer_submit の採点に使う（SetAnswerSubmitter で設定）
+	adminExecutor     AdminCommandExecutor // admin_control の実行に使う（SetAdminCommandExecutor で設定）
+	snapshotProvider  SnapshotProvider     // resume で送る状態の取得に使う（SetSnapshotProvider で設定）
+	sessionAuthorizer SessionAuthorizer    // join_session / subscribe_session の検証に使う（SetSessionAuthorizer で設定）
+
+	events        *eventStore            // セッション全体への通知の通番と再送用のバッファ
+	pendingLeaves map[string]*time.Timer // sessionID + userID -> 猶予後に participant_leave を通知するタイマー
+	leaveGrace    time.Duration
+
+	backplane Backplane      // 他のノードと通知を共有する（SetBackplane で設定。nil の場合は自ノードの接続にのみ送る）
+	nodeID    string         // 接続数の報告に使う自ノードの識別子
+	outbound  chan *Envelope // Backplane への配信待ちの通知
+	done      chan struct{}  // Close で閉じる
+
+	answerCountMu       sync.Mutex
+	answerCounts        map[string]*answerCountWindow // sessionID -> 回答者数の通知を間引いている問題
+	answerCountInterval time.Duration
 }
 
 type Message struct {
 	Type      string      `json:"type"`
 	SessionID string      `json:"sessionId,omitempty"`
+	Seq       int64       `json:"seq,omitempty"` // セッション全体への通知の通番（resume で見逃した通知を求めるのに使う）
 	Data      interface{} `json:"data,omitempty"`
 	Timestamp int64       `json:"timestamp"`
 }
@@ -26,28 +47,46 @@ type Message struct {
 type MessageType string
 
 const (
-	MessageTypeQuestionStart    MessageType = "question_start"
-	MessageTypeQuestionEnd      MessageType = "question_end"
-	MessageTypeAnswerSubmitted  MessageType = "answer_submitted"
-	MessageTypeRoundResult      MessageType = "round_result"
-	MessageTypeParticipantJoin  MessageType = "participant_join"
-	MessageTypeParticipantLeave MessageType = "participant_leave"
-	MessageTypeSessionUpdate    MessageType = "session_update"
-	MessageTypeSessionDeleted   MessageType = "session_deleted"
-	MessageTypeRevivalStart     MessageType = "revival_start"
-	MessageTypeRevivalResult    MessageType = "revival_result"
-	MessageTypeError            MessageType = "error"
-	MessageTypePing             MessageType = "ping"
-	MessageTypePong             MessageType = "pong"
+	MessageTypeQuestionStart      MessageType = "question_start"
+	MessageTypeQuestionEnd        MessageType = "question_end"
+	MessageTypeAnswerSubmitted    MessageType = "answer_submitted"     // 回答者数のみ（回答内容は送らない）
+	MessageTypeAnswerAck          MessageType = "answer_ack"           // 回答の受付結果（回答した本人のみ）
+	MessageTypeAdminControlResult MessageType = "admin_control_result" // admin_control の結果（送信した管理者のみ）
+	MessageTypeResumeState        MessageType = "resume_state"         // 再接続時の状態と見逃した通知（resume を送ったクライアントのみ）
+	MessageTypeJoinSuccess        MessageType = "join_success"         // join_session で主セッションを切り替えた
+	MessageTypeSubscriptionUpdate MessageType = "subscription_update"  // subscribe_session / unsubscribe_session の結果
+	MessageTypeSubscriptionError  MessageType = "subscription_error"   // 参加・購読できなかった（送信したクライアントのみ）
+	MessageTypeRoundResult        MessageType = "round_result"
+	MessageTypeParticipantJoin    MessageType = "participant_join"
+	MessageTypeParticipantLeave   MessageType = "participant_leave"
+	MessageTypeSessionUpdate      MessageType = "session_update"
+	MessageTypeSessionDeleted     MessageType = "session_deleted"
+	MessageTypeRevivalStart       MessageType = "revival_start"
+	MessageTypeRevivalResult      MessageType = "revival_result"
+	MessageTypeLeaderboardUpdate  MessageType = "leaderboard_update"
+	MessageTypeError              MessageType = "error"
+	MessageTypePing               MessageType = "ping"
+	MessageTypePong               MessageType = "pong"
 )
 
 func NewHub() *Hub {
 	return &Hub{
 		clients:    make(map[*Client]bool),
 		sessions:   make(map[string]map[*Client]bool),
+		languages:  make(map[string]map[string]string),
 		broadcast:  make(chan []byte, 256),
 		register:   make(chan *Client, 256),
 		unregister: make(chan *Client, 256),
+
+		events:        newEventStore(),
+		pendingLeaves: make(map[string]*time.Timer),
+		leaveGrace:    reconnectGracePeriod,
+
+		outbound: make(chan *Envelope, backplaneQueueSize),
+		done:     make(chan struct{}),
+
+		answerCounts:        make(map[string]*answerCountWindow),
+		answerCountInterval: answerCountInterval,
 	}
 }
 
@@ -72,27 +111,11 @@ func (h *Hub) registerClient(client *Client) {
 
 	h.clients[client] = true
 
-	// セッション別のクライアント管理
-	if client.SessionID != "" {
-		if h.sessions[client.SessionID] == nil {
-			h.sessions[client.SessionID] = make(map[*Client]bool)
-		}
-		h.sessions[client.SessionID][client] = true
-	}
-
 	log.Printf("Client registered: UserID=%s, SessionID=%s", client.UserID, client.SessionID)
 
-	// 参加通知を同じセッションの他のクライアントに送信
+	// セッション別のクライアント管理
 	if client.SessionID != "" {
-		h.broadcastToSession(client.SessionID, Message{
-			Type:      string(MessageTypeParticipantJoin),
-			SessionID: client.SessionID,
-			Data: map[string]interface{}{
-				"userId":      client.UserID,
-				"displayName": client.DisplayName,
-			},
-			Timestamp: getCurrentTimestamp(),
-		})
+		h.enterSession(client, client.SessionID)
 	}
 }
 
@@ -104,29 +127,141 @@ func (h *Hub) unregisterClient(client *Client) {
 		delete(h.clients, client)
 		close(client.send)
 
-		// セッション別のクライアント管理から削除
-		if client.SessionID != "" && h.sessions[client.SessionID] != nil {
-			delete(h.sessions[client.SessionID], client)
-			if len(h.sessions[client.SessionID]) == 0 {
-				delete(h.sessions, client.SessionID)
+		// 購読していたセッションから削除（主セッションは猶予時間内に再接続しなければ離脱を通知する）
+		for sessionID := range client.rooms {
+			if sessionID != client.SessionID {
+				h.removeFromRoom(client, sessionID)
 			}
 		}
 
 		log.Printf("Client unregistered: UserID=%s, SessionID=%s", client.UserID, client.SessionID)
 
-		// 離脱通知を同じセッションの他のクライアントに送信
 		if client.SessionID != "" {
-			h.broadcastToSession(client.SessionID, Message{
-				Type:      string(MessageTypeParticipantLeave),
-				SessionID: client.SessionID,
-				Data: map[string]interface{}{
-					"userId":      client.UserID,
-					"displayName": client.DisplayName,
-				},
-				Timestamp: getCurrentTimestamp(),
-			})
+			h.leaveSession(client, client.SessionID, true)
+		}
+	}
+}
+
+// enterSession クライアントを主セッションに入れ、同じセッションの他のクライアントに参加を通知する（h.mutex を取得済みで呼ぶ）
+func (h *Hub) enterSession(client *Client, sessionID string) {
+	h.addToRoom(client, sessionID)
+
+	// 接続時に言語の指定がなければ、参加時に選んだ言語で表示する
+	if client.Language == "" {
+		client.Language = h.languages[sessionID][client.UserID]
+	} else {
+		h.setUserLanguage(sessionID, client.UserID, client.Language)
+	}
+
+	// 切断後の猶予中に再接続した場合は離脱も参加も通知しない
+	if timer, ok := h.pendingLeaves[leaveKey(sessionID, client.UserID)]; ok {
+		timer.Stop()
+		delete(h.pendingLeaves, leaveKey(sessionID, client.UserID))
+		return
+	}
+
+	h.broadcastToSession(sessionID, Message{
+		Type:      string(MessageTypeParticipantJoin),
+		SessionID: sessionID,
+		Data: map[string]interface{}{
+			"userId":      client.UserID,
+			"displayName": client.DisplayName,
+		},
+		Timestamp: getCurrentTimestamp(),
+	})
+}
+
+// leaveSession クライアントを主セッションから出し、同じユーザーの接続が残っていなければ離脱を通知する（h.mutex を取得済みで呼ぶ）
+// 切断の場合（grace）は、通信が一時的に切れただけの場合に備えて猶予時間内に再接続しなければ通知する
+func (h *Hub) leaveSession(client *Client, sessionID string, grace bool) {
+	h.removeFromRoom(client, sessionID)
+	if h.hasUserInSession(sessionID, client.UserID) {
+		return
+	}
+
+	leave := Message{
+		Type:      string(MessageTypeParticipantLeave),
+		SessionID: sessionID,
+		Data: map[string]interface{}{
+			"userId":      client.UserID,
+			"displayName": client.DisplayName,
+		},
+		Timestamp: getCurrentTimestamp(),
+	}
+	if !grace {
+		h.broadcastToSession(sessionID, leave)
+		return
+	}
+
+	key := leaveKey(sessionID, client.UserID)
+	if _, pending := h.pendingLeaves[key]; pending {
+		return
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(h.leaveGrace, func() {
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+
+		if h.pendingLeaves[key] != timer {
+			return
+		}
+		delete(h.pendingLeaves, key)
+		leave.Timestamp = getCurrentTimestamp()
+		h.broadcastToSession(sessionID, leave)
+	})
+	h.pendingLeaves[key] = timer
+}
+
+// addToRoom クライアントがセッションの通知を受け取るようにする（h.mutex を取得済みで呼ぶ）
+func (h *Hub) addToRoom(client *Client, sessionID string) {
+	if h.sessions[sessionID] == nil {
+		h.sessions[sessionID] = make(map[*Client]bool)
+	}
+	h.sessions[sessionID][client] = true
+
+	if client.rooms == nil {
+		client.rooms = make(map[string]bool)
+	}
+	client.rooms[sessionID] = true
+}
+
+// removeFromRoom クライアントがセッションの通知を受け取らないようにする（h.mutex を取得済みで呼ぶ）
+func (h *Hub) removeFromRoom(client *Client, sessionID string) {
+	if sessionClients, ok := h.sessions[sessionID]; ok {
+		delete(sessionClients, client)
+		if len(sessionClients) == 0 {
+			delete(h.sessions, sessionID)
+		}
+	}
+	delete(client.rooms, sessionID)
+}
+
+// dropClient 送信が詰まったクライアントを切り離す（購読中の全セッションから外す）
+func (h *Hub) dropClient(client *Client) {
+	if !h.clients[client] {
+		return
+	}
+	close(client.send)
+	delete(h.clients, client)
+	for sessionID := range client.rooms {
+		if sessionClients, ok := h.sessions[sessionID]; ok {
+			delete(sessionClients, client)
+		}
+	}
+}
+
+// hasUserInSession ユーザーが同じセッションに他の接続を持っているか（h.mutex を取得済みで呼ぶ）
+func (h *Hub) hasUserInSession(sessionID, userID string) bool {
+	for client := range h.sessions[sessionID] {
+		if client.UserID == userID {
+			return true
 		}
 	}
+	return false
+}
+
+func leaveKey(sessionID, userID string) string {
+	return sessionID + "\x00" + userID
 }
 
 func (h *Hub) broadcastMessage(message []byte) {
@@ -137,8 +272,7 @@ func (h *Hub) broadcastMessage(message []byte) {
 		select {
 		case client.send <- message:
 		default:
-			close(client.send)
-			delete(h.clients, client)
+			h.dropClient(client)
 		}
 	}
 }
@@ -151,6 +285,35 @@ func (h *Hub) BroadcastToSession(sessionID string, msg Message) {
 }
 
 func (h *Hub) broadcastToSession(sessionID string, msg Message) {
+	// 他のノードがある場合は Backplane が振った通番で全ノードから送る
+	if h.backplane != nil && sessionID != "" {
+		h.publish(&Envelope{Kind: EnvelopeSession, SessionID: sessionID, Message: msg})
+		return
+	}
+
+	// 接続中のクライアントがいなくても、再接続時に再送できるよう通番を振って保持する
+	if sessionID != "" {
+		msg.Seq = h.events.record(sessionID, msg, nil)
+	}
+
+	h.sendToSession(sessionID, msg)
+}
+
+// BroadcastTransientToSession 通番を振らず、再接続時の再送用にも保持しない通知を送る
+// 回答者数など、次の通知で古くなり見逃しても問題のない通知に使う（再送用のバッファから他の通知を押し出さないため）
+func (h *Hub) BroadcastTransientToSession(sessionID string, msg Message) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	if h.backplane != nil {
+		h.publish(&Envelope{Kind: EnvelopeSession, SessionID: sessionID, Message: msg, Transient: true})
+		return
+	}
+	h.sendToSession(sessionID, msg)
+}
+
+// sendToSession 自ノードのセッションの接続に送る（h.mutex を取得済みで呼ぶ）
+func (h *Hub) sendToSession(sessionID string, msg Message) {
 	sessionClients, exists := h.sessions[sessionID]
 	if !exists {
 		return
@@ -166,17 +329,148 @@ func (h *Hub) broadcastToSession(sessionID string, msg Message) {
 		select {
 		case client.send <- msgBytes:
 		default:
-			close(client.send)
-			delete(h.clients, client)
-			delete(sessionClients, client)
+			h.dropClient(client)
+		}
+	}
+}
+
+// BroadcastLocalizedToSession クライアントの表示言語ごとに build で作ったメッセージを送る
+// 同じ言語のクライアントには同じメッセージを使い回す
+func (h *Hub) BroadcastLocalizedToSession(sessionID string, build func(language string) Message) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	if h.backplane != nil {
+		h.publish(localizedEnvelope(sessionID, build))
+		return
+	}
+
+	// 通番と送信時刻は全言語で共通（再送時は build で表示言語の版を作り直す）
+	seq := h.events.record(sessionID, Message{Timestamp: getCurrentTimestamp()}, build)
+
+	sessionClients, exists := h.sessions[sessionID]
+	if !exists {
+		return
+	}
+
+	messages := make(map[string][]byte)
+	for client := range sessionClients {
+		msgBytes, ok := messages[client.Language]
+		if !ok {
+			msg := build(client.Language)
+			msg.Seq = seq
+			var err error
+			if msgBytes, err = json.Marshal(msg); err != nil {
+				log.Printf("Failed to marshal message: %v", err)
+				return
+			}
+			messages[client.Language] = msgBytes
+		}
+
+		select {
+		case client.send <- msgBytes:
+		default:
+			h.dropClient(client)
 		}
 	}
 }
 
+// SetUserLanguage ユーザーの問題の表示言語を設定し、接続中のクライアントにも反映する
+func (h *Hub) SetUserLanguage(sessionID, userID, language string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	// 参加者の接続は他のノードにある場合がある
+	if h.backplane != nil {
+		h.publish(&Envelope{Kind: EnvelopeLanguage, SessionID: sessionID, UserID: userID, Language: language})
+		return
+	}
+	h.applyUserLanguage(sessionID, userID, language)
+}
+
+// applyUserLanguage 表示言語を記録し、自ノードの接続に反映する（h.mutex を取得済みで呼ぶ）
+func (h *Hub) applyUserLanguage(sessionID, userID, language string) {
+	h.setUserLanguage(sessionID, userID, language)
+	for client := range h.sessions[sessionID] {
+		if client.UserID == userID {
+			client.Language = language
+		}
+	}
+}
+
+func (h *Hub) setUserLanguage(sessionID, userID, language string) {
+	if h.languages[sessionID] == nil {
+		h.languages[sessionID] = make(map[string]string)
+	}
+	h.languages[sessionID][userID] = language
+}
+
+// ClearSessionLanguages セッションの表示言語の設定を破棄する
+func (h *Hub) ClearSessionLanguages(sessionID string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	delete(h.languages, sessionID)
+}
+
+// SetAnswerSubmitter answer_submit の採点に使う UseCase を設定する
+func (h *Hub) SetAnswerSubmitter(submitter AnswerSubmitter) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.answerSubmitter = submitter
+}
+
+func (h *Hub) getAnswerSubmitter() AnswerSubmitter {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return h.answerSubmitter
+}
+
+// SetAdminCommandExecutor admin_control の実行に使うハンドラーを設定する
+func (h *Hub) SetAdminCommandExecutor(executor AdminCommandExecutor) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.adminExecutor = executor
+}
+
+func (h *Hub) getAdminCommandExecutor() AdminCommandExecutor {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return h.adminExecutor
+}
+
+// SetSnapshotProvider resume で送る状態の取得に使う UseCase を設定する
+func (h *Hub) SetSnapshotProvider(provider SnapshotProvider) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.snapshotProvider = provider
+}
+
+func (h *Hub) getSnapshotProvider() SnapshotProvider {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return h.snapshotProvider
+}
+
 func (h *Hub) BroadcastToUser(userID string, msg Message) {
 	h.mutex.RLock()
 	defer h.mutex.RUnlock()
 
+	if h.backplane != nil {
+		h.publish(&Envelope{Kind: EnvelopeUser, UserID: userID, Message: msg})
+		return
+	}
+	h.broadcastToUser(userID, msg)
+}
+
+// broadcastToUser 自ノードにあるユーザーの全接続に送る（h.mutex を取得済みで呼ぶ）
+func (h *Hub) broadcastToUser(userID string, msg Message) {
 	msgBytes, err := json.Marshal(msg)
 	if err != nil {
 		log.Printf("Failed to marshal message: %v", err)
@@ -188,21 +482,32 @@ func (h *Hub) BroadcastToUser(userID string, msg Message) {
 			select {
 			case client.send <- msgBytes:
 			default:
-				close(client.send)
-				delete(h.clients, client)
+				h.dropClient(client)
 			}
 		}
 	}
 }
 
+// GetSessionClientCount セッションの接続数（他のノードがある場合は最後に報告された接続数を合計する）
 func (h *Hub) GetSessionClientCount(sessionID string) int {
 	h.mutex.RLock()
-	defer h.mutex.RUnlock()
+	count := len(h.sessions[sessionID])
+	backplane, nodeID := h.backplane, h.nodeID
+	h.mutex.RUnlock()
 
-	if clients, exists := h.sessions[sessionID]; exists {
-		return len(clients)
+	if backplane == nil {
+		return count
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), backplaneTimeout)
+	defer cancel()
+
+	remote, err := backplane.RemoteClientCount(ctx, sessionID, nodeID)
+	if err != nil {
+		log.Printf("Failed to get client count from backplane: SessionID=%s: %v", sessionID, err)
+		return count
 	}
-	return 0
+	return count + remote
 }
 
 func (h *Hub) GetConnectedUserIDs(sessionID string) []string {
@@ -1,10 +1,14 @@
 package websocket
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quiz-app/internal/domain"
 )
 
 func TestHub(t *testing.T) {
@@ -253,4 +257,103 @@ func TestHubErrorHandling(t *testing.T) {
 
 		time.Sleep(10 * time.Millisecond)
 	})
-}
\ No newline at end of file
+}
+func TestHubLocalizedBroadcast(t *testing.T) {
+	newTestClient := func(hub *Hub, userID, language string) *Client {
+		client := &Client{hub: hub, send: make(chan []byte, 8), UserID: userID, SessionID: "s1", Language: language}
+		hub.registerClient(client)
+		<-client.send // 自身の参加通知
+		return client
+	}
+	receive := func(t *testing.T, client *Client) map[string]interface{} {
+		var msg Message
+		select {
+		case data := <-client.send:
+			require.NoError(t, json.Unmarshal(data, &msg))
+		default:
+			t.Fatalf("no message for %s", client.UserID)
+		}
+		return msg.Data.(map[string]interface{})
+	}
+
+	t.Run("各クライアントに表示言語の問題が送られること", func(t *testing.T) {
+		hub := NewHub()
+		hub.SetUserLanguage("s1", "user-en", "en")
+		ja := newTestClient(hub, "user-ja", "")
+		en := newTestClient(hub, "user-en", "")
+		<-ja.send // user-en の参加通知
+
+		question := domain.NewQuestion("s1", 1, "問題", []string{"赤", "青", "黄", "緑"}, 0, domain.DifficultyEasy, "色", domain.AIProviderLocal)
+		require.NoError(t, question.SetTranslation("en", domain.QuestionTranslation{Text: "Question", Options: []string{"Red", "Blue", "Yellow", "Green"}}))
+		manager := &Manager{hub: hub}
+		manager.NotifyQuestionStart("s1", question, 30)
+
+		jaQuestion := receive(t, ja)["question"].(map[string]interface{})
+		assert.Equal(t, "問題", jaQuestion["text"])
+		assert.Equal(t, "ja", jaQuestion["language"])
+
+		enQuestion := receive(t, en)["question"].(map[string]interface{})
+		assert.Equal(t, "Question", enQuestion["text"])
+		assert.Equal(t, []interface{}{"Red", "Blue", "Yellow", "Green"}, enQuestion["options"])
+	})
+
+	t.Run("問題文と選択肢のメディアのURLが送られること", func(t *testing.T) {
+		hub := NewHub()
+		client := newTestClient(hub, "user1", "")
+		manager := &Manager{hub: hub}
+
+		question := domain.NewQuestion("s1", 1, "このイントロの曲は？", []string{"A", "B"}, 0, domain.DifficultyEasy, "", domain.AIProviderLocal)
+		audio := &domain.Media{Key: "a.mp3", Type: domain.MediaTypeAudio, ContentType: "audio/mpeg", URL: "/api/v1/media/a.mp3"}
+		image := &domain.Media{Key: "b.png", Type: domain.MediaTypeImage, ContentType: "image/png", URL: "/api/v1/media/b.png", AltText: "ジャケット"}
+		require.NoError(t, question.SetMedia(audio, []*domain.Media{nil, image}))
+		manager.NotifyQuestionStart("s1", question, 30)
+
+		data := receive(t, client)["question"].(map[string]interface{})
+		media := data["media"].(map[string]interface{})
+		assert.Equal(t, "audio", media["type"])
+		assert.Equal(t, "/api/v1/media/a.mp3", media["url"])
+		optionMedia := data["optionMedia"].([]interface{})
+		require.Len(t, optionMedia, 2)
+		assert.Nil(t, optionMedia[0])
+		assert.Equal(t, "ジャケット", optionMedia[1].(map[string]interface{})["altText"])
+	})
+
+	t.Run("接続中に言語を変更すると次の通知から反映されること", func(t *testing.T) {
+		hub := NewHub()
+		client := newTestClient(hub, "user1", "")
+		manager := &Manager{hub: hub}
+		question := domain.NewQuestion("s1", 1, "問題", []string{"a", "b"}, 0, domain.DifficultyEasy, "", domain.AIProviderLocal)
+		question.Explanation = "理由"
+		require.NoError(t, question.SetTranslation("en", domain.QuestionTranslation{Text: "Question", Options: []string{"a", "b"}, Explanation: "Because"}))
+
+		manager.NotifyQuestionEnd("s1", question, true)
+		assert.Equal(t, "理由", receive(t, client)["explanation"])
+
+		manager.SetUserLanguage("s1", "user1", "en")
+		manager.NotifyQuestionEnd("s1", question, true)
+		assert.Equal(t, "Because", receive(t, client)["explanation"])
+	})
+}
+
+func TestQuestionEndData(t *testing.T) {
+	t.Run("問題形式に応じた正解を送り、非公開の場合は正解・解説を送らないこと", func(t *testing.T) {
+		numeric := domain.NewQuestion("s1", 1, "富士山の標高は？", nil, 0, domain.DifficultyEasy, "", domain.AIProviderLocal)
+		numeric.SetAnswerKey(domain.AnswerKey{Type: domain.QuestionTypeNumeric, NumericAnswer: 3776, Unit: "m"})
+		numeric.Explanation = "3776mです"
+
+		data := questionEndData(numeric, true)
+		assert.Equal(t, "numeric", data["type"])
+		assert.Equal(t, -1, data["correctAnswer"])
+		assert.Equal(t, 3776.0, data["numericAnswer"])
+		assert.Equal(t, "m", data["unit"])
+		assert.Equal(t, "3776mです", data["explanation"])
+
+		data = questionEndData(numeric, false)
+		assert.NotContains(t, data, "numericAnswer")
+		assert.Equal(t, "", data["explanation"])
+
+		multi := domain.NewQuestion("s1", 2, "問題", []string{"A", "B", "C", "D"}, 0, domain.DifficultyEasy, "", domain.AIProviderLocal)
+		multi.SetAnswerKey(domain.AnswerKey{Type: domain.QuestionTypeMultiSelect, CorrectAnswers: []int{2, 0}})
+		assert.Equal(t, []int{0, 2}, questionEndData(multi, true)["correctAnswers"])
+	})
+}
@@ -3,28 +3,36 @@ package websocket
 import (
 	"net/http"
 	"quiz-app/internal/domain"
+
+	"github.com/gorilla/websocket"
 )
 
 type Manager struct {
-	hub *Hub
+	hub      *Hub
+	upgrader *websocket.Upgrader
 }
 
-func NewManager() *Manager {
+// NewManager allowedOrigins は WebSocket の接続を受け付けるページのオリジン（CORS の許可リストと同じもの）
+func NewManager(allowedOrigins []string) *Manager {
 	hub := NewHub()
 	go hub.Run()
 
 	return &Manager{
-		hub: hub,
+		hub:      hub,
+		upgrader: newUpgrader(allowedOrigins),
 	}
 }
 
-func (m *Manager) HandleWebSocket(w http.ResponseWriter, r *http.Request, userID, sessionID, displayName string, isAdmin bool) error {
-	conn, err := upgrader.Upgrade(w, r, nil)
+func (m *Manager) HandleWebSocket(w http.ResponseWriter, r *http.Request, userID, sessionID, displayName, language string, isAdmin bool) error {
+	conn, err := m.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		return err
 	}
 
 	client := NewClient(m.hub, conn, userID, sessionID, displayName, isAdmin)
+	if language = domain.NormalizeLanguage(language); domain.IsSupportedLanguage(language) {
+		client.Language = language
+	}
 	
 	// クライアントをハブに登録
 	m.hub.register <- client
@@ -36,69 +44,152 @@ func (m *Manager) HandleWebSocket(w http.ResponseWriter, r *http.Request, userID
 	return nil
 }
 
-// 問題開始の通知
+// 問題開始の通知（各クライアントには表示言語の版を送り、翻訳がなければ元の言語で送る）
 func (m *Manager) NotifyQuestionStart(sessionID string, question *domain.Question, timeLimit int) {
-	msg := Message{
-		Type:      string(MessageTypeQuestionStart),
-		SessionID: sessionID,
-		Data: map[string]interface{}{
-			"question": map[string]interface{}{
-				"id":       question.ID,
-				"text":     question.Text,
-				"options":  question.Options,
-				"round":    question.Round,
-				"category": question.Category,
+	m.hub.BroadcastLocalizedToSession(sessionID, func(language string) Message {
+		localized := question.Localized(language)
+		return Message{
+			Type:      string(MessageTypeQuestionStart),
+			SessionID: sessionID,
+			Data: map[string]interface{}{
+				"question":  questionData(localized),
+				"timeLimit": timeLimit,
 			},
-			"timeLimit": timeLimit,
-		},
-		Timestamp: getCurrentTimestamp(),
+			Timestamp: getCurrentTimestamp(),
+		}
+	})
+}
+
+// questionData 参加者に送る問題（正解は含めない。再接続時の状態でも使う）
+func questionData(question *domain.Question) map[string]interface{} {
+	return map[string]interface{}{
+		"id":       question.ID,
+		"text":     question.Text,
+		"options":  question.Options,
+		"round":    question.Round,
+		"category": question.Category,
+		"language": question.Language,
+		"type":     string(question.Kind()),
+		"unit":     question.Unit,
+		// 画像・音声（URL は blob store の配信用 URL）
+		"media":       question.Media,
+		"optionMedia": question.OptionMedia,
 	}
+}
 
-	m.hub.BroadcastToSession(sessionID, msg)
+// 問題終了の通知（正解・解説は reveal の場合のみ送る。翻訳があれば表示言語の解説を送る）
+func (m *Manager) NotifyQuestionEnd(sessionID string, question *domain.Question, reveal bool) {
+	m.hub.BroadcastLocalizedToSession(sessionID, func(language string) Message {
+		return Message{
+			Type:      string(MessageTypeQuestionEnd),
+			SessionID: sessionID,
+			Data:      questionEndData(question.Localized(language), reveal),
+			Timestamp: getCurrentTimestamp(),
+		}
+	})
+}
+
+// questionEndData 問題終了のペイロード（正解は問題形式に応じた項目で送る）
+// correctAnswer は単一選択・○×の正解番号（それ以外の形式と非公開の場合は -1）
+func questionEndData(question *domain.Question, reveal bool) map[string]interface{} {
+	data := map[string]interface{}{
+		"questionId":    question.ID,
+		"type":          string(question.Kind()),
+		"correctAnswer": -1,
+		"explanation":   "",
+	}
+	if !reveal {
+		return data
+	}
+
+	data["explanation"] = question.Explanation
+	switch question.Kind() {
+	case domain.QuestionTypeChoice, domain.QuestionTypeTrueFalse:
+		data["correctAnswer"] = question.CorrectAnswer
+	case domain.QuestionTypeMultiSelect, domain.QuestionTypeOrdering:
+		data["correctAnswers"] = question.CorrectAnswers
+	case domain.QuestionTypeNumeric:
+		data["numericAnswer"] = question.NumericAnswer
+		data["unit"] = question.Unit
+	}
+	return data
 }
 
-// 問題終了の通知
-func (m *Manager) NotifyQuestionEnd(sessionID string, questionID string, correctAnswer int) {
+// ラウンド結果の通知
+func (m *Manager) NotifyRoundResult(sessionID string, result *domain.RoundResult) {
 	msg := Message{
-		Type:      string(MessageTypeQuestionEnd),
+		Type:      string(MessageTypeRoundResult),
 		SessionID: sessionID,
-		Data: map[string]interface{}{
-			"questionId":    questionID,
-			"correctAnswer": correctAnswer,
-		},
+		Data:      RoundResultData(result),
 		Timestamp: getCurrentTimestamp(),
 	}
 
 	m.hub.BroadcastToSession(sessionID, msg)
 }
 
-// ラウンド結果の通知
-func (m *Manager) NotifyRoundResult(sessionID string, survivors []*domain.Participant, eliminated []*domain.Participant, round int) {
-	survivorData := make([]map[string]interface{}, len(survivors))
-	for i, p := range survivors {
-		survivorData[i] = map[string]interface{}{
-			"userId":      p.UserID,
-			"displayName": p.DisplayName,
-			"score":       p.Score,
+// RoundResultData ラウンド結果を進行方式に応じたペイロードに変換する
+// survival / lives: survivors / eliminated / lostLife（各参加者の残りライフ付き）
+// classic:          correct / rankings / remainingRounds
+func RoundResultData(result *domain.RoundResult) map[string]interface{} {
+	data := map[string]interface{}{
+		"round":      result.Round,
+		"mode":       string(result.Mode),
+		"survivors":  participantResultData(result.Survivors, result.Mode),
+		"eliminated": participantResultData(result.Eliminated, result.Mode),
+		"gameOver":   result.GameOver,
+	}
+
+	switch result.Mode {
+	case domain.GameModeClassic:
+		correct := make([]string, len(result.Correct))
+		for i, p := range result.Correct {
+			correct[i] = p.UserID
 		}
+
+		rankings := make([]map[string]interface{}, len(result.Rankings))
+		for i, p := range result.Rankings {
+			rankings[i] = map[string]interface{}{
+				"rank":           i + 1,
+				"userId":         p.UserID,
+				"displayName":    p.DisplayName,
+				"score":          p.Score,
+				"correctAnswers": p.CorrectAnswers,
+			}
+		}
+
+		data["correct"] = correct
+		data["rankings"] = rankings
+		data["remainingRounds"] = result.RemainingRounds
+	default:
+		data["lostLife"] = participantResultData(result.LostLife, result.Mode)
 	}
 
-	eliminatedData := make([]map[string]interface{}, len(eliminated))
-	for i, p := range eliminated {
-		eliminatedData[i] = map[string]interface{}{
+	return data
+}
+
+func participantResultData(participants []*domain.Participant, mode domain.GameMode) []map[string]interface{} {
+	data := make([]map[string]interface{}, len(participants))
+	for i, p := range participants {
+		data[i] = map[string]interface{}{
 			"userId":      p.UserID,
 			"displayName": p.DisplayName,
 			"score":       p.Score,
 		}
+		if mode != domain.GameModeClassic {
+			data[i]["lives"] = p.Lives
+		}
 	}
+	return data
+}
 
+// 順位更新の通知（ラウンド結果処理後）
+func (m *Manager) NotifyLeaderboardUpdate(sessionID string, round int, leaderboard []*domain.LeaderboardEntry) {
 	msg := Message{
-		Type:      string(MessageTypeRoundResult),
+		Type:      string(MessageTypeLeaderboardUpdate),
 		SessionID: sessionID,
 		Data: map[string]interface{}{
-			"round":      round,
-			"survivors":  survivorData,
-			"eliminated": eliminatedData,
+			"round":       round,
+			"leaderboard": leaderboard,
 		},
 		Timestamp: getCurrentTimestamp(),
 	}
@@ -134,6 +225,8 @@ func (m *Manager) NotifySessionDeleted(sessionID string) {
 	}
 
 	m.hub.BroadcastToSession(sessionID, msg)
+	m.hub.ClearSessionLanguages(sessionID)
+	m.hub.events.clear(sessionID)
 }
 
 // 敗者復活戦開始の通知
@@ -193,6 +286,11 @@ func (m *Manager) SendErrorToUser(userID, errorMsg string) {
 	m.hub.BroadcastToUser(userID, msg)
 }
 
+// SetUserLanguage 参加者の問題の表示言語を設定する（以降の問題から反映される）
+func (m *Manager) SetUserLanguage(sessionID, userID, language string) {
+	m.hub.SetUserLanguage(sessionID, userID, language)
+}
+
 // セッションの接続クライアント数を取得
 func (m *Manager) GetSessionClientCount(sessionID string) int {
 	return m.hub.GetSessionClientCount(sessionID)
@@ -0,0 +1,187 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// redisEventsChannel 全ノードが購読するチャネル（1つのチャネルに流すことで全ノードに同じ順序で届く）
+	redisEventsChannel = "quiz:ws:events"
+	// redisNodesKey 接続数を報告したノードと報告の有効期限（Unix ミリ秒）の sorted set
+	redisNodesKey = "quiz:ws:nodes"
+	// redisSeqTTL 最後の通知からセッションの通番を保持する期間
+	redisSeqTTL = 24 * time.Hour
+)
+
+// redisPublishScript セッションの通番を振って配信する（INCR と PUBLISH を不可分にし、通番の順に届くようにする）
+// KEYS[1]: 通番のキー、ARGV[1]: チャネル、ARGV[2]: Envelope の JSON、ARGV[3]: 通番の保持期間（秒）
+// チャネルはキーではないため ARGV で渡す（KEYS を通番のキーだけにしてクラスタでも1つのスロットで完結させる）
+var redisPublishScript = redis.NewScript(`
+local seq = redis.call('INCR', KEYS[1])
+redis.call('EXPIRE', KEYS[1], ARGV[3])
+redis.call('PUBLISH', ARGV[1], seq .. ' ' .. ARGV[2])
+return seq
+`)
+
+// RedisBackplane Redis の pub/sub で通知を、ノードごとの hash で接続数を共有する Backplane
+type RedisBackplane struct {
+	client *redis.Client
+	pubsub *redis.PubSub
+}
+
+// NewRedisBackplane redisURL（redis://[:password@]host:port/db）の Redis に接続する
+func NewRedisBackplane(ctx context.Context, redisURL string) (*RedisBackplane, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	return &RedisBackplane{client: client}, nil
+}
+
+func redisSeqKey(sessionID string) string {
+	return "quiz:ws:seq:" + sessionID
+}
+
+func redisCountsKey(nodeID string) string {
+	return "quiz:ws:counts:" + nodeID
+}
+
+// Publish チャネルに "<通番> <Envelope の JSON>" を配信する（通番を振らない通知は0）
+func (b *RedisBackplane) Publish(ctx context.Context, env *Envelope) error {
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to marshal envelope: %w", err)
+	}
+
+	if !env.sequenced() {
+		return b.client.Publish(ctx, redisEventsChannel, "0 "+string(payload)).Err()
+	}
+	keys := []string{redisSeqKey(env.SessionID)}
+	return redisPublishScript.Run(ctx, b.client, keys, redisEventsChannel, string(payload), int(redisSeqTTL.Seconds())).Err()
+}
+
+// decodeRedisFrame Publish が配信した "<通番> <Envelope の JSON>" を戻す
+func decodeRedisFrame(payload string) (*Envelope, error) {
+	seqText, body, ok := strings.Cut(payload, " ")
+	if !ok {
+		return nil, errors.New("malformed backplane frame")
+	}
+	seq, err := strconv.ParseInt(seqText, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("malformed backplane seq: %w", err)
+	}
+
+	var env Envelope
+	if err := json.Unmarshal([]byte(body), &env); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal envelope: %w", err)
+	}
+	if seq == 0 {
+		return &env, nil
+	}
+	return env.withSeq(seq), nil
+}
+
+// Subscribe チャネルを購読し、受け取った通知を順に handler に渡す
+// Redis との接続が切れた間の通知は失われる（クライアントは再接続時の resume で状態を取り直す）
+func (b *RedisBackplane) Subscribe(handler func(env *Envelope)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), backplaneTimeout)
+	defer cancel()
+
+	pubsub := b.client.Subscribe(ctx, redisEventsChannel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return err
+	}
+	b.pubsub = pubsub
+
+	go func() {
+		for msg := range pubsub.Channel() {
+			env, err := decodeRedisFrame(msg.Payload)
+			if err != nil {
+				log.Printf("Failed to decode backplane message: %v", err)
+				continue
+			}
+			handler(env)
+		}
+	}()
+	return nil
+}
+
+// ReportClientCounts ノードの接続数を hash に置き換え、ttl 後に期限切れにする
+func (b *RedisBackplane) ReportClientCounts(ctx context.Context, nodeID string, counts map[string]int, ttl time.Duration) error {
+	key := redisCountsKey(nodeID)
+	expiresAt := time.Now().Add(ttl).UnixMilli()
+
+	_, err := b.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(counts) > 0 {
+			values := make(map[string]interface{}, len(counts))
+			for sessionID, count := range counts {
+				values[sessionID] = count
+			}
+			pipe.HSet(ctx, key, values)
+		}
+		pipe.PExpire(ctx, key, ttl)
+		pipe.ZAdd(ctx, redisNodesKey, redis.Z{Score: float64(expiresAt), Member: nodeID})
+		return nil
+	})
+	return err
+}
+
+// RemoteClientCount 報告が有効な他のノードの接続数を合計する（期限切れのノードは一覧から外す）
+func (b *RedisBackplane) RemoteClientCount(ctx context.Context, sessionID, excludeNodeID string) (int, error) {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	if err := b.client.ZRemRangeByScore(ctx, redisNodesKey, "-inf", "("+now).Err(); err != nil {
+		return 0, err
+	}
+	nodeIDs, err := b.client.ZRange(ctx, redisNodesKey, 0, -1).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	pipe := b.client.Pipeline()
+	var counts []*redis.StringCmd
+	for _, nodeID := range nodeIDs {
+		if nodeID != excludeNodeID {
+			counts = append(counts, pipe.HGet(ctx, redisCountsKey(nodeID), sessionID))
+		}
+	}
+	if len(counts) == 0 {
+		return 0, nil
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+
+	total := 0
+	for _, cmd := range counts {
+		count, err := cmd.Int()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+func (b *RedisBackplane) Close() error {
+	if b.pubsub != nil {
+		b.pubsub.Close()
+	}
+	return b.client.Close()
+}
@@ -0,0 +1,164 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisBackplane(t *testing.T) {
+	ctx := context.Background()
+
+	newBackplane := func(t *testing.T, mr *miniredis.Miniredis) *RedisBackplane {
+		backplane, err := NewRedisBackplane(ctx, "redis://"+mr.Addr()+"/0")
+		require.NoError(t, err)
+		t.Cleanup(func() { backplane.Close() })
+		return backplane
+	}
+	// subscribe 受け取った通知を順に流すチャネルを返す
+	subscribe := func(t *testing.T, backplane *RedisBackplane) <-chan *Envelope {
+		received := make(chan *Envelope, 16)
+		require.NoError(t, backplane.Subscribe(func(env *Envelope) { received <- env }))
+		return received
+	}
+	receive := func(t *testing.T, received <-chan *Envelope) *Envelope {
+		select {
+		case env := <-received:
+			return env
+		case <-time.After(time.Second):
+			t.Fatal("no envelope received")
+			return nil
+		}
+	}
+
+	t.Run("配信されたフレームから通番と Envelope を戻せること", func(t *testing.T) {
+		payload, err := json.Marshal(&Envelope{
+			Kind:      EnvelopeSession,
+			SessionID: "s1",
+			Message:   Message{Type: "question_start"},
+			Localized: map[string]Message{"en": {Type: "question_start", SessionID: "s1"}},
+		})
+		require.NoError(t, err)
+
+		env, err := decodeRedisFrame("7 " + string(payload))
+		require.NoError(t, err)
+		assert.Equal(t, "s1", env.SessionID)
+		assert.Equal(t, int64(7), env.Message.Seq)
+		assert.Equal(t, int64(7), env.Localized["en"].Seq)
+
+		// 通番0は通番を振らない通知
+		env, err = decodeRedisFrame("0 " + string(payload))
+		require.NoError(t, err)
+		assert.Zero(t, env.Message.Seq)
+
+		for _, frame := range []string{string(payload), "x " + string(payload), "1 {"} {
+			_, err := decodeRedisFrame(frame)
+			assert.Error(t, err, frame)
+		}
+	})
+
+	t.Run("セッションへの通知はセッションごとの通番の順に全ノードに届くこと", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		publisher, other := newBackplane(t, mr), newBackplane(t, mr)
+		received, otherReceived := subscribe(t, publisher), subscribe(t, other)
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, publisher.Publish(ctx, &Envelope{Kind: EnvelopeSession, SessionID: "s1", Message: Message{Type: "session_update"}}))
+		}
+		require.NoError(t, other.Publish(ctx, &Envelope{Kind: EnvelopeSession, SessionID: "s2", Message: Message{Type: "session_update"}}))
+
+		for _, ch := range []<-chan *Envelope{received, otherReceived} {
+			for seq := int64(1); seq <= 3; seq++ {
+				env := receive(t, ch)
+				assert.Equal(t, "s1", env.SessionID)
+				assert.Equal(t, seq, env.Message.Seq)
+			}
+			env := receive(t, ch)
+			assert.Equal(t, "s2", env.SessionID)
+			assert.Equal(t, int64(1), env.Message.Seq)
+		}
+
+		// 通番は保持期間付きでセッションごとのキーに保持される
+		seq, err := mr.Get(redisSeqKey("s1"))
+		require.NoError(t, err)
+		assert.Equal(t, "3", seq)
+		assert.Equal(t, redisSeqTTL, mr.TTL(redisSeqKey("s1")))
+	})
+
+	t.Run("一時的な通知とユーザーへの通知は通番を振らずに届くこと", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		backplane := newBackplane(t, mr)
+		received := subscribe(t, backplane)
+
+		require.NoError(t, backplane.Publish(ctx, &Envelope{Kind: EnvelopeSession, SessionID: "s1", Transient: true, Message: Message{Type: "answer_submitted"}}))
+		require.NoError(t, backplane.Publish(ctx, &Envelope{Kind: EnvelopeUser, UserID: "u1", Message: Message{Type: "error"}}))
+
+		env := receive(t, received)
+		assert.Equal(t, "answer_submitted", env.Message.Type)
+		assert.Zero(t, env.Message.Seq)
+		env = receive(t, received)
+		assert.Equal(t, "u1", env.UserID)
+		assert.Zero(t, env.Message.Seq)
+
+		assert.False(t, mr.Exists(redisSeqKey("s1")))
+	})
+
+	t.Run("報告が有効な他のノードの接続数だけを合計すること", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		backplane := newBackplane(t, mr)
+
+		require.NoError(t, backplane.ReportClientCounts(ctx, "node1", map[string]int{"s1": 2, "s2": 1}, time.Minute))
+		require.NoError(t, backplane.ReportClientCounts(ctx, "node2", map[string]int{"s1": 3}, time.Minute))
+		require.NoError(t, backplane.ReportClientCounts(ctx, "stale", map[string]int{"s1": 10}, time.Millisecond))
+		time.Sleep(5 * time.Millisecond)
+
+		count, err := backplane.RemoteClientCount(ctx, "s1", "node1")
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		count, err = backplane.RemoteClientCount(ctx, "s2", "node2")
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		// 接続がなくなったノードの報告は置き換えられる
+		require.NoError(t, backplane.ReportClientCounts(ctx, "node2", map[string]int{}, time.Minute))
+		count, err = backplane.RemoteClientCount(ctx, "s1", "node1")
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("Redis を共有するノードに接続したクライアントに共通の通番で届くこと", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		hubs := make([]*Hub, 2)
+		for i := range hubs {
+			hubs[i] = NewHub()
+			require.NoError(t, hubs[i].SetBackplane(newBackplane(t, mr), ""))
+			t.Cleanup(func() { hubs[i].Close() })
+		}
+		player1 := &Client{hub: hubs[0], send: make(chan []byte, 16), UserID: "player1", SessionID: "s1"}
+		player2 := &Client{hub: hubs[1], send: make(chan []byte, 16), UserID: "player2", SessionID: "s1"}
+		hubs[0].registerClient(player1)
+		hubs[1].registerClient(player2)
+
+		hubs[1].BroadcastToSession("s1", Message{Type: "session_update", SessionID: "s1"})
+
+		// 2人の参加通知の後に届く
+		for _, client := range []*Client{player1, player2} {
+			var last Message
+			for last.Type != "session_update" {
+				select {
+				case data := <-client.send:
+					require.NoError(t, json.Unmarshal(data, &last))
+				case <-time.After(time.Second):
+					t.Fatalf("no session_update for %s", client.UserID)
+				}
+			}
+			assert.Equal(t, int64(3), last.Seq)
+		}
+	})
+}
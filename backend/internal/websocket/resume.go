@@ -0,0 +1,227 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"quiz-app/internal/domain"
+)
+
+const (
+	// eventBufferSize 再接続時に再送するため、セッションごとに保持する直近の通知の数
+	eventBufferSize = 256
+	// reconnectGracePeriod 切断から participant_leave を通知するまでの猶予（この間に再接続すれば通知しない）
+	reconnectGracePeriod = 10 * time.Second
+	// resumeTimeout 再接続時の状態の取得の上限時間
+	resumeTimeout = 10 * time.Second
+)
+
+// SnapshotProvider 再接続したクライアントに送るセッションの現在の状態を返す（usecase.QuizUseCase が実装する）
+type SnapshotProvider interface {
+	GetSessionSnapshot(ctx context.Context, sessionID, userID string) (*domain.SessionSnapshot, error)
+}
+
+// bufferedEvent セッションに送った通知（表示言語ごとに作る通知は localize で作り直す）
+type bufferedEvent struct {
+	msg      Message
+	localize func(language string) Message
+}
+
+// message 表示言語に応じた通知を返す（通番と送信時刻は最初に送ったものを使う）
+func (e bufferedEvent) message(language string) Message {
+	if e.localize == nil {
+		return e.msg
+	}
+	msg := e.localize(language)
+	msg.Seq = e.msg.Seq
+	msg.Timestamp = e.msg.Timestamp
+	return msg
+}
+
+// eventLog セッションの通知の通番と直近 eventBufferSize 件のリングバッファ
+type eventLog struct {
+	seq    int64
+	events []bufferedEvent // seq の昇順（古いものから捨てる）
+}
+
+// eventStore セッションごとの eventLog（Hub の mutex とは別に排他する）
+type eventStore struct {
+	mu   sync.Mutex
+	logs map[string]*eventLog
+}
+
+func newEventStore() *eventStore {
+	return &eventStore{logs: make(map[string]*eventLog)}
+}
+
+// record 通知に通番を振って保持し、通番を返す
+func (s *eventStore) record(sessionID string, msg Message, localize func(language string) Message) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l := s.log(sessionID)
+	l.seq++
+	msg.Seq = l.seq
+	l.push(bufferedEvent{msg: msg, localize: localize})
+	return l.seq
+}
+
+// store Backplane で通番が振られた通知を保持する（通番は全ノードで共通）
+func (s *eventStore) store(sessionID string, e bufferedEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l := s.log(sessionID)
+	if e.msg.Seq > l.seq {
+		l.seq = e.msg.Seq
+	}
+	l.push(e)
+}
+
+func (s *eventStore) log(sessionID string) *eventLog {
+	l, ok := s.logs[sessionID]
+	if !ok {
+		l = &eventLog{}
+		s.logs[sessionID] = l
+	}
+	return l
+}
+
+func (l *eventLog) push(e bufferedEvent) {
+	if len(l.events) == eventBufferSize {
+		copy(l.events, l.events[1:])
+		l.events = l.events[:eventBufferSize-1]
+	}
+	l.events = append(l.events, e)
+}
+
+// since lastSeq より後の通知と最新の通番を返す
+// 欠落分の一部を既に捨てている場合、または lastSeq が最新より新しい場合（サーバーの再起動後など）は complete が false
+// lastSeq が0の場合は通知を返さない（状態はスナップショットで受け取る）
+func (s *eventStore) since(sessionID string, lastSeq int64) (events []bufferedEvent, latest int64, complete bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.logs[sessionID]
+	if !ok {
+		return nil, 0, lastSeq <= 0
+	}
+	if lastSeq <= 0 {
+		return nil, l.seq, true
+	}
+	if lastSeq > l.seq {
+		return nil, l.seq, false
+	}
+
+	complete = len(l.events) == 0 || l.events[0].msg.Seq <= lastSeq+1
+	for _, e := range l.events {
+		if e.msg.Seq > lastSeq {
+			events = append(events, e)
+		}
+	}
+	return events, l.seq, complete
+}
+
+func (s *eventStore) clear(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.logs, sessionID)
+}
+
+// SetSnapshotProvider 再接続時の状態の取得に使う UseCase を設定する（未設定の間は見逃した通知のみ再送する）
+func (m *Manager) SetSnapshotProvider(provider SnapshotProvider) {
+	m.hub.SetSnapshotProvider(provider)
+}
+
+// handleResume 再接続したクライアントに、現在の状態と lastSeq 以降に見逃した通知を resume_state で送る
+// resume_state を受け取った後の通知は通常どおり届くため、クライアントは seq で重複を除く
+func (c *Client) handleResume(msg ClientMessage) {
+	var req struct {
+		LastSeq int64 `json:"lastSeq"`
+	}
+	if raw, err := json.Marshal(msg.Data); err == nil {
+		json.Unmarshal(raw, &req)
+	}
+	if c.SessionID == "" {
+		c.SendError("Session ID is required to resume")
+		return
+	}
+
+	events, latest, complete := c.hub.events.since(c.SessionID, req.LastSeq)
+	missed := make([]Message, len(events))
+	for i, e := range events {
+		missed[i] = e.message(c.Language)
+	}
+
+	data := map[string]interface{}{
+		"seq":          latest,
+		"missedEvents": missed,
+		// true の場合は見逃した通知の一部を再送できないため、snapshot を正とする
+		"truncated": !complete,
+		"snapshot":  nil,
+	}
+
+	if provider := c.hub.getSnapshotProvider(); provider != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), resumeTimeout)
+		defer cancel()
+
+		snapshot, err := provider.GetSessionSnapshot(ctx, c.SessionID, c.UserID)
+		if err != nil {
+			log.Printf("Failed to get session snapshot: UserID=%s, SessionID=%s: %v", c.UserID, c.SessionID, err)
+		} else {
+			data["snapshot"] = snapshotData(snapshot, c.Language)
+		}
+	}
+
+	c.SendMessage(Message{
+		Type:      string(MessageTypeResumeState),
+		SessionID: c.SessionID,
+		Data:      data,
+		Timestamp: getCurrentTimestamp(),
+	})
+}
+
+// snapshotData セッションの状態のペイロード（問題は question_start と同じ形で表示言語の版を送る）
+func snapshotData(snapshot *domain.SessionSnapshot, language string) map[string]interface{} {
+	session := snapshot.Session
+	data := map[string]interface{}{
+		"session": map[string]interface{}{
+			"id":           session.ID,
+			"title":        session.Title,
+			"status":       string(session.Status),
+			"currentRound": session.CurrentRound,
+			"gameMode":     string(session.Settings.Mode()),
+			"timeLimit":    session.Settings.TimeLimit,
+		},
+		"question":    nil,
+		"participant": nil,
+	}
+
+	if snapshot.Question != nil {
+		data["question"] = map[string]interface{}{
+			"question":      questionData(snapshot.Question.Localized(language)),
+			"timeLimit":     session.Settings.TimeLimit,
+			"open":          snapshot.QuestionOpen,
+			"remainingTime": snapshot.RemainingTime.Milliseconds(), // ミリ秒
+		}
+	}
+
+	if p := snapshot.Participant; p != nil {
+		participant := map[string]interface{}{
+			"userId":         p.UserID,
+			"displayName":    p.DisplayName,
+			"status":         string(p.Status),
+			"score":          p.Score,
+			"correctAnswers": p.CorrectAnswers,
+		}
+		if session.Settings.Mode() != domain.GameModeClassic {
+			participant["lives"] = p.Lives
+		}
+		data["participant"] = participant
+	}
+	return data
+}
@@ -0,0 +1,210 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"quiz-app/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSnapshotProvider struct {
+	snapshot *domain.SessionSnapshot
+}
+
+func (f *fakeSnapshotProvider) GetSessionSnapshot(ctx context.Context, sessionID, userID string) (*domain.SessionSnapshot, error) {
+	return f.snapshot, nil
+}
+
+func TestEventStore(t *testing.T) {
+	t.Run("通番を振り、lastSeq より後の通知を返すこと", func(t *testing.T) {
+		store := newEventStore()
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, int64(i+1), store.record("s1", Message{Type: "e"}, nil))
+		}
+		assert.Equal(t, int64(1), store.record("s2", Message{Type: "e"}, nil))
+
+		events, latest, complete := store.since("s1", 1)
+		assert.True(t, complete)
+		assert.Equal(t, int64(3), latest)
+		require.Len(t, events, 2)
+		assert.Equal(t, int64(2), events[0].msg.Seq)
+		assert.Equal(t, int64(3), events[1].msg.Seq)
+
+		events, _, complete = store.since("s1", 3)
+		assert.True(t, complete)
+		assert.Empty(t, events)
+	})
+
+	t.Run("バッファから溢れた通知がある場合と、サーバーより新しい lastSeq は complete が false になること", func(t *testing.T) {
+		store := newEventStore()
+		for i := 0; i < eventBufferSize+10; i++ {
+			store.record("s1", Message{Type: "e"}, nil)
+		}
+
+		events, latest, complete := store.since("s1", 5)
+		assert.False(t, complete)
+		assert.Equal(t, int64(eventBufferSize+10), latest)
+		assert.Len(t, events, eventBufferSize)
+		assert.Equal(t, int64(11), events[0].msg.Seq)
+
+		_, _, complete = store.since("s1", 10)
+		assert.True(t, complete)
+
+		events, _, complete = store.since("s1", eventBufferSize+100)
+		assert.False(t, complete)
+		assert.Empty(t, events)
+	})
+}
+
+func TestHandleResume(t *testing.T) {
+	receive := func(t *testing.T, client *Client) Message {
+		var msg Message
+		select {
+		case data := <-client.send:
+			require.NoError(t, json.Unmarshal(data, &msg))
+		default:
+			t.Fatalf("no message for %s", client.UserID)
+		}
+		return msg
+	}
+
+	t.Run("現在の状態と、見逃した通知を表示言語で再送すること", func(t *testing.T) {
+		hub := NewHub()
+		manager := &Manager{hub: hub}
+		question := domain.NewQuestion("s1", 2, "問題", []string{"赤", "青"}, 0, domain.DifficultyEasy, "", domain.AIProviderLocal)
+		question.ID = "q2"
+		require.NoError(t, question.SetTranslation("en", domain.QuestionTranslation{Text: "Question", Options: []string{"Red", "Blue"}}))
+
+		session := domain.NewSession("クイズ", 100, domain.Settings{TimeLimit: 30, GameMode: domain.GameModeLives})
+		session.ID = "s1"
+		require.NoError(t, session.Start())
+		participant := &domain.Participant{UserID: "user1", SessionID: "s1", Status: domain.ParticipantStatusActive, Score: 20, Lives: 2}
+		hub.SetSnapshotProvider(&fakeSnapshotProvider{snapshot: &domain.SessionSnapshot{
+			Session:       session,
+			Question:      question,
+			QuestionOpen:  true,
+			RemainingTime: 12500 * time.Millisecond,
+			Participant:   participant,
+		}})
+
+		// 切断中に送られた通知
+		manager.NotifySessionUpdate("s1", session)
+		manager.NotifyQuestionStart("s1", question, 30)
+
+		client := &Client{hub: hub, send: make(chan []byte, 8), UserID: "user1", SessionID: "s1", Language: "en"}
+		hub.registerClient(client)
+		receive(t, client) // 自身の参加通知
+
+		client.handleMessage(ClientMessage{Type: "resume", Data: map[string]interface{}{"lastSeq": 1}})
+
+		msg := receive(t, client)
+		require.Equal(t, string(MessageTypeResumeState), msg.Type)
+		data := msg.Data.(map[string]interface{})
+		assert.Equal(t, float64(3), data["seq"])
+		assert.Equal(t, false, data["truncated"])
+
+		missed := data["missedEvents"].([]interface{})
+		require.Len(t, missed, 2)
+		questionStart := missed[0].(map[string]interface{})
+		assert.Equal(t, "question_start", questionStart["type"])
+		assert.Equal(t, float64(2), questionStart["seq"])
+		assert.Equal(t, "Question", questionStart["data"].(map[string]interface{})["question"].(map[string]interface{})["text"])
+		assert.Equal(t, "participant_join", missed[1].(map[string]interface{})["type"])
+
+		snapshot := data["snapshot"].(map[string]interface{})
+		assert.Equal(t, "active", snapshot["session"].(map[string]interface{})["status"])
+		current := snapshot["question"].(map[string]interface{})
+		assert.Equal(t, true, current["open"])
+		assert.Equal(t, float64(12500), current["remainingTime"])
+		assert.Equal(t, "Question", current["question"].(map[string]interface{})["text"])
+		assert.NotContains(t, current["question"], "correctAnswer")
+		own := snapshot["participant"].(map[string]interface{})
+		assert.Equal(t, "active", own["status"])
+		assert.Equal(t, float64(20), own["score"])
+		assert.Equal(t, float64(2), own["lives"])
+	})
+
+	t.Run("セッション全体への通知にのみ通番が付くこと", func(t *testing.T) {
+		hub := NewHub()
+		client := &Client{hub: hub, send: make(chan []byte, 8), UserID: "user1", SessionID: "s1"}
+		hub.registerClient(client)
+		assert.Equal(t, int64(1), receive(t, client).Seq)
+
+		hub.BroadcastToUser("user1", Message{Type: "error"})
+		assert.Zero(t, receive(t, client).Seq)
+	})
+
+	t.Run("回答者数は通番を振らず、再送用のバッファから他の通知を押し出さないこと", func(t *testing.T) {
+		hub := NewHub()
+		client := &Client{hub: hub, send: make(chan []byte, 8), UserID: "user1", SessionID: "s1"}
+		hub.registerClient(client)
+		receive(t, client)
+		hub.BroadcastToSession("s1", Message{Type: string(MessageTypeQuestionStart)})
+		receive(t, client)
+
+		for i := 1; i <= eventBufferSize; i++ {
+			hub.broadcastAnswerCount("s1", "q1", i)
+			msg := receive(t, client)
+			assert.Equal(t, string(MessageTypeAnswerSubmitted), msg.Type)
+			assert.Zero(t, msg.Seq)
+		}
+
+		events, latest, complete := hub.events.since("s1", 1)
+		assert.True(t, complete)
+		assert.Equal(t, int64(2), latest)
+		require.Len(t, events, 1)
+		assert.Equal(t, string(MessageTypeQuestionStart), events[0].msg.Type)
+	})
+}
+
+func TestReconnectGracePeriod(t *testing.T) {
+	newTestClient := func(hub *Hub, userID string) *Client {
+		client := &Client{hub: hub, send: make(chan []byte, 8), UserID: userID, SessionID: "s1"}
+		hub.registerClient(client)
+		return client
+	}
+	messageTypes := func(client *Client) []string {
+		var types []string
+		for len(client.send) > 0 {
+			var msg Message
+			json.Unmarshal(<-client.send, &msg)
+			types = append(types, msg.Type)
+		}
+		return types
+	}
+
+	t.Run("猶予時間内に再接続した場合は離脱・参加を通知しないこと", func(t *testing.T) {
+		hub := NewHub()
+		hub.leaveGrace = 50 * time.Millisecond
+		admin := newTestClient(hub, "admin")
+		phone := newTestClient(hub, "player")
+		messageTypes(admin)
+
+		hub.unregisterClient(phone)
+		newTestClient(hub, "player")
+		time.Sleep(100 * time.Millisecond)
+
+		assert.Empty(t, messageTypes(admin))
+	})
+
+	t.Run("猶予時間を過ぎると participant_leave を通知すること", func(t *testing.T) {
+		hub := NewHub()
+		hub.leaveGrace = 10 * time.Millisecond
+		admin := newTestClient(hub, "admin")
+		phone := newTestClient(hub, "player")
+		messageTypes(admin)
+
+		hub.unregisterClient(phone)
+		assert.Eventually(t, func() bool {
+			hub.mutex.RLock()
+			defer hub.mutex.RUnlock()
+			return len(admin.send) > 0
+		}, time.Second, 5*time.Millisecond)
+		assert.Equal(t, []string{"participant_leave"}, messageTypes(admin))
+	})
+}
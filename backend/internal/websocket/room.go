@@ -0,0 +1,241 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"quiz-app/internal/domain"
+)
+
+// sessionAccessTimeout セッションへの参加・購読の検証の上限時間
+const sessionAccessTimeout = 10 * time.Second
+
+// SessionAuthorizer セッションへの参加・購読を検証する（usecase.SessionUseCase が実装する）
+// セッションがなければ domain.ErrSessionNotFound、参加者でなければ domain.ErrParticipantNotFound を返す（管理者は参加者でなくてよい）
+type SessionAuthorizer interface {
+	AuthorizeSessionAccess(ctx context.Context, sessionID, userID string, isAdmin bool) error
+}
+
+// SetSessionAuthorizer セッションへの参加・購読の検証に使う UseCase を設定する（未設定の間は参加・購読を受け付けない）
+func (m *Manager) SetSessionAuthorizer(authorizer SessionAuthorizer) {
+	m.hub.SetSessionAuthorizer(authorizer)
+}
+
+// SetSessionAuthorizer セッションへの参加・購読の検証に使う UseCase を設定する
+func (h *Hub) SetSessionAuthorizer(authorizer SessionAuthorizer) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.sessionAuthorizer = authorizer
+}
+
+func (h *Hub) getSessionAuthorizer() SessionAuthorizer {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return h.sessionAuthorizer
+}
+
+// ErrSessionAuthorizerUnset セッションへの参加・購読の検証に使う UseCase が未設定
+var ErrSessionAuthorizerUnset = errors.New("session authorizer is not set")
+
+// AuthorizeSessionAccess 接続時に指定されたセッションへの参加を検証する（接続を受け付ける前に呼ぶ）
+// エラーは SessionAuthorizer と同じ（未設定の場合は ErrSessionAuthorizerUnset）
+func (m *Manager) AuthorizeSessionAccess(sessionID, userID string, isAdmin bool) error {
+	return m.hub.authorizeSessionAccess(sessionID, userID, isAdmin)
+}
+
+func (h *Hub) authorizeSessionAccess(sessionID, userID string, isAdmin bool) error {
+	authorizer := h.getSessionAuthorizer()
+	if authorizer == nil {
+		return ErrSessionAuthorizerUnset
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sessionAccessTimeout)
+	defer cancel()
+
+	return authorizer.AuthorizeSessionAccess(ctx, sessionID, userID, isAdmin)
+}
+
+// MoveClient クライアントの主セッションを sessionID に切り替える
+// 元の主セッションからは離脱し、切り替え先のセッションに参加を通知する（接続が切れている場合は false）
+func (h *Hub) MoveClient(client *Client, sessionID string) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if !h.clients[client] {
+		return false
+	}
+	if client.SessionID == sessionID {
+		h.addToRoom(client, sessionID)
+		return true
+	}
+
+	if client.SessionID != "" {
+		h.leaveSession(client, client.SessionID, false)
+	}
+	client.SessionID = sessionID
+	h.enterSession(client, sessionID)
+	return true
+}
+
+// Subscribe 主セッションを変えずに sessionID の通知も受け取るようにする（管理者が複数のセッションを見る場合など）
+// 参加・離脱は通知しない（接続が切れている場合は false）
+func (h *Hub) Subscribe(client *Client, sessionID string) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if !h.clients[client] {
+		return false
+	}
+	h.addToRoom(client, sessionID)
+	return true
+}
+
+// Unsubscribe sessionID の通知を受け取らないようにする
+// 主セッションの場合は離脱を通知し、主セッションなしの状態になる（接続が切れている場合は false）
+func (h *Hub) Unsubscribe(client *Client, sessionID string) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if !h.clients[client] {
+		return false
+	}
+	if sessionID == client.SessionID {
+		h.leaveSession(client, sessionID, false)
+		client.SessionID = ""
+		return true
+	}
+	h.removeFromRoom(client, sessionID)
+	return true
+}
+
+// ClientSessions クライアントが通知を受け取っているセッション
+func (h *Hub) ClientSessions(client *Client) []string {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	sessionIDs := make([]string, 0, len(client.rooms))
+	for sessionID := range client.rooms {
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	sort.Strings(sessionIDs)
+	return sessionIDs
+}
+
+// handleJoinSession 主セッションを切り替える（参加者であることを確認してから移動する）
+func (c *Client) handleJoinSession(msg ClientMessage) {
+	sessionID, ok := c.authorizeSession("join", msg)
+	if !ok {
+		return
+	}
+	if !c.hub.MoveClient(c, sessionID) {
+		return
+	}
+
+	c.SendMessage(Message{
+		Type:      string(MessageTypeJoinSuccess),
+		SessionID: sessionID,
+		Data: map[string]interface{}{
+			"userId":      c.UserID,
+			"displayName": c.DisplayName,
+			"sessions":    c.hub.ClientSessions(c),
+		},
+		Timestamp: getCurrentTimestamp(),
+	})
+}
+
+// handleSubscribeSession 主セッションを変えずにセッションの通知を購読する
+func (c *Client) handleSubscribeSession(msg ClientMessage) {
+	sessionID, ok := c.authorizeSession("subscribe", msg)
+	if !ok {
+		return
+	}
+	if !c.hub.Subscribe(c, sessionID) {
+		return
+	}
+	c.sendSubscriptionUpdate("subscribe", sessionID)
+}
+
+// handleUnsubscribeSession セッションの通知の購読をやめる（購読をやめるだけなので検証しない）
+func (c *Client) handleUnsubscribeSession(msg ClientMessage) {
+	sessionID := sessionIDFromData(msg)
+	if sessionID == "" {
+		c.sendSubscriptionError("unsubscribe", sessionID, "BAD_REQUEST", "Session ID is required")
+		return
+	}
+	if !c.hub.Unsubscribe(c, sessionID) {
+		return
+	}
+	c.sendSubscriptionUpdate("unsubscribe", sessionID)
+}
+
+// authorizeSession data の sessionId を取り出し、セッションの存在と参加者であること（管理者は不要）を確認する
+// 確認できない場合は subscription_error を送って false を返す
+func (c *Client) authorizeSession(action string, msg ClientMessage) (string, bool) {
+	sessionID := sessionIDFromData(msg)
+	if sessionID == "" {
+		c.sendSubscriptionError(action, sessionID, "BAD_REQUEST", "Session ID is required")
+		return "", false
+	}
+
+	if err := c.hub.authorizeSessionAccess(sessionID, c.UserID, c.IsAdmin); err != nil {
+		switch {
+		case errors.Is(err, ErrSessionAuthorizerUnset):
+			c.sendSubscriptionError(action, sessionID, "UNAVAILABLE", "Joining sessions is not available")
+		case errors.Is(err, domain.ErrSessionNotFound):
+			c.sendSubscriptionError(action, sessionID, "NOT_FOUND", "Session not found")
+		case errors.Is(err, domain.ErrParticipantNotFound):
+			c.sendSubscriptionError(action, sessionID, "FORBIDDEN", "Not a participant of this session")
+		default:
+			log.Printf("Failed to authorize session access: UserID=%s, SessionID=%s: %v", c.UserID, sessionID, err)
+			c.sendSubscriptionError(action, sessionID, "INTERNAL_ERROR", "Failed to verify session access")
+		}
+		return "", false
+	}
+	return sessionID, true
+}
+
+// sessionIDFromData data の sessionId（未指定・形式が違う場合は空文字）
+func sessionIDFromData(msg ClientMessage) string {
+	var data struct {
+		SessionID string `json:"sessionId"`
+	}
+	if raw, err := json.Marshal(msg.Data); err == nil {
+		json.Unmarshal(raw, &data)
+	}
+	return data.SessionID
+}
+
+// sendSubscriptionUpdate 購読の変更後に、通知を受け取っているセッションの一覧を送る
+func (c *Client) sendSubscriptionUpdate(action, sessionID string) {
+	c.SendMessage(Message{
+		Type:      string(MessageTypeSubscriptionUpdate),
+		SessionID: c.SessionID,
+		Data: map[string]interface{}{
+			"action":    action,
+			"sessionId": sessionID,
+			"sessions":  c.hub.ClientSessions(c),
+		},
+		Timestamp: getCurrentTimestamp(),
+	})
+}
+
+// sendSubscriptionError 参加・購読できなかった理由を送る（code は REST API のエラーコードと同じ）
+func (c *Client) sendSubscriptionError(action, sessionID, code, message string) {
+	c.SendMessage(Message{
+		Type:      string(MessageTypeSubscriptionError),
+		SessionID: c.SessionID,
+		Data: map[string]interface{}{
+			"action":    action,
+			"sessionId": sessionID,
+			"code":      code,
+			"message":   message,
+		},
+		Timestamp: getCurrentTimestamp(),
+	})
+}
@@ -0,0 +1,177 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"quiz-app/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSessionAuthorizer participants に含まれるユーザーのみ参加者とみなす
+type fakeSessionAuthorizer struct {
+	sessions map[string][]string // sessionID -> 参加者の userID
+}
+
+func (f *fakeSessionAuthorizer) AuthorizeSessionAccess(ctx context.Context, sessionID, userID string, isAdmin bool) error {
+	participants, ok := f.sessions[sessionID]
+	if !ok {
+		return domain.ErrSessionNotFound
+	}
+	if isAdmin {
+		return nil
+	}
+	for _, id := range participants {
+		if id == userID {
+			return nil
+		}
+	}
+	return domain.ErrParticipantNotFound
+}
+
+func TestRoomMembership(t *testing.T) {
+	newTestHub := func() *Hub {
+		hub := NewHub()
+		hub.SetSessionAuthorizer(&fakeSessionAuthorizer{sessions: map[string][]string{
+			"s1": {"player", "other"},
+			"s2": {"player", "other"},
+		}})
+		return hub
+	}
+	newTestClient := func(hub *Hub, userID, sessionID string, isAdmin bool) *Client {
+		client := &Client{hub: hub, send: make(chan []byte, 16), UserID: userID, SessionID: sessionID, IsAdmin: isAdmin}
+		hub.registerClient(client)
+		return client
+	}
+	messages := func(client *Client) []Message {
+		var msgs []Message
+		for len(client.send) > 0 {
+			var msg Message
+			json.Unmarshal(<-client.send, &msg)
+			msgs = append(msgs, msg)
+		}
+		return msgs
+	}
+	messageTypes := func(client *Client) []string {
+		var types []string
+		for _, msg := range messages(client) {
+			types = append(types, msg.Type)
+		}
+		return types
+	}
+	receive := func(t *testing.T, client *Client, msgType MessageType) map[string]interface{} {
+		msgs := messages(client)
+		require.Len(t, msgs, 1)
+		require.Equal(t, string(msgType), msgs[0].Type)
+		return msgs[0].Data.(map[string]interface{})
+	}
+	send := func(client *Client, msgType, sessionID string) {
+		client.handleMessage(ClientMessage{Type: msgType, Data: map[string]interface{}{"sessionId": sessionID}})
+	}
+
+	t.Run("セッションなしで接続してから参加した場合も、セッションへの通知が届くこと", func(t *testing.T) {
+		hub := newTestHub()
+		other := newTestClient(hub, "other", "s1", false)
+		player := newTestClient(hub, "player", "", false)
+		messages(other)
+
+		send(player, "join_session", "s1")
+
+		msgs := messages(player)
+		require.Len(t, msgs, 2)
+		assert.Equal(t, string(MessageTypeParticipantJoin), msgs[0].Type)
+		assert.Equal(t, string(MessageTypeJoinSuccess), msgs[1].Type)
+		assert.Equal(t, []interface{}{"s1"}, msgs[1].Data.(map[string]interface{})["sessions"])
+		assert.Equal(t, []string{"participant_join"}, messageTypes(other))
+		assert.Equal(t, 2, hub.GetSessionClientCount("s1"))
+
+		hub.BroadcastToSession("s1", Message{Type: "session_update"})
+		assert.Equal(t, []string{"session_update"}, messageTypes(player))
+	})
+
+	t.Run("別のセッションに移ると元のセッションから離脱し、切断時は移動先のセッションから外れること", func(t *testing.T) {
+		hub := newTestHub()
+		other := newTestClient(hub, "other", "s1", false)
+		player := newTestClient(hub, "player", "s1", false)
+		messages(other)
+		messages(player)
+
+		send(player, "join_session", "s2")
+
+		assert.Equal(t, "s2", player.SessionID)
+		assert.Equal(t, []string{"participant_join", "join_success"}, messageTypes(player))
+		assert.Equal(t, []string{"participant_leave"}, messageTypes(other))
+		assert.False(t, hub.IsUserConnected("s1", "player"))
+		assert.True(t, hub.IsUserConnected("s2", "player"))
+
+		hub.BroadcastToSession("s1", Message{Type: "session_update"})
+		assert.Empty(t, player.send)
+
+		hub.leaveGrace = 0
+		hub.unregisterClient(player)
+		assert.Zero(t, hub.GetSessionClientCount("s2"))
+		assert.Equal(t, 1, hub.GetSessionClientCount("s1"))
+	})
+
+	t.Run("管理者は複数のセッションを購読でき、購読をやめると通知が止まること", func(t *testing.T) {
+		hub := newTestHub()
+		admin := newTestClient(hub, "admin", "", true)
+
+		send(admin, "subscribe_session", "s1")
+		assert.Equal(t, []interface{}{"s1"}, receive(t, admin, MessageTypeSubscriptionUpdate)["sessions"])
+		send(admin, "subscribe_session", "s2")
+		assert.Equal(t, []interface{}{"s1", "s2"}, receive(t, admin, MessageTypeSubscriptionUpdate)["sessions"])
+
+		// 購読では参加を通知しない
+		player := newTestClient(hub, "player", "s1", false)
+		assert.Equal(t, []string{"participant_join"}, messageTypes(player))
+
+		hub.BroadcastToSession("s1", Message{Type: "session_update"})
+		hub.BroadcastToSession("s2", Message{Type: "session_update"})
+		assert.Equal(t, []string{"participant_join", "session_update", "session_update"}, messageTypes(admin))
+		messages(player)
+
+		send(admin, "unsubscribe_session", "s1")
+		data := receive(t, admin, MessageTypeSubscriptionUpdate)
+		assert.Equal(t, "unsubscribe", data["action"])
+		assert.Equal(t, []interface{}{"s2"}, data["sessions"])
+		assert.Empty(t, messageTypes(player))
+
+		hub.BroadcastToSession("s1", Message{Type: "session_update"})
+		assert.Empty(t, admin.send)
+	})
+
+	t.Run("参加者でない場合と存在しないセッションには参加・購読できないこと", func(t *testing.T) {
+		hub := newTestHub()
+		hub.SetSessionAuthorizer(&fakeSessionAuthorizer{sessions: map[string][]string{"s1": {"other"}}})
+		player := newTestClient(hub, "player", "", false)
+
+		send(player, "join_session", "s1")
+		data := receive(t, player, MessageTypeSubscriptionError)
+		assert.Equal(t, "join", data["action"])
+		assert.Equal(t, "FORBIDDEN", data["code"])
+
+		send(player, "subscribe_session", "missing")
+		data = receive(t, player, MessageTypeSubscriptionError)
+		assert.Equal(t, "subscribe", data["action"])
+		assert.Equal(t, "NOT_FOUND", data["code"])
+
+		player.handleMessage(ClientMessage{Type: "join_session", Data: "s1"})
+		assert.Equal(t, "BAD_REQUEST", receive(t, player, MessageTypeSubscriptionError)["code"])
+
+		assert.Empty(t, player.SessionID)
+		assert.Zero(t, hub.GetSessionClientCount("s1"))
+	})
+
+	t.Run("検証に使う UseCase が未設定の場合は UNAVAILABLE を返すこと", func(t *testing.T) {
+		hub := NewHub()
+		player := newTestClient(hub, "player", "", false)
+
+		send(player, "join_session", "s1")
+
+		assert.Equal(t, "UNAVAILABLE", receive(t, player, MessageTypeSubscriptionError)["code"])
+	})
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -12,6 +13,9 @@ type Config struct {
 	Firebase   FirebaseConfig
 	AI         AIConfig
 	AccessCode AccessCodeConfig
+	Storage    StorageConfig
+	Media      MediaConfig
+	WebSocket  WebSocketConfig
 }
 
 type ServerConfig struct {
@@ -32,16 +36,59 @@ type FirebaseConfig struct {
 	StorageBucket string
 }
 
+// AIConfig 問題生成 AI の設定
+// Provider は "auto"（デフォルト、API キーが設定されたクライアントを使用）または "local" のいずれか
+// "local" の場合はネットワークを使わず、LocalSeed から再現可能な問題を生成する
+// RoutingPolicy は "priority"（デフォルト）、"round_robin"、"lowest_latency" のいずれか
 type AIConfig struct {
-	GeminiAPIKey string
-	OpenAIAPIKey string
-	ClaudeAPIKey string
+	Provider                string
+	LocalSeed               int
+	GeminiAPIKey            string
+	OpenAIAPIKey            string
+	ClaudeAPIKey            string
+	RoutingPolicy           string
+	MaxRetries              int      // プロバイダーごとの再試行回数
+	RequestTimeout          int      // 1回の呼び出しの上限（秒）
+	CircuitFailureThreshold int      // 呼び出しを止める連続失敗回数
+	CircuitCooldown         int      // 呼び出しを止める時間（秒）
+	BannedWords             []string // 生成された問題で拒否する語（既定の禁止語に追加）
+	DuplicateSimilarity     int      // 出題済みの問題と重複とみなす類似度（%）
+	DuplicateRetries        int      // 重複した問題を再生成する回数
 }
 
 type AccessCodeConfig struct {
 	FilePath string
 }
 
+// StorageConfig 永続化バックエンドの設定
+// Backend は "firebase"（デフォルト）、"memory"、"sqlite"、"postgres" のいずれか
+// "memory" の場合は Firebase に接続せずプロセス内で完結する
+type StorageConfig struct {
+	Backend           string
+	DatabaseURL       string // sqlite の場合はファイルパス、postgres の場合は接続文字列
+	SeedAdminPassword string // memory 以外のバックエンドで管理者ユーザーを作成する場合は必須
+}
+
+// MediaConfig 問題に添付する画像・音声の保存先の設定
+// Backend は "filesystem"（デフォルト、Dir に保存）、"bucket"（Firebase.StorageBucket に保存）、"memory" のいずれか
+// PublicURL は配信用 URL の接頭辞（未指定の場合はサーバーの /api/v1/media/ から配信する）
+type MediaConfig struct {
+	Backend       string
+	Dir           string
+	PublicURL     string
+	MaxUploadSize int // アップロードできるファイルの上限（MB）
+}
+
+// WebSocketConfig 複数のサーバーで WebSocket の通知を共有する設定
+// Backplane は "none"（デフォルト、単一サーバー）、"redis"、"memory"（同一プロセス内のみ）のいずれか
+// "redis" の場合は出題中の問題の回答受付の状態（repository.RoundStore）も同じ Redis で共有する
+// NodeID は接続数の集計に使うサーバーの識別子（未指定の場合はホスト名から生成する）
+type WebSocketConfig struct {
+	Backplane string
+	RedisURL  string
+	NodeID    string
+}
+
 func Load() (*Config, error) {
 	if err := godotenv.Load(); err != nil {
 		// .envファイルが存在しない場合は無視（環境変数から読み取り）
@@ -65,13 +112,39 @@ func Load() (*Config, error) {
 			StorageBucket: getEnv("FIREBASE_STORAGE_BUCKET", ""),
 		},
 		AI: AIConfig{
-			GeminiAPIKey: getEnv("GEMINI_API_KEY", ""),
-			OpenAIAPIKey: getEnv("OPENAI_API_KEY", ""),
-			ClaudeAPIKey: getEnv("CLAUDE_API_KEY", ""),
+			Provider:                getEnv("AI_PROVIDER", "auto"),
+			LocalSeed:               getEnvAsInt("AI_LOCAL_SEED", 1),
+			GeminiAPIKey:            getEnv("GEMINI_API_KEY", ""),
+			OpenAIAPIKey:            getEnv("OPENAI_API_KEY", ""),
+			ClaudeAPIKey:            getEnv("CLAUDE_API_KEY", ""),
+			RoutingPolicy:           getEnv("AI_ROUTING_POLICY", "priority"),
+			MaxRetries:              getEnvAsInt("AI_MAX_RETRIES", 2),
+			RequestTimeout:          getEnvAsInt("AI_REQUEST_TIMEOUT", 20),
+			CircuitFailureThreshold: getEnvAsInt("AI_CIRCUIT_FAILURE_THRESHOLD", 3),
+			CircuitCooldown:         getEnvAsInt("AI_CIRCUIT_COOLDOWN", 30),
+			BannedWords:             getEnvAsList("AI_BANNED_WORDS"),
+			DuplicateSimilarity:     getEnvAsInt("AI_DUPLICATE_SIMILARITY", 80),
+			DuplicateRetries:        getEnvAsInt("AI_DUPLICATE_RETRIES", 2),
 		},
 		AccessCode: AccessCodeConfig{
 			FilePath: getEnv("ACCESS_CODE_FILE_PATH", "/app/configs/access_codes.txt"),
 		},
+		Storage: StorageConfig{
+			Backend:           getEnv("STORAGE_BACKEND", "firebase"),
+			DatabaseURL:       getEnv("DATABASE_URL", "quiz.db"),
+			SeedAdminPassword: getEnv("SEED_ADMIN_PASSWORD", ""),
+		},
+		Media: MediaConfig{
+			Backend:       getEnv("MEDIA_BACKEND", "filesystem"),
+			Dir:           getEnv("MEDIA_DIR", "media"),
+			PublicURL:     getEnv("MEDIA_PUBLIC_URL", ""),
+			MaxUploadSize: getEnvAsInt("MEDIA_MAX_UPLOAD_SIZE", 10),
+		},
+		WebSocket: WebSocketConfig{
+			Backplane: getEnv("WS_BACKPLANE", "none"),
+			RedisURL:  getEnv("REDIS_URL", "redis://localhost:6379/0"),
+			NodeID:    getEnv("WS_NODE_ID", ""),
+		},
 	}
 
 	return config, nil
@@ -84,6 +157,17 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvAsList カンマ区切りの値を空要素を除いて返す
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func getEnvAsInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
 		if intValue, err := strconv.Atoi(value); err == nil {
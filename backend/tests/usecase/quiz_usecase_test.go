@@ -0,0 +1,472 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quiz-app/internal/domain"
+	"quiz-app/internal/repository"
+	"quiz-app/internal/usecase"
+	"quiz-app/internal/websocket"
+)
+
+// quizFixture メモリのリポジトリで動かすセッションとクイズ進行
+type quizFixture struct {
+	repos      *repository.Repositories
+	answerRepo repository.AnswerRepository
+	wsManager  *websocket.Manager
+	sessions   usecase.SessionUseCase
+	quiz       usecase.QuizUseCase
+	session    *domain.Session
+	drafts     int
+}
+
+// sessionScopedAnswerRepository Firestore と同じく、セッションを指定しないと回答を引けない回答リポジトリ
+type sessionScopedAnswerRepository struct {
+	repository.AnswerRepository
+}
+
+func (r *sessionScopedAnswerRepository) GetByUserAndQuestion(ctx context.Context, userID, questionID string) (*domain.Answer, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *sessionScopedAnswerRepository) GetByQuestion(ctx context.Context, questionID string) ([]*domain.Answer, error) {
+	return nil, errors.New("not implemented")
+}
+
+// newQuizFixture 参加者が参加して開始済みのセッションを用意する
+func newQuizFixture(t *testing.T, settings domain.Settings, userIDs ...string) *quizFixture {
+	return newQuizFixtureWithAnswers(t, nil, settings, userIDs...)
+}
+
+// newQuizFixtureWithAnswers wrapAnswers でクイズ進行が使う回答リポジトリを差し替えて用意する
+func newQuizFixtureWithAnswers(t *testing.T, wrapAnswers func(repository.AnswerRepository) repository.AnswerRepository, settings domain.Settings, userIDs ...string) *quizFixture {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepositories()
+	answerRepo := repos.AnswerRepo
+	if wrapAnswers != nil {
+		answerRepo = wrapAnswers(answerRepo)
+	}
+	wsManager := websocket.NewManager(nil)
+	t.Cleanup(func() { wsManager.Close() })
+
+	f := &quizFixture{
+		repos:      repos,
+		answerRepo: answerRepo,
+		wsManager:  wsManager,
+		sessions:   usecase.NewSessionUseCase(repos.SessionRepo, repos.ParticipantRepo, repos.UserRepo, wsManager),
+	}
+	f.restart()
+
+	session, err := f.sessions.CreateSession(ctx, "テスト", 10, settings)
+	require.NoError(t, err)
+	for _, userID := range userIDs {
+		_, err := f.sessions.JoinSession(ctx, session.ID, userID, userID, "")
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.sessions.StartSession(ctx, session.ID))
+	f.session = session
+	return f
+}
+
+// restart 出題状態を持たない新しいサーバーでクイズ進行を作り直す（再起動・出題状態の破棄を再現する）
+func (f *quizFixture) restart() {
+	repos := f.repos
+	scheduler := usecase.NewRoundScheduler(repository.NewMemoryRoundStore())
+	f.quiz = usecase.NewQuizUseCase(repos.SessionRepo, repos.ParticipantRepo, repos.QuestionRepo, f.answerRepo,
+		repos.QuestionBankRepo, repos.TemplateRepo, repository.NewMemoryBlobStore(""), nil, f.wsManager, scheduler)
+}
+
+// addDraft 問題を下書きとして保存する
+func (f *quizFixture) addDraft(t *testing.T, question *domain.Question) *domain.Question {
+	f.drafts++
+	question.ID = ""
+	question.SessionID = f.session.ID
+	question.MarkDraft(f.drafts)
+	require.NoError(t, f.repos.QuestionRepo.Create(context.Background(), question))
+	return question
+}
+
+// publish 問題を下書きから承認して出題する
+func (f *quizFixture) publish(t *testing.T, question *domain.Question) *domain.Question {
+	ctx := context.Background()
+	draft := f.addDraft(t, question)
+	_, err := f.quiz.ReviewDraftQuestion(ctx, f.session.ID, draft.ID, true)
+	require.NoError(t, err)
+
+	published, err := f.quiz.PublishNextQuestion(ctx, f.session.ID)
+	require.NoError(t, err)
+	require.Equal(t, draft.ID, published.ID)
+	return published
+}
+
+func (f *quizFixture) participant(t *testing.T, userID string) *domain.Participant {
+	participant, err := f.repos.ParticipantRepo.GetByUserAndSession(context.Background(), userID, f.session.ID)
+	require.NoError(t, err)
+	return participant
+}
+
+// choiceQuestion 正解が1番の単一選択問題
+func choiceQuestion() *domain.Question {
+	return domain.NewQuestion("", 0, "1+1は？", []string{"1", "2", "3", "4"}, 1, domain.DifficultyEasy, "算数", domain.AIProviderLocal)
+}
+
+func choiceAnswer(option int) domain.AnswerInput {
+	return domain.AnswerInput{SelectedOption: &option}
+}
+
+func numericQuestion(answer float64) *domain.Question {
+	return &domain.Question{Text: "東京タワーの高さは何m？", Type: domain.QuestionTypeNumeric, NumericAnswer: answer, Unit: "m"}
+}
+
+func numericAnswer(value float64) domain.AnswerInput {
+	return domain.AnswerInput{NumericValue: &value}
+}
+
+func TestQuizUseCaseClosestWins(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		values      map[string]float64 // userID -> 回答
+		wantCorrect []string
+	}{
+		{
+			name:        "最も近い回答だけが正解になること",
+			values:      map[string]float64{"alice": 330, "bob": 300, "carol": 400},
+			wantCorrect: []string{"alice"},
+		},
+		{
+			name:        "同じ距離の回答はすべて正解になること",
+			values:      map[string]float64{"alice": 323, "bob": 343, "carol": 400},
+			wantCorrect: []string{"alice", "bob"},
+		},
+	}
+	answerRepos := map[string]func(repository.AnswerRepository) repository.AnswerRepository{
+		"memory": nil,
+		"session-scoped": func(r repository.AnswerRepository) repository.AnswerRepository {
+			return &sessionScopedAnswerRepository{r}
+		},
+	}
+
+	for repoName, wrapAnswers := range answerRepos {
+		for _, tt := range tests {
+			t.Run(repoName+": "+tt.name, func(t *testing.T) {
+				settings := domain.Settings{GameMode: domain.GameModeClassic, TotalRounds: 5}
+				f := newQuizFixtureWithAnswers(t, wrapAnswers, settings, "alice", "bob", "carol")
+				question := f.publish(t, numericQuestion(333))
+
+				for userID, value := range tt.values {
+					answer, err := f.quiz.SubmitAnswer(ctx, f.session.ID, userID, question.ID, numericAnswer(value), 0)
+					require.NoError(t, err)
+					assert.True(t, answer.Pending)
+					assert.False(t, answer.IsCorrect)
+				}
+
+				result, err := f.quiz.ProcessRoundResults(ctx, f.session.ID, question.ID)
+				require.NoError(t, err)
+
+				var correct []string
+				for _, participant := range result.Correct {
+					correct = append(correct, participant.UserID)
+				}
+				assert.ElementsMatch(t, tt.wantCorrect, correct)
+
+				// 正解者には得点が入り、保存した回答も正解になる
+				for userID := range tt.values {
+					answer, err := f.repos.AnswerRepo.GetByUserAndQuestion(ctx, userID, question.ID)
+					require.NoError(t, err)
+					won := contains(tt.wantCorrect, userID)
+					assert.Equal(t, won, answer.IsCorrect, userID)
+					assert.Equal(t, won, f.participant(t, userID).Score > 0, userID)
+				}
+			})
+		}
+	}
+}
+
+func TestQuizUseCaseGameModes(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		settings       domain.Settings
+		answers        map[string]int // userID -> 選んだ選択肢（回答しない参加者は不正解）
+		wantCorrect    []string
+		wantLostLife   []string
+		wantEliminated []string
+		wantLives      map[string]int
+		wantGameOver   bool
+	}{
+		{
+			name:        "classic は不正解でも脱落しないこと",
+			settings:    domain.Settings{GameMode: domain.GameModeClassic, TotalRounds: 5},
+			answers:     map[string]int{"alice": 1, "bob": 0},
+			wantCorrect: []string{"alice"},
+		},
+		{
+			name:         "classic は規定ラウンドでゲーム終了となること",
+			settings:     domain.Settings{GameMode: domain.GameModeClassic, TotalRounds: 1},
+			answers:      map[string]int{"alice": 1, "bob": 1, "carol": 1},
+			wantCorrect:  []string{"alice", "bob", "carol"},
+			wantGameOver: true,
+		},
+		{
+			name:         "lives は不正解でライフを1つ失うこと",
+			settings:     domain.Settings{GameMode: domain.GameModeLives, Lives: 2},
+			answers:      map[string]int{"alice": 1, "bob": 0},
+			wantCorrect:  []string{"alice"},
+			wantLostLife: []string{"bob", "carol"},
+			wantLives:    map[string]int{"alice": 2, "bob": 1, "carol": 1},
+		},
+		{
+			name:           "lives はライフが無くなると脱落し、残りが1人でゲーム終了となること",
+			settings:       domain.Settings{GameMode: domain.GameModeLives, Lives: 1},
+			answers:        map[string]int{"alice": 1, "bob": 0},
+			wantCorrect:    []string{"alice"},
+			wantEliminated: []string{"bob", "carol"},
+			wantLives:      map[string]int{"alice": 1, "bob": 0, "carol": 0},
+			wantGameOver:   true,
+		},
+	}
+
+	userIDs := func(participants []*domain.Participant) []string {
+		ids := []string{}
+		for _, participant := range participants {
+			ids = append(ids, participant.UserID)
+		}
+		return ids
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newQuizFixture(t, tt.settings, "alice", "bob", "carol")
+			question := f.publish(t, choiceQuestion())
+			for userID, option := range tt.answers {
+				_, err := f.quiz.SubmitAnswer(ctx, f.session.ID, userID, question.ID, choiceAnswer(option), 0)
+				require.NoError(t, err)
+			}
+
+			result, err := f.quiz.ProcessRoundResults(ctx, f.session.ID, question.ID)
+			require.NoError(t, err)
+
+			assert.ElementsMatch(t, tt.wantCorrect, userIDs(result.Correct))
+			assert.ElementsMatch(t, tt.wantLostLife, userIDs(result.LostLife))
+			assert.ElementsMatch(t, tt.wantEliminated, userIDs(result.Eliminated))
+			assert.Equal(t, tt.wantGameOver, result.GameOver)
+			for userID, lives := range tt.wantLives {
+				assert.Equal(t, lives, f.participant(t, userID).Lives, userID)
+			}
+			for _, userID := range tt.wantEliminated {
+				assert.True(t, f.participant(t, userID).IsEliminated(), userID)
+			}
+
+			session, err := f.repos.SessionRepo.GetByID(ctx, f.session.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantGameOver, session.Status == domain.GameStatusFinished)
+		})
+	}
+}
+
+func TestQuizUseCaseAutoProcessAtDeadline(t *testing.T) {
+	ctx := context.Background()
+	settings := domain.Settings{GameMode: domain.GameModeLives, Lives: 1, TimeLimit: 1}
+	f := newQuizFixture(t, settings, "alice", "bob")
+	question := f.publish(t, choiceQuestion())
+
+	_, err := f.quiz.SubmitAnswer(ctx, f.session.ID, "alice", question.ID, choiceAnswer(1), 0)
+	require.NoError(t, err)
+
+	// 制限時間と猶予時間を過ぎると、手動で処理しなくても結果処理されゲーム終了となる
+	assert.Eventually(t, func() bool {
+		session, err := f.repos.SessionRepo.GetByID(ctx, f.session.ID)
+		return err == nil && session.Status == domain.GameStatusFinished
+	}, 8*time.Second, 50*time.Millisecond)
+
+	assert.True(t, f.participant(t, "bob").IsEliminated())
+	assert.False(t, f.participant(t, "alice").IsEliminated())
+
+	// 締め切り後の回答は受け付けず、結果処理も繰り返さない
+	_, err = f.quiz.SubmitAnswer(ctx, f.session.ID, "bob", question.ID, choiceAnswer(1), 0)
+	assert.Error(t, err)
+	_, err = f.quiz.ProcessRoundResults(ctx, f.session.ID, question.ID)
+	assert.ErrorIs(t, err, domain.ErrRoundAlreadyProcessed)
+}
+
+func TestQuizUseCasePublishDraft(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		reviews []*bool // 下書きごとのレビュー（nil は未レビュー）
+		want    int     // 出題される下書きの番号（-1 は出題できない）
+	}{
+		{name: "承認した下書きを出題できること", reviews: []*bool{boolPtr(true)}, want: 0},
+		{name: "未レビューの下書きは出題できないこと", reviews: []*bool{nil}, want: -1},
+		{name: "却下した下書きは出題できないこと", reviews: []*bool{boolPtr(false)}, want: -1},
+		{name: "承認した下書きのうち出題順が最も早いものを出題すること", reviews: []*bool{nil, boolPtr(true), boolPtr(true)}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newQuizFixture(t, domain.Settings{GameMode: domain.GameModeClassic, TotalRounds: 5}, "alice")
+			drafts := make([]*domain.Question, len(tt.reviews))
+			for i, review := range tt.reviews {
+				drafts[i] = f.addDraft(t, choiceQuestion())
+				if review != nil {
+					_, err := f.quiz.ReviewDraftQuestion(ctx, f.session.ID, drafts[i].ID, *review)
+					require.NoError(t, err)
+				}
+			}
+
+			published, err := f.quiz.PublishNextQuestion(ctx, f.session.ID)
+			if tt.want < 0 {
+				assert.ErrorIs(t, err, domain.ErrNoApprovedQuestion)
+				_, err = f.quiz.GetCurrentQuestion(ctx, f.session.ID)
+				assert.ErrorIs(t, err, domain.ErrQuestionNotFound)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, drafts[tt.want].ID, published.ID)
+			assert.Equal(t, domain.QuestionStatusPublished, published.Status)
+			assert.Equal(t, 1, published.Round)
+			assert.False(t, published.OpenedAt.IsZero())
+
+			// 出題した問題が現在の問題になり、回答を受け付ける
+			current, err := f.quiz.GetCurrentQuestion(ctx, f.session.ID)
+			require.NoError(t, err)
+			assert.Equal(t, published.ID, current.ID)
+			_, err = f.quiz.SubmitAnswer(ctx, f.session.ID, "alice", published.ID, choiceAnswer(1), 0)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestQuizUseCaseSessionSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		timeLimit     int
+		userID        string
+		after         func(t *testing.T, f *quizFixture, question *domain.Question)
+		wantOpen      bool
+		wantRemaining bool // 残り時間が0より大きい
+	}{
+		{name: "制限時間のある出題中の問題は残り時間を返すこと", timeLimit: 30, userID: "alice", wantOpen: true, wantRemaining: true},
+		{name: "制限時間のない出題中の問題は残り時間0で受付中を返すこと", userID: "alice", wantOpen: true},
+		{
+			name: "結果処理した問題は締め切り済みを返すこと", timeLimit: 30, userID: "alice",
+			after: func(t *testing.T, f *quizFixture, question *domain.Question) {
+				_, err := f.quiz.ProcessRoundResults(ctx, f.session.ID, question.ID)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "出題状態を失った問題は締め切り済みを返すこと", timeLimit: 30, userID: "alice",
+			after: func(t *testing.T, f *quizFixture, question *domain.Question) { f.restart() },
+		},
+		{name: "参加者でないユーザーには参加状況を返さないこと", timeLimit: 30, userID: "viewer", wantOpen: true, wantRemaining: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := domain.Settings{GameMode: domain.GameModeClassic, TotalRounds: 5, TimeLimit: tt.timeLimit}
+			f := newQuizFixture(t, settings, "alice", "bob")
+			question := f.publish(t, choiceQuestion())
+			if tt.after != nil {
+				tt.after(t, f, question)
+			}
+
+			snapshot, err := f.quiz.GetSessionSnapshot(ctx, f.session.ID, tt.userID)
+			require.NoError(t, err)
+			assert.Equal(t, f.session.ID, snapshot.Session.ID)
+			require.NotNil(t, snapshot.Question)
+			assert.Equal(t, question.ID, snapshot.Question.ID)
+			assert.Equal(t, tt.wantOpen, snapshot.QuestionOpen)
+			assert.Equal(t, tt.wantRemaining, snapshot.RemainingTime > 0)
+			assert.LessOrEqual(t, snapshot.RemainingTime, time.Duration(tt.timeLimit)*time.Second)
+			if tt.userID == "viewer" {
+				assert.Nil(t, snapshot.Participant)
+			} else {
+				require.NotNil(t, snapshot.Participant)
+				assert.Equal(t, tt.userID, snapshot.Participant.UserID)
+			}
+		})
+	}
+}
+
+func TestQuizUseCaseProcessRoundResultsOnce(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		restart bool // 1回目の結果処理の後に出題状態を失う
+	}{
+		{name: "同じ問題は2回結果処理できないこと"},
+		{name: "出題状態を失った後も同じ問題は結果処理できないこと", restart: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := domain.Settings{GameMode: domain.GameModeLives, Lives: 3, TotalRounds: 5}
+			f := newQuizFixture(t, settings, "alice", "bob")
+			question := f.publish(t, choiceQuestion())
+			_, err := f.quiz.SubmitAnswer(ctx, f.session.ID, "alice", question.ID, choiceAnswer(1), 0)
+			require.NoError(t, err)
+
+			_, err = f.quiz.ProcessRoundResults(ctx, f.session.ID, question.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 2, f.participant(t, "bob").Lives)
+
+			if tt.restart {
+				f.restart()
+			}
+			_, err = f.quiz.ProcessRoundResults(ctx, f.session.ID, question.ID)
+			assert.ErrorIs(t, err, domain.ErrRoundAlreadyProcessed)
+			assert.Equal(t, 2, f.participant(t, "bob").Lives)
+
+			stored, err := f.repos.QuestionRepo.GetByID(ctx, question.ID)
+			require.NoError(t, err)
+			assert.True(t, stored.IsProcessed())
+		})
+	}
+}
+
+func TestQuizUseCaseLifeLossPerQuestion(t *testing.T) {
+	ctx := context.Background()
+	settings := domain.Settings{GameMode: domain.GameModeLives, Lives: 3, TotalRounds: 5}
+	f := newQuizFixture(t, settings, "alice", "bob", "carol")
+
+	first := f.publish(t, choiceQuestion())
+	second := f.publish(t, domain.NewQuestion("", 0, "2+2は？", []string{"2", "3", "4", "5"}, 2, domain.DifficultyEasy, "算数", domain.AIProviderLocal))
+
+	// 後の問題を先に処理しても、前の問題の不正解でライフを失う
+	for _, question := range []*domain.Question{second, first} {
+		result, err := f.quiz.ProcessRoundResults(ctx, f.session.ID, question.ID)
+		require.NoError(t, err)
+		assert.Len(t, result.LostLife, 3)
+	}
+
+	bob := f.participant(t, "bob")
+	assert.Equal(t, 1, bob.Lives)
+	assert.Equal(t, []string{second.ID, first.ID}, bob.LifeLostFor)
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
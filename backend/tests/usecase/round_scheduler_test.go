@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quiz-app/internal/domain"
+	"quiz-app/internal/repository"
+	"quiz-app/internal/usecase"
+)
+
+func TestRoundScheduler(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("制限時間と猶予時間を過ぎると回答を受け付けず、結果処理を呼び出すこと", func(t *testing.T) {
+		scheduler := usecase.NewRoundScheduler(repository.NewMemoryRoundStore())
+		var expired atomic.Int32
+		openedAt := time.Now().Add(-1500 * time.Millisecond)
+		require.NoError(t, scheduler.Start(ctx, "s1", "q1", openedAt, 2*time.Second, func() { expired.Add(1) }))
+
+		deadline := openedAt.Add(2 * time.Second)
+		assert.NoError(t, scheduler.CheckAnswerable(ctx, "s1", "q1", deadline))
+		assert.NoError(t, scheduler.CheckAnswerable(ctx, "s1", "q1", deadline.Add(500*time.Millisecond)))
+		assert.ErrorIs(t, scheduler.CheckAnswerable(ctx, "s1", "q1", deadline.Add(2*time.Second)), domain.ErrTimeExpired)
+		assert.ErrorIs(t, scheduler.CheckAnswerable(ctx, "s1", "q0", deadline), domain.ErrTimeExpired)
+
+		assert.Eventually(t, func() bool { return expired.Load() == 1 }, 3*time.Second, 10*time.Millisecond)
+
+		// 発火しただけでは結果処理済みにならない（結果処理の Close で締め切る）
+		assert.NoError(t, scheduler.Close(ctx, "s1", "q1"))
+		assert.ErrorIs(t, scheduler.CheckAnswerable(ctx, "s1", "q1", deadline), domain.ErrTimeExpired)
+	})
+
+	t.Run("結果処理は1回だけ行えること", func(t *testing.T) {
+		scheduler := usecase.NewRoundScheduler(repository.NewMemoryRoundStore())
+		var expired atomic.Int32
+		require.NoError(t, scheduler.Start(ctx, "s1", "q1", time.Now(), 50*time.Millisecond, func() { expired.Add(1) }))
+
+		assert.NoError(t, scheduler.Close(ctx, "s1", "q1"))
+		assert.ErrorIs(t, scheduler.Close(ctx, "s1", "q1"), domain.ErrRoundAlreadyProcessed)
+		assert.ErrorIs(t, scheduler.CheckAnswerable(ctx, "s1", "q1", time.Now()), domain.ErrTimeExpired)
+
+		// 締め切り後はタイマーも発火しない
+		time.Sleep(50*time.Millisecond + 1200*time.Millisecond)
+		assert.Zero(t, expired.Load())
+	})
+
+	t.Run("出題状態を共有する他のサーバーで結果処理した問題は処理しないこと", func(t *testing.T) {
+		store := repository.NewMemoryRoundStore()
+		started := usecase.NewRoundScheduler(store)
+		other := usecase.NewRoundScheduler(store)
+		var expired atomic.Int32
+		require.NoError(t, started.Start(ctx, "s1", "q1", time.Now(), 50*time.Millisecond, func() { expired.Add(1) }))
+
+		// 他のサーバーでも同じ締め切りで回答を受け付け、回答者数を数える
+		assert.NoError(t, other.CheckAnswerable(ctx, "s1", "q1", time.Now()))
+		_, err := started.RecordAnswer(ctx, "s1", "q1")
+		require.NoError(t, err)
+		answered, err := other.RecordAnswer(ctx, "s1", "q1")
+		require.NoError(t, err)
+		assert.Equal(t, 2, answered)
+
+		assert.NoError(t, other.Close(ctx, "s1", "q1"))
+		assert.ErrorIs(t, started.Close(ctx, "s1", "q1"), domain.ErrRoundAlreadyProcessed)
+		assert.ErrorIs(t, started.CheckAnswerable(ctx, "s1", "q1", time.Now()), domain.ErrTimeExpired)
+
+		// 出題したサーバーのタイマーは発火しても結果処理を呼び出さない
+		time.Sleep(50*time.Millisecond + 1200*time.Millisecond)
+		assert.Zero(t, expired.Load())
+	})
+
+	t.Run("中止した問題は回答を受け付けず、結果処理はできること", func(t *testing.T) {
+		scheduler := usecase.NewRoundScheduler(repository.NewMemoryRoundStore())
+		require.NoError(t, scheduler.Start(ctx, "s1", "q1", time.Now(), 0, nil))
+		_, open, err := scheduler.Remaining(ctx, "s1", "q1", time.Now())
+		require.NoError(t, err)
+		assert.True(t, open)
+
+		require.NoError(t, scheduler.Cancel(ctx, "s1"))
+
+		assert.ErrorIs(t, scheduler.CheckAnswerable(ctx, "s1", "q1", time.Now()), domain.ErrTimeExpired)
+		_, open, _ = scheduler.Remaining(ctx, "s1", "q1", time.Now())
+		assert.False(t, open)
+		assert.NoError(t, scheduler.Close(ctx, "s1", "q1"))
+		assert.ErrorIs(t, scheduler.Close(ctx, "s1", "q1"), domain.ErrRoundAlreadyProcessed)
+	})
+
+	t.Run("回答者数は出題中の問題のみ数え、次の問題で数え直すこと", func(t *testing.T) {
+		scheduler := usecase.NewRoundScheduler(repository.NewMemoryRoundStore())
+		require.NoError(t, scheduler.Start(ctx, "s1", "q1", time.Now(), 0, nil))
+
+		scheduler.RecordAnswer(ctx, "s1", "q1")
+		answered, err := scheduler.RecordAnswer(ctx, "s1", "q1")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, answered)
+		_, err = scheduler.RecordAnswer(ctx, "s1", "q0")
+		assert.ErrorIs(t, err, domain.ErrRoundNotFound)
+
+		require.NoError(t, scheduler.Start(ctx, "s1", "q2", time.Now(), 0, nil))
+		answered, _ = scheduler.RecordAnswer(ctx, "s1", "q2")
+		assert.Equal(t, 1, answered)
+		assert.ErrorIs(t, scheduler.CheckAnswerable(ctx, "s1", "q1", time.Now()), domain.ErrTimeExpired)
+	})
+
+	t.Run("把握していない問題への回答は受け付けず、締め切れないこと", func(t *testing.T) {
+		scheduler := usecase.NewRoundScheduler(repository.NewMemoryRoundStore())
+
+		assert.ErrorIs(t, scheduler.CheckAnswerable(ctx, "s1", "q1", time.Now()), domain.ErrTimeExpired)
+		_, open, err := scheduler.Remaining(ctx, "s1", "q1", time.Now())
+		assert.NoError(t, err)
+		assert.False(t, open)
+		assert.ErrorIs(t, scheduler.Close(ctx, "s1", "q1"), domain.ErrRoundNotFound)
+	})
+
+	t.Run("前の問題の結果処理では出題中の問題のタイマーを止めないこと", func(t *testing.T) {
+		scheduler := usecase.NewRoundScheduler(repository.NewMemoryRoundStore())
+		var expired atomic.Int32
+		require.NoError(t, scheduler.Start(ctx, "s1", "q2", time.Now(), 50*time.Millisecond, func() { expired.Add(1) }))
+
+		assert.ErrorIs(t, scheduler.Close(ctx, "s1", "q1"), domain.ErrRoundNotFound)
+
+		assert.Eventually(t, func() bool { return expired.Load() == 1 }, 3*time.Second, 10*time.Millisecond)
+	})
+}
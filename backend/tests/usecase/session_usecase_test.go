@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quiz-app/internal/domain"
+	"quiz-app/internal/repository"
+	"quiz-app/internal/usecase"
+)
+
+// failingSessionRepository GetByID が常に失敗するセッションリポジトリ
+type failingSessionRepository struct {
+	repository.SessionRepository
+	err error
+}
+
+func (r *failingSessionRepository) GetByID(ctx context.Context, id string) (*domain.Session, error) {
+	return nil, r.err
+}
+
+func TestAuthorizeSessionAccess(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepositories()
+	session := &domain.Session{ID: "s1", Title: "テスト"}
+	require.NoError(t, repos.SessionRepo.Create(ctx, session))
+	require.NoError(t, repos.ParticipantRepo.Create(ctx, &domain.Participant{UserID: "u1", SessionID: "s1"}))
+	uc := usecase.NewSessionUseCase(repos.SessionRepo, repos.ParticipantRepo, repos.UserRepo, nil)
+
+	t.Run("参加者と管理者はアクセスできること", func(t *testing.T) {
+		assert.NoError(t, uc.AuthorizeSessionAccess(ctx, "s1", "u1", false))
+		assert.NoError(t, uc.AuthorizeSessionAccess(ctx, "s1", "admin", true))
+	})
+
+	t.Run("存在しないセッションと参加者以外は拒否すること", func(t *testing.T) {
+		assert.ErrorIs(t, uc.AuthorizeSessionAccess(ctx, "s0", "u1", false), domain.ErrSessionNotFound)
+		assert.ErrorIs(t, uc.AuthorizeSessionAccess(ctx, "s1", "u2", false), domain.ErrParticipantNotFound)
+	})
+
+	t.Run("リポジトリの障害は存在しない扱いにしないこと", func(t *testing.T) {
+		repoErr := errors.New("connection refused")
+		uc := usecase.NewSessionUseCase(&failingSessionRepository{err: repoErr}, repos.ParticipantRepo, repos.UserRepo, nil)
+
+		err := uc.AuthorizeSessionAccess(ctx, "s1", "u1", true)
+		assert.ErrorIs(t, err, repoErr)
+		assert.NotErrorIs(t, err, domain.ErrSessionNotFound)
+	})
+}
@@ -18,9 +18,12 @@ export interface JoinGameRequest {
 // Legacy alias for backward compatibility
 export interface JoinSessionRequest extends JoinGameRequest {}
 
+// 回答は問題形式に応じて selectedOption / selectedOptions / numericValue のいずれかを指定する
 export interface SubmitAnswerRequest {
   questionId: string;
-  selectedOption: number;
+  selectedOption?: number; // 単一選択・○×
+  selectedOptions?: number[]; // 複数選択（選んだ選択肢）・並べ替え（並べた順）
+  numericValue?: number; // 数値問題
   responseTime: number;
 }
 
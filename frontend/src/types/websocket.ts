@@ -1,6 +1,7 @@
 export interface WebSocketMessage {
   type: string;
   sessionId?: string;
+  seq?: number; // セッション全体への通知の通番（再接続時に resume の lastSeq として送る。回答者数など再送しない通知には付かない）
   data?: any;
   timestamp: number;
 }
@@ -9,6 +10,12 @@ export type MessageType =
   | 'question_start'
   | 'question_end'
   | 'answer_submitted'
+  | 'answer_ack'
+  | 'admin_control_result'
+  | 'resume_state'
+  | 'join_success'
+  | 'subscription_update'
+  | 'subscription_error'
   | 'round_result'
   | 'participant_join'
   | 'participant_leave'
@@ -19,6 +26,18 @@ export type MessageType =
   | 'ping'
   | 'pong';
 
+// 問題形式（未指定の問題は choice）
+export type QuestionType = 'choice' | 'true_false' | 'multi_select' | 'numeric' | 'ordering';
+
+// 問題文・選択肢に添付する画像・音声
+export interface Media {
+  key: string;
+  type: 'image' | 'audio';
+  contentType: string;
+  url: string;
+  altText?: string;
+}
+
 export interface QuestionStartMessage {
   question: {
     id: string;
@@ -26,13 +45,127 @@ export interface QuestionStartMessage {
     options: string[];
     round: number;
     category: string;
+    language: string; // 表示している言語（翻訳がない場合は問題の元の言語）
+    type: QuestionType;
+    unit: string; // 数値問題の単位
+    media?: Media;
+    optionMedia?: (Media | null)[]; // 選択肢と同じ順序（メディアのない選択肢は null）
   };
   timeLimit: number;
 }
 
 export interface QuestionEndMessage {
   questionId: string;
-  correctAnswer: number;
+  type: QuestionType;
+  correctAnswer: number; // 単一選択・○×の正解（それ以外の形式と非公開の場合は -1）
+  correctAnswers?: number[]; // 複数選択の正解・並べ替えの正しい順序
+  numericAnswer?: number; // 数値問題の正解
+  unit?: string;
+  explanation: string;
+}
+
+// 回答者数（誰が何を選んだかは送られない。一定の間隔でまとめて送られるため、途中の数は届かないことがある）
+export interface AnswerSubmittedMessage {
+  questionId: string;
+  answeredCount: number;
+}
+
+// answer_submit の受付結果（回答した本人にのみ送られる）
+export interface AnswerAckMessage {
+  questionId: string;
+  status: 'accepted' | 'duplicate' | 'too_late' | 'eliminated' | 'rejected';
+  reason?: string; // rejected の理由（not_participant, invalid_answer など）
+  // accepted / duplicate の場合のみ
+  answerId?: string;
+  isCorrect?: boolean;
+  pending?: boolean; // 締め切り時に採点される回答
+  points?: number;
+  responseTime?: number;
+}
+
+// admin_control で送るコマンド（管理者セッションでログインした接続のみ）
+export type AdminCommand = 'start' | 'finish' | 'next_round' | 'skip' | 'process_results' | 'revival';
+
+export interface AdminControlRequest {
+  requestId: string; // admin_control_result の requestId で応答を対応付ける
+  command: AdminCommand;
+  questionId?: string; // process_results のみ
+  count?: number; // revival のみ
+}
+
+// admin_control の結果（REST API の応答と同じ success / data / error）
+export interface AdminControlResultMessage {
+  requestId: string;
+  command: AdminCommand;
+  success: boolean;
+  data?: any;
+  error?: {
+    code: string;
+    message: string;
+  };
+}
+
+// 再接続時に送る resume の data（lastSeq は最後に受け取った seq。0 の場合は状態のみ受け取る）
+export interface ResumeRequest {
+  lastSeq: number;
+}
+
+// resume への応答（missedEvents を適用した後、snapshot を現在の状態として使う）
+export interface ResumeStateMessage {
+  seq: number; // 最新の通番（以降の通知は seq で重複を除く）
+  missedEvents: WebSocketMessage[];
+  truncated: boolean; // 見逃した通知の一部が再送できなかった
+  snapshot: {
+    session: {
+      id: string;
+      title: string;
+      status: string;
+      currentRound: number;
+      gameMode: string;
+      timeLimit: number;
+    };
+    question: {
+      question: QuestionStartMessage['question'];
+      timeLimit: number;
+      open: boolean; // 回答を受け付けているか
+      remainingTime: number; // 締め切りまでの残り時間（ミリ秒）
+    } | null;
+    participant: {
+      userId: string;
+      displayName: string;
+      status: string;
+      score: number;
+      correctAnswers: number;
+      lives?: number; // classic 以外
+    } | null;
+  } | null;
+}
+
+// join_session / subscribe_session / unsubscribe_session の data
+export interface SessionSubscriptionRequest {
+  sessionId: string;
+}
+
+// join_session の応答（sessions は通知を受け取っているセッションの一覧）
+export interface JoinSuccessMessage {
+  userId: string;
+  displayName: string;
+  sessions: string[];
+}
+
+// subscribe_session / unsubscribe_session の応答
+export interface SubscriptionUpdateMessage {
+  action: 'subscribe' | 'unsubscribe';
+  sessionId: string;
+  sessions: string[];
+}
+
+// 参加・購読できなかった場合（code は REST API のエラーコードと同じ）
+export interface SubscriptionErrorMessage {
+  action: 'join' | 'subscribe' | 'unsubscribe';
+  sessionId: string;
+  code: string;
+  message: string;
 }
 
 export interface RoundResultMessage {